@@ -2,48 +2,61 @@ package main
 
 import (
 	"context"
+	"fmt"
 	"log"
+	"log/slog"
 	"os"
 
 	"github.com/eminsonlu/salystic/internal/config"
 	"github.com/eminsonlu/salystic/internal/repo"
 	"github.com/eminsonlu/salystic/internal/service"
 	"github.com/eminsonlu/salystic/pkg/database"
+	"github.com/eminsonlu/salystic/pkg/logging"
 )
 
 func main() {
 	if len(os.Args) < 2 {
-		log.Fatal("Usage: go run cmd/import/main.go <json_file_path>")
+		fmt.Fprintln(os.Stderr, "Usage: go run cmd/import/main.go <json_file_path>")
+		os.Exit(2)
 	}
 
 	jsonFilePath := os.Args[1]
 
-	cfg, err := config.Load()
+	cfg, err := config.Load(os.Getenv("CONFIG_FILE"))
 	if err != nil {
 		log.Fatalf("Failed to load config: %v", err)
 	}
 
+	logger, err := logging.New(os.Stderr, cfg.LogLevel, cfg.LogFormat)
+	if err != nil {
+		log.Fatalf("Failed to create logger: %v", err)
+	}
+
 	db, err := database.NewMongoDB(cfg.MongoURI, cfg.MongoDB, cfg.MongoUser, cfg.MongoPass)
 	if err != nil {
-		log.Fatalf("Failed to connect to database: %v", err)
+		fatal(logger, "Failed to connect to database", err)
 	}
 	defer db.Close()
 
 	salaryRepo := repo.NewSalaryEntryRepository(db)
-	importService := service.NewDataImportService(salaryRepo)
+	importService := service.NewDataImportService(salaryRepo, logger)
 
 	ctx := context.Background()
 
-	log.Printf("Starting import from file: %s", jsonFilePath)
+	logger.InfoContext(ctx, "Starting import", "file", jsonFilePath)
 
-	log.Println("Analyzing tech stacks in the data...")
 	if err := importService.AnalyzeTechStacks(ctx, jsonFilePath); err != nil {
-		log.Printf("Warning: Failed to analyze tech stacks: %v", err)
+		logger.WarnContext(ctx, "Failed to analyze tech stacks", "error", err)
 	}
 
 	if err := importService.ImportFromJSON(ctx, jsonFilePath); err != nil {
-		log.Fatalf("Import failed: %v", err)
+		fatal(logger, "Import failed", err)
 	}
 
-	log.Println("Import completed successfully!")
-}
\ No newline at end of file
+	logger.InfoContext(ctx, "Import completed")
+}
+
+func fatal(logger *slog.Logger, msg string, err error) {
+	logger.Error(msg, "error", err)
+	os.Exit(1)
+}
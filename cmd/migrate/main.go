@@ -0,0 +1,171 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"text/tabwriter"
+	"time"
+
+	"github.com/eminsonlu/salystic/internal/config"
+	"github.com/eminsonlu/salystic/internal/migrations"
+	"github.com/eminsonlu/salystic/internal/repo"
+	"github.com/eminsonlu/salystic/pkg/database"
+	"github.com/eminsonlu/salystic/pkg/logging"
+	"github.com/eminsonlu/salystic/pkg/migrate"
+)
+
+const usage = `Usage: go run cmd/migrate/main.go [--config file] <command>
+
+Commands:
+  up              apply all pending migrations
+  down [-steps N] roll back the last N applied migrations (default 1)
+  status          list migrations and whether they are applied
+  indexes [-sync] report index drift against the declared definitions, optionally fixing it (MongoDB only)
+
+The database is chosen by STORAGE (mongo or postgres).
+`
+
+func main() {
+	configFile := flag.String("config", os.Getenv("CONFIG_FILE"), "path to a YAML config file")
+	flag.Usage = func() { fmt.Fprint(flag.CommandLine.Output(), usage) }
+	flag.Parse()
+
+	if flag.NArg() < 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	cfg, err := config.Load(*configFile)
+	if err != nil {
+		log.Fatalf("Failed to load config: %v", err)
+	}
+
+	logger, err := logging.New(os.Stderr, cfg.LogLevel, cfg.LogFormat)
+	if err != nil {
+		log.Fatalf("Failed to create logger: %v", err)
+	}
+
+	var (
+		migrator *migrate.Migrator
+		mongoDB  *database.MongoDB
+	)
+
+	switch cfg.Storage {
+	case config.StorageMemory:
+		log.Fatalf("STORAGE=%s has no migrations", config.StorageMemory)
+
+	case config.StoragePostgres:
+		pg, err := database.NewPostgres(cfg.PostgresURL)
+		if err != nil {
+			log.Fatalf("Failed to connect to database: %v", err)
+		}
+		defer pg.Close()
+
+		migrator, err = migrations.NewPostgresMigrator(pg, logger, cfg.MigrationsLockWait)
+		if err != nil {
+			log.Fatalf("Failed to set up migrations: %v", err)
+		}
+
+	default:
+		mongoDB, err = database.NewMongoDB(cfg.MongoURI, cfg.MongoDB, cfg.MongoUser, cfg.MongoPass)
+		if err != nil {
+			log.Fatalf("Failed to connect to database: %v", err)
+		}
+		defer mongoDB.Close()
+
+		migrator, err = migrations.NewMigrator(mongoDB, logger, cfg.MigrationsLockWait)
+		if err != nil {
+			log.Fatalf("Failed to set up migrations: %v", err)
+		}
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	switch command := flag.Arg(0); command {
+	case "up":
+		count, err := migrator.Up(ctx)
+		if err != nil {
+			log.Fatalf("Migration failed after applying %d: %v", count, err)
+		}
+		fmt.Printf("Applied %d migrations\n", count)
+
+	case "down":
+		downFlags := flag.NewFlagSet("down", flag.ExitOnError)
+		steps := downFlags.Int("steps", 1, "number of migrations to roll back")
+		downFlags.Parse(flag.Args()[1:])
+
+		if *steps < 1 {
+			log.Fatal("-steps must be at least 1")
+		}
+
+		count, err := migrator.Down(ctx, *steps)
+		if err != nil {
+			log.Fatalf("Rollback failed after rolling back %d: %v", count, err)
+		}
+		fmt.Printf("Rolled back %d migrations\n", count)
+
+	case "status":
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			log.Fatalf("Failed to load migration status: %v", err)
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "VERSION\tDESCRIPTION\tAPPLIED AT")
+		for _, status := range statuses {
+			appliedAt := "pending"
+			if status.AppliedAt != nil {
+				appliedAt = status.AppliedAt.Format(time.RFC3339)
+			}
+			fmt.Fprintf(w, "%d\t%s\t%s\n", status.Version, status.Description, appliedAt)
+		}
+		w.Flush()
+
+	case "indexes":
+		indexFlags := flag.NewFlagSet("indexes", flag.ExitOnError)
+		sync := indexFlags.Bool("sync", false, "build missing and mismatched indexes and drop extra ones")
+		indexFlags.Parse(flag.Args()[1:])
+
+		if mongoDB == nil {
+			log.Fatalf("The indexes command needs STORAGE=%s, PostgreSQL indexes are created by its migrations", config.StorageMongo)
+		}
+
+		indexRepo := repo.NewIndexRepo(mongoDB.Database, logger)
+		drift, err := indexRepo.CheckIndexes(ctx)
+		if err != nil {
+			log.Fatalf("Failed to check indexes: %v", err)
+		}
+
+		if len(drift) == 0 {
+			fmt.Println("Indexes match their definitions")
+			return
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "COLLECTION\tINDEX\tDRIFT\tDETAIL")
+		for _, d := range drift {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", d.Collection, d.Name, d.Kind, d.Detail)
+		}
+		w.Flush()
+
+		if !*sync {
+			os.Exit(1)
+		}
+
+		if err := indexRepo.SyncIndexes(ctx, drift); err != nil {
+			log.Fatalf("Failed to sync indexes: %v", err)
+		}
+		fmt.Printf("Synced %d indexes\n", len(drift))
+
+	default:
+		fmt.Fprintf(os.Stderr, "unknown command %q\n\n", command)
+		flag.Usage()
+		os.Exit(2)
+	}
+}
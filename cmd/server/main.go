@@ -2,17 +2,35 @@ package main
 
 import (
 	"context"
+	"errors"
+	"flag"
+	"fmt"
 	"log"
+	"log/slog"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"github.com/eminsonlu/salystic/internal/api/routes"
 	"github.com/eminsonlu/salystic/internal/auth"
 	"github.com/eminsonlu/salystic/internal/config"
+	"github.com/eminsonlu/salystic/internal/metrics"
+	"github.com/eminsonlu/salystic/internal/migrations"
 	"github.com/eminsonlu/salystic/internal/repo"
 	"github.com/eminsonlu/salystic/internal/service"
+	"github.com/eminsonlu/salystic/pkg/buildinfo"
 	"github.com/eminsonlu/salystic/pkg/database"
+	"github.com/eminsonlu/salystic/pkg/health"
+	"github.com/eminsonlu/salystic/pkg/logging"
+	"github.com/eminsonlu/salystic/pkg/migrate"
+	"github.com/eminsonlu/salystic/pkg/ratelimit"
+	"github.com/eminsonlu/salystic/pkg/tracing"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/labstack/echo/v4"
+	"go.mongodb.org/mongo-driver/event"
 )
 
 type CustomValidator struct {
@@ -24,46 +42,206 @@ func (cv *CustomValidator) Validate(i interface{}) error {
 }
 
 func main() {
-	cfg, err := config.Load()
+	configFile := flag.String("config", os.Getenv("CONFIG_FILE"), "path to a YAML config file")
+	printConfig := flag.Bool("print-config", false, "print the effective configuration with secrets redacted and exit")
+	flag.Parse()
+
+	cfg, err := config.Load(*configFile)
 	if err != nil {
 		log.Fatalf("Failed to load config: %v", err)
 	}
 
-	db, err := database.NewMongoDB(cfg.MongoURI, cfg.MongoDB, cfg.MongoUser, cfg.MongoPass)
-	if err != nil {
-		log.Fatalf("Failed to connect to MongoDB: %v", err)
+	if *printConfig {
+		if err := cfg.PrintRedacted(os.Stdout); err != nil {
+			log.Fatalf("Failed to print config: %v", err)
+		}
+		return
 	}
-	defer db.Close()
-
-	log.Println("Connected to MongoDB successfully")
 
-	constantsRepo := repo.NewConstantsRepository(db)
-	if err := constantsRepo.SeedConstants(context.Background()); err != nil {
-		log.Fatalf("Failed to seed constants: %v", err)
+	logger, err := logging.New(os.Stdout, cfg.LogLevel, cfg.LogFormat)
+	if err != nil {
+		log.Fatalf("Failed to create logger: %v", err)
 	}
-
-	indexRepo := repo.NewIndexRepo(db.Database)
-	if err := indexRepo.CreateAllIndexes(context.Background()); err != nil {
-		log.Fatalf("Failed to create indexes: %v", err)
+	slog.SetDefault(logger)
+
+	shutdownTracing, err := tracing.Setup(context.Background(), tracing.Options{
+		ServiceName:    cfg.TracingServiceName,
+		ServiceVersion: buildinfo.Version,
+		Environment:    cfg.Environment,
+		Exporter:       cfg.TracingExporter,
+		OTLPEndpoint:   cfg.TracingOTLPEndpoint,
+		OTLPInsecure:   cfg.TracingOTLPInsecure,
+		SampleRatio:    cfg.TracingSampleRatio,
+		Stdout:         os.Stdout,
+	})
+	if err != nil {
+		fatal(logger, "Failed to set up tracing", err)
 	}
 
-	userRepo := repo.NewUserRepository(db)
+	appMetrics := metrics.New()
+	checker := health.NewChecker(2 * time.Second)
+
+	var (
+		repos        *repo.Repositories
+		closeStorage = func() error { return nil }
+	)
+
+	switch cfg.Storage {
+	case config.StorageMemory:
+		logger.Warn("Using in-memory storage, all data is lost when the server stops")
+		repos = repo.NewMemoryRepositories(logger)
+		checker.AddStartupTask("constants_seeded", repos.Constants.SeedConstants)
+
+	case config.StoragePostgres:
+		pg, err := database.NewPostgres(cfg.PostgresURL)
+		if err != nil {
+			fatal(logger, "Failed to connect to PostgreSQL", err)
+		}
+		logger.Info("Connected to PostgreSQL")
+		closeStorage = pg.Close
+
+		checker.AddCheck("postgres", pg.Ping)
+
+		migrator, err := migrations.NewPostgresMigrator(pg, logger, cfg.MigrationsLockWait)
+		if err != nil {
+			fatal(logger, "Failed to set up migrations", err)
+		}
+		checker.AddStartupTask("migrations", runMigrations(migrator, cfg.MigrationsAutoApply))
+
+		repos = repo.NewPostgresRepositories(pg, logger)
+
+	default:
+		mongoMonitors := []*event.CommandMonitor{appMetrics.MongoMonitor()}
+		if cfg.TracingExporter != tracing.ExporterNone {
+			mongoMonitors = append(mongoMonitors, tracing.MongoMonitor())
+		}
+
+		db, err := database.NewMongoDB(cfg.MongoURI, cfg.MongoDB, cfg.MongoUser, cfg.MongoPass, mongoMonitors...)
+		if err != nil {
+			fatal(logger, "Failed to connect to MongoDB", err)
+		}
+		logger.Info("Connected to MongoDB", "database", cfg.MongoDB)
+		closeStorage = db.Close
+
+		checker.AddCheck("mongo", db.Ping)
+
+		migrator, err := migrations.NewMigrator(db, logger, cfg.MigrationsLockWait)
+		if err != nil {
+			fatal(logger, "Failed to set up migrations", err)
+		}
+		checker.AddStartupTask("migrations", runMigrations(migrator, cfg.MigrationsAutoApply))
+
+		indexRepo := repo.NewIndexRepo(db.Database, logger)
+		checker.AddStartupTask("indexes_verified", func(ctx context.Context) error {
+			return indexRepo.VerifyIndexes(ctx, cfg.IndexSync)
+		})
+
+		repos = repo.NewMongoRepositories(db, logger)
+	}
 
-	jwtManager, err := auth.NewJWTManager(cfg.JWTSecret, cfg.JWTExpiry, cfg.HMACSecret)
+	jwtManager, err := auth.NewJWTManager(cfg.JWTSecret, cfg.JWTExpiry.String(), cfg.HMACSecret)
 	if err != nil {
-		log.Fatalf("Failed to create JWT manager: %v", err)
+		fatal(logger, "Failed to create JWT manager", err)
 	}
 
+	handoffStore := auth.NewHandoffStore(cfg.AuthHandoffCodeTTL)
+
 	linkedinOAuth := auth.NewLinkedInOAuth(cfg.LinkedInClientID, cfg.LinkedInClientSecret, cfg.LinkedInRedirectURL, cfg.HMACSecret)
-	authService := service.NewAuthService(userRepo, linkedinOAuth, jwtManager)
+	authService := service.NewAuthService(repos.Users, repos.Sessions, linkedinOAuth, jwtManager, handoffStore, logger)
 
 	e := echo.New()
+	e.HideBanner = true
+	e.HidePort = true
 	e.Validator = &CustomValidator{validator: validator.New()}
 
-	routes.SetupRoutes(e, db, authService, cfg)
+	e.Server.ReadTimeout = cfg.ServerReadTimeout
+	e.Server.WriteTimeout = cfg.ServerWriteTimeout
+	e.Server.IdleTimeout = cfg.ServerIdleTimeout
+
+	rateLimitStore := ratelimit.NewMemoryStore(time.Minute)
+
+	closeRoutes := routes.SetupRoutes(e, repos, authService, cfg, rateLimitStore, logger, appMetrics, checker)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	serverErr := make(chan error, 1)
+	go func() {
+		logger.Info("Server starting", "port", cfg.Port, "version", buildinfo.Version)
+		if err := e.Start(fmt.Sprintf(":%d", cfg.Port)); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			serverErr <- err
+		}
+		close(serverErr)
+	}()
+
+	startupErr := make(chan error, 1)
+	go func() {
+		if err := checker.RunStartupTasks(ctx); err != nil {
+			startupErr <- err
+			return
+		}
+		logger.Info("Startup tasks completed, service is ready")
+	}()
+
+	exitCode := 0
+	select {
+	case err := <-serverErr:
+		if err != nil {
+			logger.Error("Server stopped unexpectedly", "error", err)
+			exitCode = 1
+		}
+	case err := <-startupErr:
+		logger.Error("Startup failed", "error", err)
+		exitCode = 1
+	case <-ctx.Done():
+		logger.Info("Shutdown signal received, draining in-flight requests")
+	}
+	stop()
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.ShutdownTimeout)
+	defer cancel()
+
+	if err := e.Shutdown(shutdownCtx); err != nil {
+		logger.Error("Failed to shut down server gracefully", "error", err)
+	}
+
+	closeRoutes()
+	rateLimitStore.Stop()
+	handoffStore.Stop()
+
+	if err := closeStorage(); err != nil {
+		logger.Error("Failed to close storage", "error", err)
+	}
+
+	if err := shutdownTracing(shutdownCtx); err != nil {
+		logger.Error("Failed to flush traces", "error", err)
+	}
+
+	logger.Info("Server stopped")
+	if exitCode != 0 {
+		os.Exit(exitCode)
+	}
+}
+
+func fatal(logger *slog.Logger, msg string, err error) {
+	logger.Error(msg, "error", err)
+	os.Exit(1)
+}
 
-	log.Printf("Server starting on port %s", cfg.Port)
-	if err := e.Start(":" + cfg.Port); err != nil {
-		log.Fatalf("Failed to start server: %v", err)
+func runMigrations(migrator *migrate.Migrator, autoApply bool) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		if autoApply {
+			_, err := migrator.Up(ctx)
+			return err
+		}
+
+		pending, err := migrator.Pending(ctx)
+		if err != nil {
+			return err
+		}
+		if len(pending) > 0 {
+			return fmt.Errorf("%d pending migrations, run cmd/migrate up", len(pending))
+		}
+		return nil
 	}
 }
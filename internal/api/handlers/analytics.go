@@ -1,6 +1,13 @@
 package handlers
 
 import (
+	"encoding/csv"
+	"log/slog"
+	"net/http"
+	"sort"
+	"strconv"
+
+	"github.com/eminsonlu/salystic/internal/model"
 	"github.com/eminsonlu/salystic/internal/service"
 	"github.com/eminsonlu/salystic/pkg/responses"
 	"github.com/labstack/echo/v4"
@@ -8,11 +15,28 @@ import (
 
 type AnalyticsHandler struct {
 	analyticsService *service.AnalyticsService
+	logger           *slog.Logger
 }
 
-func NewAnalyticsHandler(analyticsService *service.AnalyticsService) *AnalyticsHandler {
+func NewAnalyticsHandler(analyticsService *service.AnalyticsService, logger *slog.Logger) *AnalyticsHandler {
 	return &AnalyticsHandler{
 		analyticsService: analyticsService,
+		logger:           logger,
+	}
+}
+
+const invalidAnalyticsBasis = "basis must be net_monthly, gross_monthly, net_annual or gross_annual"
+
+// analyticsBasis reads the salary basis the general analytics are computed on,
+// defaulting to net monthly.
+func analyticsBasis(c echo.Context) (string, bool) {
+	switch basis := c.QueryParam("basis"); basis {
+	case "":
+		return model.SalaryBasisNetMonthly, true
+	case model.SalaryBasisNetMonthly, model.SalaryBasisGrossMonthly, model.SalaryBasisNetAnnual, model.SalaryBasisGrossAnnual:
+		return basis, true
+	default:
+		return "", false
 	}
 }
 
@@ -24,9 +48,23 @@ func (h *AnalyticsHandler) GetGeneralAnalytics(c echo.Context) error {
 		currency = "TRY"
 	}
 
-	analytics, err := h.analyticsService.GetGeneralAnalytics(c.Request().Context(), level, position, currency)
+	basis, ok := analyticsBasis(c)
+	if !ok {
+		return responses.BadRequest(c, invalidAnalyticsBasis)
+	}
+
+	// API keys with the read:analytics scope get the extra breakdowns.
+	if _, ok := c.Get("api_key").(*model.APIKey); ok {
+		analytics, err := h.analyticsService.GetDetailedAnalytics(c.Request().Context(), level, position, currency, basis)
+		if err != nil {
+			return internalServerError(c, h.logger, "Failed to get analytics", err)
+		}
+		return responses.Success(c, analytics)
+	}
+
+	analytics, err := h.analyticsService.GetGeneralAnalytics(c.Request().Context(), level, position, currency, basis)
 	if err != nil {
-		return responses.InternalServerError(c, "Failed to get analytics")
+		return internalServerError(c, h.logger, "Failed to get analytics", err)
 	}
 
 	return responses.Success(c, analytics)
@@ -35,7 +73,30 @@ func (h *AnalyticsHandler) GetGeneralAnalytics(c echo.Context) error {
 func (h *AnalyticsHandler) GetCareerAnalytics(c echo.Context) error {
 	analytics, err := h.analyticsService.GetCareerAnalytics(c.Request().Context())
 	if err != nil {
-		return responses.InternalServerError(c, "Failed to get career analytics")
+		return internalServerError(c, h.logger, "Failed to get career analytics", err)
+	}
+
+	return responses.Success(c, analytics)
+}
+
+func (h *AnalyticsHandler) GetCompensationAnalytics(c echo.Context) error {
+	currency := c.QueryParam("currency")
+	if currency == "" {
+		currency = "TRY"
+	}
+
+	basis := c.QueryParam("basis")
+	switch basis {
+	case "":
+		basis = model.SalaryBasisGrossAnnual
+	case model.SalaryBasisNetMonthly, model.SalaryBasisGrossAnnual:
+	default:
+		return responses.BadRequest(c, "basis must be net_monthly or gross_annual")
+	}
+
+	analytics, err := h.analyticsService.GetCompensationAnalytics(c.Request().Context(), currency, basis)
+	if err != nil {
+		return internalServerError(c, h.logger, "Failed to get compensation analytics", err)
 	}
 
 	return responses.Success(c, analytics)
@@ -44,7 +105,7 @@ func (h *AnalyticsHandler) GetCareerAnalytics(c echo.Context) error {
 func (h *AnalyticsHandler) GetAvailablePositions(c echo.Context) error {
 	positions, err := h.analyticsService.GetAvailablePositions(c.Request().Context())
 	if err != nil {
-		return responses.InternalServerError(c, "Failed to get available positions")
+		return internalServerError(c, h.logger, "Failed to get available positions", err)
 	}
 
 	return responses.Success(c, positions)
@@ -53,8 +114,77 @@ func (h *AnalyticsHandler) GetAvailablePositions(c echo.Context) error {
 func (h *AnalyticsHandler) GetAvailableLevels(c echo.Context) error {
 	levels, err := h.analyticsService.GetAvailableLevels(c.Request().Context())
 	if err != nil {
-		return responses.InternalServerError(c, "Failed to get available levels")
+		return internalServerError(c, h.logger, "Failed to get available levels", err)
 	}
 
 	return responses.Success(c, levels)
 }
+
+func (h *AnalyticsHandler) ExportAnalytics(c echo.Context) error {
+	level := c.QueryParam("level")
+	position := c.QueryParam("position")
+	currency := c.QueryParam("currency")
+	if currency == "" {
+		currency = "TRY"
+	}
+
+	basis, ok := analyticsBasis(c)
+	if !ok {
+		return responses.BadRequest(c, invalidAnalyticsBasis)
+	}
+
+	analytics, err := h.analyticsService.GetGeneralAnalytics(c.Request().Context(), level, position, currency, basis)
+	if err != nil {
+		return internalServerError(c, h.logger, "Failed to get analytics", err)
+	}
+
+	dimensions := []struct {
+		name string
+		avg  map[string]float64
+		min  map[string]float64
+		max  map[string]float64
+	}{
+		{"position", analytics.AverageSalaryByPosition, analytics.MinSalaryByPosition, analytics.MaxSalaryByPosition},
+		{"level", analytics.AverageSalaryByLevel, analytics.MinSalaryByLevel, analytics.MaxSalaryByLevel},
+		{"tech", analytics.AverageSalaryByTech, analytics.MinSalaryByTech, analytics.MaxSalaryByTech},
+		{"experience", analytics.AverageSalaryByExperience, analytics.MinSalaryByExperience, analytics.MaxSalaryByExperience},
+		{"company", analytics.AverageSalaryByCompany, analytics.MinSalaryByCompany, analytics.MaxSalaryByCompany},
+		{"city", analytics.AverageSalaryByCity, analytics.MinSalaryByCity, analytics.MaxSalaryByCity},
+		{"company_size", analytics.AverageSalaryByCompanySize, analytics.MinSalaryByCompanySize, analytics.MaxSalaryByCompanySize},
+		{"work_type", analytics.AverageSalaryByWorkType, analytics.MinSalaryByWorkType, analytics.MaxSalaryByWorkType},
+		{"currency", analytics.AverageSalaryByCurrency, analytics.MinSalaryByCurrency, analytics.MaxSalaryByCurrency},
+	}
+
+	c.Response().Header().Set(echo.HeaderContentType, "text/csv; charset=utf-8")
+	c.Response().Header().Set(echo.HeaderContentDisposition, `attachment; filename="analytics.csv"`)
+	c.Response().WriteHeader(http.StatusOK)
+
+	writer := csv.NewWriter(c.Response())
+	if err := writer.Write([]string{"dimension", "category", "average", "min", "max"}); err != nil {
+		return err
+	}
+
+	for _, dimension := range dimensions {
+		categories := make([]string, 0, len(dimension.avg))
+		for category := range dimension.avg {
+			categories = append(categories, category)
+		}
+		sort.Strings(categories)
+
+		for _, category := range categories {
+			record := []string{
+				dimension.name,
+				category,
+				strconv.FormatFloat(dimension.avg[category], 'f', 2, 64),
+				strconv.FormatFloat(dimension.min[category], 'f', 2, 64),
+				strconv.FormatFloat(dimension.max[category], 'f', 2, 64),
+			}
+			if err := writer.Write(record); err != nil {
+				return err
+			}
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
@@ -0,0 +1,77 @@
+package handlers
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/eminsonlu/salystic/internal/model"
+	"github.com/eminsonlu/salystic/internal/service"
+	"github.com/eminsonlu/salystic/pkg/responses"
+
+	"github.com/labstack/echo/v4"
+)
+
+type APIKeyHandler struct {
+	apiKeyService service.APIKeyService
+	logger        *slog.Logger
+}
+
+func NewAPIKeyHandler(apiKeyService service.APIKeyService, logger *slog.Logger) *APIKeyHandler {
+	return &APIKeyHandler{
+		apiKeyService: apiKeyService,
+		logger:        logger,
+	}
+}
+
+func (h *APIKeyHandler) CreateKey(c echo.Context) error {
+	userID := c.Get("user_id").(string)
+
+	var req model.CreateAPIKeyRequest
+	if err := c.Bind(&req); err != nil {
+		return responses.BadRequest(c, "Invalid request body")
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return responses.BadRequest(c, err.Error())
+	}
+
+	created, err := h.apiKeyService.CreateKey(c.Request().Context(), userID, &req)
+	if err != nil {
+		if err.Error() == "expiry must be in the future" {
+			return responses.BadRequest(c, "Expiry must be in the future")
+		}
+		return internalServerError(c, h.logger, "Failed to create API key", err)
+	}
+
+	return c.JSON(http.StatusCreated, responses.Response{
+		Success: true,
+		Message: "Store this key securely, it will not be shown again",
+		Data:    created,
+	})
+}
+
+func (h *APIKeyHandler) ListKeys(c echo.Context) error {
+	keys, err := h.apiKeyService.ListKeys(c.Request().Context())
+	if err != nil {
+		return internalServerError(c, h.logger, "Failed to list API keys", err)
+	}
+
+	return responses.Success(c, keys)
+}
+
+func (h *APIKeyHandler) RevokeKey(c echo.Context) error {
+	keyID := c.Param("id")
+
+	if keyID == "" {
+		return responses.BadRequest(c, "API key ID is required")
+	}
+
+	if err := h.apiKeyService.RevokeKey(c.Request().Context(), keyID); err != nil {
+		if err.Error() == "api key not found" {
+			return responses.NotFound(c, "API key not found")
+		}
+		return internalServerError(c, h.logger, "Failed to revoke API key", err)
+	}
+
+	return responses.SuccessWithMessage(c, "API key revoked successfully", nil)
+}
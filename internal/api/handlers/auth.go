@@ -4,9 +4,15 @@ import (
 	"crypto/rand"
 	"encoding/hex"
 	"fmt"
+	"log/slog"
 	"net/http"
 	"net/url"
+	"strings"
+	"time"
+
+	"github.com/eminsonlu/salystic/internal/auth"
 	"github.com/eminsonlu/salystic/internal/config"
+	"github.com/eminsonlu/salystic/internal/model"
 	"github.com/eminsonlu/salystic/internal/service"
 	"github.com/eminsonlu/salystic/pkg/responses"
 
@@ -16,31 +22,29 @@ import (
 type AuthHandler struct {
 	authService service.AuthService
 	config      *config.Config
+	logger      *slog.Logger
 }
 
-func NewAuthHandler(authService service.AuthService, cfg *config.Config) *AuthHandler {
+func NewAuthHandler(authService service.AuthService, cfg *config.Config, logger *slog.Logger) *AuthHandler {
 	return &AuthHandler{
 		authService: authService,
 		config:      cfg,
+		logger:      logger,
 	}
 }
 
 func (h *AuthHandler) LinkedInLogin(c echo.Context) error {
 	state, err := generateRandomState()
 	if err != nil {
-		return responses.InternalServerError(c, "Failed to generate state")
+		return internalServerError(c, h.logger, "Failed to generate state", err)
 	}
 
-	c.SetCookie(&http.Cookie{
-		Name:     "oauth_state",
-		Value:    state,
-		Path:     "/",
-		HttpOnly: true,
-		Secure:   false,
-		SameSite: http.SameSiteLaxMode,
-	})
+	codeVerifier := auth.GenerateCodeVerifier()
+
+	h.setCookie(c, "oauth_state", state, 600)
+	h.setCookie(c, "oauth_verifier", codeVerifier, 600)
 
-	authURL := h.authService.GetLinkedInAuthURL(state)
+	authURL := h.authService.GetLinkedInAuthURL(state, codeVerifier)
 
 	return c.Redirect(http.StatusFound, authURL)
 }
@@ -85,53 +89,161 @@ func (h *AuthHandler) LinkedInCallback(c echo.Context) error {
 		return c.Redirect(http.StatusFound, redirectURL)
 	}
 
-	c.SetCookie(&http.Cookie{
-		Name:   "oauth_state",
-		Value:  "",
-		Path:   "/",
-		MaxAge: -1,
-	})
+	codeVerifier := ""
+	if cookie, err := c.Cookie("oauth_verifier"); err == nil {
+		codeVerifier = cookie.Value
+	}
+
+	h.setCookie(c, "oauth_state", "", -1)
+	h.setCookie(c, "oauth_verifier", "", -1)
+
+	if codeVerifier == "" {
+		redirectURL := fmt.Sprintf("%s?error=missing_verifier&error_description=%s",
+			h.config.FrontendCallbackURL,
+			url.QueryEscape("PKCE code verifier is missing"))
+		return c.Redirect(http.StatusFound, redirectURL)
+	}
+
+	metadata := &model.SessionMetadata{
+		UserAgent: c.Request().UserAgent(),
+		IPAddress: c.RealIP(),
+	}
 
-	authResponse, err := h.authService.AuthenticateWithLinkedIn(c.Request().Context(), code)
+	authResponse, err := h.authService.AuthenticateWithLinkedIn(c.Request().Context(), code, codeVerifier, metadata)
 	if err != nil {
+		h.logger.WarnContext(c.Request().Context(), "LinkedIn authentication failed", "error", err)
 		redirectURL := fmt.Sprintf("%s?error=auth_failed&error_description=%s",
 			h.config.FrontendCallbackURL, url.QueryEscape(err.Error()))
 		return c.Redirect(http.StatusFound, redirectURL)
 	}
 
-	redirectURL := fmt.Sprintf("%s?success=true&token=%s&expires_in=%d",
+	if h.config.AuthHandoffMode == "cookie" {
+		maxAge := int(time.Until(time.Unix(authResponse.ExpiresIn, 0)).Seconds())
+		h.setCookie(c, auth.AccessTokenCookieName, authResponse.AccessToken, maxAge)
+
+		redirectURL := fmt.Sprintf("%s?success=true&expires_in=%d",
+			h.config.FrontendCallbackURL,
+			authResponse.ExpiresIn)
+		return c.Redirect(http.StatusFound, redirectURL)
+	}
+
+	handoffCode, err := h.authService.IssueHandoffCode(authResponse)
+	if err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "Failed to issue handoff code", "error", err)
+		redirectURL := fmt.Sprintf("%s?error=auth_failed&error_description=%s",
+			h.config.FrontendCallbackURL, url.QueryEscape("Failed to issue authorization code"))
+		return c.Redirect(http.StatusFound, redirectURL)
+	}
+
+	redirectURL := fmt.Sprintf("%s?success=true&code=%s",
 		h.config.FrontendCallbackURL,
-		url.QueryEscape(authResponse.AccessToken),
-		authResponse.ExpiresIn)
+		url.QueryEscape(handoffCode))
 
 	return c.Redirect(http.StatusFound, redirectURL)
 }
 
+func (h *AuthHandler) ExchangeCode(c echo.Context) error {
+	var req model.TokenExchangeRequest
+	if err := c.Bind(&req); err != nil {
+		return responses.BadRequest(c, "Invalid request body")
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return responses.BadRequest(c, err.Error())
+	}
+
+	authResponse, err := h.authService.RedeemHandoffCode(req.Code)
+	if err != nil {
+		return responses.Unauthorized(c, "Invalid or expired authorization code")
+	}
+
+	return responses.Success(c, authResponse)
+}
+
 func (h *AuthHandler) Me(c echo.Context) error {
 	userID := c.Get("user_id").(string)
 
 	user, err := h.authService.GetUserByID(c.Request().Context(), userID)
 	if err != nil {
-		return responses.InternalServerError(c, "Failed to get user information")
+		return internalServerError(c, h.logger, "Failed to get user information", err)
 	}
 
 	return responses.Success(c, user)
 }
 
+func (h *AuthHandler) ListSessions(c echo.Context) error {
+	userID := c.Get("user_id").(string)
+	sessionID, _ := c.Get("session_id").(string)
+
+	sessions, err := h.authService.ListSessions(c.Request().Context(), userID, sessionID)
+	if err != nil {
+		return internalServerError(c, h.logger, "Failed to get sessions", err)
+	}
+
+	return responses.Success(c, sessions)
+}
+
+func (h *AuthHandler) RevokeSession(c echo.Context) error {
+	userID := c.Get("user_id").(string)
+	sessionID := c.Param("id")
+
+	if sessionID == "" {
+		return responses.BadRequest(c, "Session ID is required")
+	}
+
+	if err := h.authService.RevokeSession(c.Request().Context(), userID, sessionID); err != nil {
+		if err.Error() == "session not found" {
+			return responses.NotFound(c, "Session not found")
+		}
+		return internalServerError(c, h.logger, "Failed to revoke session", err)
+	}
+
+	return responses.SuccessWithMessage(c, "Session revoked successfully", nil)
+}
+
 func (h *AuthHandler) Logout(c echo.Context) error {
 	userID := c.Get("user_id").(string)
+	sessionID, _ := c.Get("session_id").(string)
+
+	if err := h.authService.Logout(c.Request().Context(), userID, sessionID); err != nil {
+		return internalServerError(c, h.logger, "Failed to logout", err)
+	}
 
-	if err := h.authService.Logout(c.Request().Context(), userID); err != nil {
-		return responses.InternalServerError(c, "Failed to logout")
+	if _, err := c.Cookie(auth.AccessTokenCookieName); err == nil {
+		h.setCookie(c, auth.AccessTokenCookieName, "", -1)
 	}
 
 	return responses.SuccessWithMessage(c, "Successfully logged out", nil)
 }
 
+func (h *AuthHandler) setCookie(c echo.Context, name, value string, maxAge int) {
+	c.SetCookie(&http.Cookie{
+		Name:     name,
+		Value:    value,
+		Path:     "/",
+		Domain:   h.config.CookieDomain,
+		MaxAge:   maxAge,
+		HttpOnly: true,
+		Secure:   h.config.CookieSecure,
+		SameSite: parseSameSite(h.config.CookieSameSite),
+	})
+}
+
+func parseSameSite(value string) http.SameSite {
+	switch strings.ToLower(value) {
+	case "strict":
+		return http.SameSiteStrictMode
+	case "none":
+		return http.SameSiteNoneMode
+	default:
+		return http.SameSiteLaxMode
+	}
+}
+
 func generateRandomState() (string, error) {
 	bytes := make([]byte, 32)
 	if _, err := rand.Read(bytes); err != nil {
 		return "", err
 	}
 	return hex.EncodeToString(bytes), nil
-}
\ No newline at end of file
+}
@@ -3,13 +3,18 @@ package handlers
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/eminsonlu/salystic/internal/config"
 	"github.com/eminsonlu/salystic/internal/model"
+	"github.com/eminsonlu/salystic/pkg/logging"
 
+	"github.com/go-playground/validator/v10"
 	"github.com/labstack/echo/v4"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -20,13 +25,26 @@ type MockAuthService struct {
 	mock.Mock
 }
 
-func (m *MockAuthService) GetLinkedInAuthURL(state string) string {
-	args := m.Called(state)
+func (m *MockAuthService) GetLinkedInAuthURL(state, codeVerifier string) string {
+	args := m.Called(state, codeVerifier)
 	return args.String(0)
 }
 
-func (m *MockAuthService) AuthenticateWithLinkedIn(ctx context.Context, code string) (*model.AuthResponse, error) {
-	args := m.Called(ctx, code)
+func (m *MockAuthService) AuthenticateWithLinkedIn(ctx context.Context, code, codeVerifier string, metadata *model.SessionMetadata) (*model.AuthResponse, error) {
+	args := m.Called(ctx, code, codeVerifier, metadata)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.AuthResponse), args.Error(1)
+}
+
+func (m *MockAuthService) IssueHandoffCode(authResponse *model.AuthResponse) (string, error) {
+	args := m.Called(authResponse)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockAuthService) RedeemHandoffCode(code string) (*model.AuthResponse, error) {
+	args := m.Called(code)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
@@ -41,6 +59,24 @@ func (m *MockAuthService) ValidateToken(tokenString string) (*model.JWTClaims, e
 	return args.Get(0).(*model.JWTClaims), args.Error(1)
 }
 
+func (m *MockAuthService) ValidateSession(ctx context.Context, claims *model.JWTClaims) error {
+	args := m.Called(ctx, claims)
+	return args.Error(0)
+}
+
+func (m *MockAuthService) ListSessions(ctx context.Context, userID, currentSessionID string) ([]*model.Session, error) {
+	args := m.Called(ctx, userID, currentSessionID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*model.Session), args.Error(1)
+}
+
+func (m *MockAuthService) RevokeSession(ctx context.Context, userID, sessionID string) error {
+	args := m.Called(ctx, userID, sessionID)
+	return args.Error(0)
+}
+
 func (m *MockAuthService) GetUserByID(ctx context.Context, userID string) (*model.User, error) {
 	args := m.Called(ctx, userID)
 	if args.Get(0) == nil {
@@ -49,8 +85,8 @@ func (m *MockAuthService) GetUserByID(ctx context.Context, userID string) (*mode
 	return args.Get(0).(*model.User), args.Error(1)
 }
 
-func (m *MockAuthService) Logout(ctx context.Context, userID string) error {
-	args := m.Called(ctx, userID)
+func (m *MockAuthService) Logout(ctx context.Context, userID, sessionID string) error {
+	args := m.Called(ctx, userID, sessionID)
 	return args.Error(0)
 }
 
@@ -58,7 +94,7 @@ func TestNewAuthHandler(t *testing.T) {
 	mockService := &MockAuthService{}
 	cfg := &config.Config{}
 
-	handler := NewAuthHandler(mockService, cfg)
+	handler := NewAuthHandler(mockService, cfg, logging.NewNop())
 
 	assert.NotNil(t, handler)
 	assert.Equal(t, mockService, handler.authService)
@@ -69,9 +105,9 @@ func TestLinkedInLogin(t *testing.T) {
 	e := echo.New()
 	mockService := &MockAuthService{}
 	cfg := &config.Config{}
-	handler := NewAuthHandler(mockService, cfg)
+	handler := NewAuthHandler(mockService, cfg, logging.NewNop())
 
-	mockService.On("GetLinkedInAuthURL", mock.AnythingOfType("string")).Return("https://linkedin.com/auth")
+	mockService.On("GetLinkedInAuthURL", mock.AnythingOfType("string"), mock.AnythingOfType("string")).Return("https://linkedin.com/auth")
 
 	req := httptest.NewRequest(http.MethodGet, "/auth/linkedin", nil)
 	rec := httptest.NewRecorder()
@@ -84,10 +120,13 @@ func TestLinkedInLogin(t *testing.T) {
 	assert.Equal(t, "https://linkedin.com/auth", rec.Header().Get("Location"))
 
 	cookies := rec.Result().Cookies()
-	assert.Len(t, cookies, 1)
+	assert.Len(t, cookies, 2)
 	assert.Equal(t, "oauth_state", cookies[0].Name)
 	assert.NotEmpty(t, cookies[0].Value)
 	assert.True(t, cookies[0].HttpOnly)
+	assert.Equal(t, "oauth_verifier", cookies[1].Name)
+	assert.NotEmpty(t, cookies[1].Value)
+	assert.True(t, cookies[1].HttpOnly)
 
 	mockService.AssertExpectations(t)
 }
@@ -98,7 +137,7 @@ func TestLinkedInCallback_Success(t *testing.T) {
 	cfg := &config.Config{
 		FrontendCallbackURL: "http://frontend.com/callback",
 	}
-	handler := NewAuthHandler(mockService, cfg)
+	handler := NewAuthHandler(mockService, cfg, logging.NewNop())
 
 	mockAuthResponse := &model.AuthResponse{
 		User: &model.User{
@@ -109,10 +148,50 @@ func TestLinkedInCallback_Success(t *testing.T) {
 		ExpiresIn:   3600,
 	}
 
-	mockService.On("AuthenticateWithLinkedIn", mock.Anything, "auth_code").Return(mockAuthResponse, nil)
+	mockService.On("AuthenticateWithLinkedIn", mock.Anything, "auth_code", "test_verifier", mock.AnythingOfType("*model.SessionMetadata")).Return(mockAuthResponse, nil)
+	mockService.On("IssueHandoffCode", mockAuthResponse).Return("handoff_code", nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/auth/linkedin/callback?code=auth_code&state=test_state", nil)
+	req.AddCookie(&http.Cookie{Name: "oauth_state", Value: "test_state"})
+	req.AddCookie(&http.Cookie{Name: "oauth_verifier", Value: "test_verifier"})
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	err := handler.LinkedInCallback(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusFound, rec.Code)
+	assert.Contains(t, rec.Header().Get("Location"), "success=true")
+	assert.Contains(t, rec.Header().Get("Location"), "code=handoff_code")
+	assert.NotContains(t, rec.Header().Get("Location"), "access_token")
+
+	mockService.AssertExpectations(t)
+}
+
+func TestLinkedInCallback_CookieMode(t *testing.T) {
+	e := echo.New()
+	mockService := &MockAuthService{}
+	cfg := &config.Config{
+		FrontendCallbackURL: "http://frontend.com/callback",
+		AuthHandoffMode:     "cookie",
+		CookieSecure:        true,
+	}
+	handler := NewAuthHandler(mockService, cfg, logging.NewNop())
+
+	mockAuthResponse := &model.AuthResponse{
+		User: &model.User{
+			ID:              primitive.NewObjectID(),
+			PseudonymizedID: "pseudo123",
+		},
+		AccessToken: "access_token",
+		ExpiresIn:   time.Now().Add(time.Hour).Unix(),
+	}
+
+	mockService.On("AuthenticateWithLinkedIn", mock.Anything, "auth_code", "test_verifier", mock.AnythingOfType("*model.SessionMetadata")).Return(mockAuthResponse, nil)
 
 	req := httptest.NewRequest(http.MethodGet, "/auth/linkedin/callback?code=auth_code&state=test_state", nil)
 	req.AddCookie(&http.Cookie{Name: "oauth_state", Value: "test_state"})
+	req.AddCookie(&http.Cookie{Name: "oauth_verifier", Value: "test_verifier"})
 	rec := httptest.NewRecorder()
 	c := e.NewContext(req, rec)
 
@@ -121,19 +200,102 @@ func TestLinkedInCallback_Success(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, http.StatusFound, rec.Code)
 	assert.Contains(t, rec.Header().Get("Location"), "success=true")
-	assert.Contains(t, rec.Header().Get("Location"), "token=access_token")
-	assert.Contains(t, rec.Header().Get("Location"), "expires_in=3600")
+	assert.NotContains(t, rec.Header().Get("Location"), "token=")
+
+	var sessionCookie *http.Cookie
+	for _, cookie := range rec.Result().Cookies() {
+		if cookie.Name == "access_token" {
+			sessionCookie = cookie
+		}
+	}
+	assert.NotNil(t, sessionCookie)
+	assert.Equal(t, "access_token", sessionCookie.Value)
+	assert.True(t, sessionCookie.HttpOnly)
+	assert.True(t, sessionCookie.Secure)
+
+	mockService.AssertExpectations(t)
+}
+
+func TestLinkedInCallback_MissingVerifier(t *testing.T) {
+	e := echo.New()
+	mockService := &MockAuthService{}
+	cfg := &config.Config{
+		FrontendCallbackURL: "http://frontend.com/callback",
+	}
+	handler := NewAuthHandler(mockService, cfg, logging.NewNop())
+
+	req := httptest.NewRequest(http.MethodGet, "/auth/linkedin/callback?code=auth_code&state=test_state", nil)
+	req.AddCookie(&http.Cookie{Name: "oauth_state", Value: "test_state"})
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	err := handler.LinkedInCallback(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusFound, rec.Code)
+	assert.Contains(t, rec.Header().Get("Location"), "error=missing_verifier")
+	mockService.AssertNotCalled(t, "AuthenticateWithLinkedIn", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestExchangeCode_Success(t *testing.T) {
+	e := echo.New()
+	e.Validator = &CustomValidator{validator: validator.New()}
+	mockService := &MockAuthService{}
+	handler := NewAuthHandler(mockService, &config.Config{}, logging.NewNop())
+
+	mockAuthResponse := &model.AuthResponse{
+		AccessToken: "access_token",
+		TokenType:   "Bearer",
+		ExpiresIn:   3600,
+	}
+	mockService.On("RedeemHandoffCode", "handoff_code").Return(mockAuthResponse, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/auth/token", strings.NewReader(`{"code":"handoff_code"}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	err := handler.ExchangeCode(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var response map[string]interface{}
+	err = json.Unmarshal(rec.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.True(t, response["success"].(bool))
+	assert.Equal(t, "access_token", response["data"].(map[string]interface{})["access_token"])
 
 	mockService.AssertExpectations(t)
 }
 
+func TestExchangeCode_InvalidCode(t *testing.T) {
+	e := echo.New()
+	e.Validator = &CustomValidator{validator: validator.New()}
+	mockService := &MockAuthService{}
+	handler := NewAuthHandler(mockService, &config.Config{}, logging.NewNop())
+
+	mockService.On("RedeemHandoffCode", "bad_code").Return(nil, errors.New("invalid or expired handoff code"))
+
+	req := httptest.NewRequest(http.MethodPost, "/auth/token", strings.NewReader(`{"code":"bad_code"}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	err := handler.ExchangeCode(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	mockService.AssertExpectations(t)
+}
+
 func TestLinkedInCallback_MissingCode(t *testing.T) {
 	e := echo.New()
 	mockService := &MockAuthService{}
 	cfg := &config.Config{
 		FrontendCallbackURL: "http://frontend.com/callback",
 	}
-	handler := NewAuthHandler(mockService, cfg)
+	handler := NewAuthHandler(mockService, cfg, logging.NewNop())
 
 	req := httptest.NewRequest(http.MethodGet, "/auth/linkedin/callback?state=test_state", nil)
 	rec := httptest.NewRecorder()
@@ -152,7 +314,7 @@ func TestLinkedInCallback_InvalidState(t *testing.T) {
 	cfg := &config.Config{
 		FrontendCallbackURL: "http://frontend.com/callback",
 	}
-	handler := NewAuthHandler(mockService, cfg)
+	handler := NewAuthHandler(mockService, cfg, logging.NewNop())
 
 	req := httptest.NewRequest(http.MethodGet, "/auth/linkedin/callback?code=auth_code&state=invalid_state", nil)
 	req.AddCookie(&http.Cookie{Name: "oauth_state", Value: "correct_state"})
@@ -170,7 +332,7 @@ func TestMe_Success(t *testing.T) {
 	e := echo.New()
 	mockService := &MockAuthService{}
 	cfg := &config.Config{}
-	handler := NewAuthHandler(mockService, cfg)
+	handler := NewAuthHandler(mockService, cfg, logging.NewNop())
 
 	userID := primitive.NewObjectID().Hex()
 	mockUser := &model.User{
@@ -203,16 +365,18 @@ func TestLogout_Success(t *testing.T) {
 	e := echo.New()
 	mockService := &MockAuthService{}
 	cfg := &config.Config{}
-	handler := NewAuthHandler(mockService, cfg)
+	handler := NewAuthHandler(mockService, cfg, logging.NewNop())
 
 	userID := primitive.NewObjectID().Hex()
+	sessionID := primitive.NewObjectID().Hex()
 
-	mockService.On("Logout", mock.Anything, userID).Return(nil)
+	mockService.On("Logout", mock.Anything, userID, sessionID).Return(nil)
 
 	req := httptest.NewRequest(http.MethodPost, "/auth/logout", nil)
 	rec := httptest.NewRecorder()
 	c := e.NewContext(req, rec)
 	c.Set("user_id", userID)
+	c.Set("session_id", sessionID)
 
 	err := handler.Logout(c)
 
@@ -228,6 +392,89 @@ func TestLogout_Success(t *testing.T) {
 	mockService.AssertExpectations(t)
 }
 
+func TestListSessions_Success(t *testing.T) {
+	e := echo.New()
+	mockService := &MockAuthService{}
+	handler := NewAuthHandler(mockService, &config.Config{}, logging.NewNop())
+
+	userID := primitive.NewObjectID().Hex()
+	sessionID := primitive.NewObjectID()
+	sessions := []*model.Session{
+		{ID: sessionID, UserAgent: "Mozilla/5.0", Current: true},
+		{ID: primitive.NewObjectID(), UserAgent: "curl/8.0"},
+	}
+
+	mockService.On("ListSessions", mock.Anything, userID, sessionID.Hex()).Return(sessions, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/auth/sessions", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set("user_id", userID)
+	c.Set("session_id", sessionID.Hex())
+
+	err := handler.ListSessions(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var response map[string]interface{}
+	err = json.Unmarshal(rec.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	data := response["data"].([]interface{})
+	assert.Len(t, data, 2)
+	assert.Equal(t, true, data[0].(map[string]interface{})["current"])
+
+	mockService.AssertExpectations(t)
+}
+
+func TestRevokeSession_Success(t *testing.T) {
+	e := echo.New()
+	mockService := &MockAuthService{}
+	handler := NewAuthHandler(mockService, &config.Config{}, logging.NewNop())
+
+	userID := primitive.NewObjectID().Hex()
+	sessionID := primitive.NewObjectID().Hex()
+
+	mockService.On("RevokeSession", mock.Anything, userID, sessionID).Return(nil)
+
+	req := httptest.NewRequest(http.MethodDelete, "/auth/sessions/"+sessionID, nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set("user_id", userID)
+	c.SetParamNames("id")
+	c.SetParamValues(sessionID)
+
+	err := handler.RevokeSession(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestRevokeSession_NotFound(t *testing.T) {
+	e := echo.New()
+	mockService := &MockAuthService{}
+	handler := NewAuthHandler(mockService, &config.Config{}, logging.NewNop())
+
+	userID := primitive.NewObjectID().Hex()
+	sessionID := primitive.NewObjectID().Hex()
+
+	mockService.On("RevokeSession", mock.Anything, userID, sessionID).Return(errors.New("session not found"))
+
+	req := httptest.NewRequest(http.MethodDelete, "/auth/sessions/"+sessionID, nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set("user_id", userID)
+	c.SetParamNames("id")
+	c.SetParamValues(sessionID)
+
+	err := handler.RevokeSession(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	mockService.AssertExpectations(t)
+}
+
 func TestGenerateRandomState(t *testing.T) {
 	state1, err1 := generateRandomState()
 	state2, err2 := generateRandomState()
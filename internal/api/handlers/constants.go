@@ -1,6 +1,8 @@
 package handlers
 
 import (
+	"log/slog"
+
 	"github.com/eminsonlu/salystic/internal/repo"
 	"github.com/eminsonlu/salystic/pkg/responses"
 
@@ -9,18 +11,20 @@ import (
 
 type ConstantsHandler struct {
 	constantsRepo repo.ConstantsRepository
+	logger        *slog.Logger
 }
 
-func NewConstantsHandler(constantsRepo repo.ConstantsRepository) *ConstantsHandler {
+func NewConstantsHandler(constantsRepo repo.ConstantsRepository, logger *slog.Logger) *ConstantsHandler {
 	return &ConstantsHandler{
 		constantsRepo: constantsRepo,
+		logger:        logger,
 	}
 }
 
 func (h *ConstantsHandler) GetPositions(c echo.Context) error {
 	positions, err := h.constantsRepo.GetPositions(c.Request().Context())
 	if err != nil {
-		return responses.InternalServerError(c, "Failed to get positions")
+		return internalServerError(c, h.logger, "Failed to get positions", err)
 	}
 
 	return responses.Success(c, positions)
@@ -29,7 +33,7 @@ func (h *ConstantsHandler) GetPositions(c echo.Context) error {
 func (h *ConstantsHandler) GetLevels(c echo.Context) error {
 	levels, err := h.constantsRepo.GetLevels(c.Request().Context())
 	if err != nil {
-		return responses.InternalServerError(c, "Failed to get levels")
+		return internalServerError(c, h.logger, "Failed to get levels", err)
 	}
 
 	return responses.Success(c, levels)
@@ -38,7 +42,7 @@ func (h *ConstantsHandler) GetLevels(c echo.Context) error {
 func (h *ConstantsHandler) GetTechStacks(c echo.Context) error {
 	techStacks, err := h.constantsRepo.GetTechStacks(c.Request().Context())
 	if err != nil {
-		return responses.InternalServerError(c, "Failed to get tech stacks")
+		return internalServerError(c, h.logger, "Failed to get tech stacks", err)
 	}
 
 	return responses.Success(c, techStacks)
@@ -47,7 +51,7 @@ func (h *ConstantsHandler) GetTechStacks(c echo.Context) error {
 func (h *ConstantsHandler) GetExperiences(c echo.Context) error {
 	experiences, err := h.constantsRepo.GetExperiences(c.Request().Context())
 	if err != nil {
-		return responses.InternalServerError(c, "Failed to get experiences")
+		return internalServerError(c, h.logger, "Failed to get experiences", err)
 	}
 
 	return responses.Success(c, experiences)
@@ -56,7 +60,7 @@ func (h *ConstantsHandler) GetExperiences(c echo.Context) error {
 func (h *ConstantsHandler) GetCompanies(c echo.Context) error {
 	companies, err := h.constantsRepo.GetCompanies(c.Request().Context())
 	if err != nil {
-		return responses.InternalServerError(c, "Failed to get companies")
+		return internalServerError(c, h.logger, "Failed to get companies", err)
 	}
 
 	return responses.Success(c, companies)
@@ -65,7 +69,7 @@ func (h *ConstantsHandler) GetCompanies(c echo.Context) error {
 func (h *ConstantsHandler) GetCompanySizes(c echo.Context) error {
 	companySizes, err := h.constantsRepo.GetCompanySizes(c.Request().Context())
 	if err != nil {
-		return responses.InternalServerError(c, "Failed to get company sizes")
+		return internalServerError(c, h.logger, "Failed to get company sizes", err)
 	}
 
 	return responses.Success(c, companySizes)
@@ -74,7 +78,7 @@ func (h *ConstantsHandler) GetCompanySizes(c echo.Context) error {
 func (h *ConstantsHandler) GetWorkTypes(c echo.Context) error {
 	workTypes, err := h.constantsRepo.GetWorkTypes(c.Request().Context())
 	if err != nil {
-		return responses.InternalServerError(c, "Failed to get work types")
+		return internalServerError(c, h.logger, "Failed to get work types", err)
 	}
 
 	return responses.Success(c, workTypes)
@@ -83,7 +87,7 @@ func (h *ConstantsHandler) GetWorkTypes(c echo.Context) error {
 func (h *ConstantsHandler) GetCities(c echo.Context) error {
 	cities, err := h.constantsRepo.GetCities(c.Request().Context())
 	if err != nil {
-		return responses.InternalServerError(c, "Failed to get cities")
+		return internalServerError(c, h.logger, "Failed to get cities", err)
 	}
 
 	return responses.Success(c, cities)
@@ -92,7 +96,7 @@ func (h *ConstantsHandler) GetCities(c echo.Context) error {
 func (h *ConstantsHandler) GetCurrencies(c echo.Context) error {
 	currencies, err := h.constantsRepo.GetCurrencies(c.Request().Context())
 	if err != nil {
-		return responses.InternalServerError(c, "Failed to get currencies")
+		return internalServerError(c, h.logger, "Failed to get currencies", err)
 	}
 
 	return responses.Success(c, currencies)
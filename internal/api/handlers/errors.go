@@ -0,0 +1,18 @@
+package handlers
+
+import (
+	"log/slog"
+
+	"github.com/eminsonlu/salystic/pkg/responses"
+
+	"github.com/labstack/echo/v4"
+)
+
+func internalServerError(c echo.Context, logger *slog.Logger, message string, err error) error {
+	logger.ErrorContext(c.Request().Context(), message,
+		"error", err,
+		"method", c.Request().Method,
+		"route", c.Path(),
+	)
+	return responses.InternalServerError(c, message)
+}
@@ -1,33 +1,60 @@
 package handlers
 
 import (
-	"github.com/eminsonlu/salystic/pkg/database"
+	"github.com/eminsonlu/salystic/pkg/buildinfo"
+	"github.com/eminsonlu/salystic/pkg/health"
 	"github.com/eminsonlu/salystic/pkg/responses"
 
 	"github.com/labstack/echo/v4"
 )
 
+const mongoCheckName = "mongo"
+
 type HealthHandler struct {
-	db *database.MongoDB
+	checker *health.Checker
 }
 
-func NewHealthHandler(db *database.MongoDB) *HealthHandler {
+func NewHealthHandler(checker *health.Checker) *HealthHandler {
 	return &HealthHandler{
-		db: db,
+		checker: checker,
 	}
 }
 
-func (h *HealthHandler) Health(c echo.Context) error {
-	dbStatus := "connected"
-	if err := h.db.Health(); err != nil {
-		dbStatus = "disconnected"
+func (h *HealthHandler) Live(c echo.Context) error {
+	return responses.Success(c, newHealthResponse("ok", nil))
+}
+
+func (h *HealthHandler) Ready(c echo.Context) error {
+	report := h.checker.Ready(c.Request().Context())
+	if !report.Ready {
+		return responses.ServiceUnavailable(c, "Service is not ready", newHealthResponse("unavailable", report.Checks))
 	}
+	return responses.Success(c, newHealthResponse("ok", report.Checks))
+}
+
+func (h *HealthHandler) Health(c echo.Context) error {
+	report := h.checker.Ready(c.Request().Context())
 
-	healthData := responses.HealthResponse{
-		Status:   "ok",
-		Database: dbStatus,
-		Version:  "1.0.0",
+	healthData := newHealthResponse("ok", report.Checks)
+	healthData.Database = "connected"
+	if report.Checks[mongoCheckName] != health.StatusOK {
+		healthData.Database = "disconnected"
 	}
 
+	if !report.Ready {
+		healthData.Status = "unavailable"
+		return responses.ServiceUnavailable(c, "Service is not ready", healthData)
+	}
 	return responses.Success(c, healthData)
-}
\ No newline at end of file
+}
+
+func newHealthResponse(status string, checks map[string]string) responses.HealthResponse {
+	info := buildinfo.Get()
+	return responses.HealthResponse{
+		Status:    status,
+		Version:   info.Version,
+		Commit:    info.Commit,
+		BuildTime: info.BuildTime,
+		Checks:    checks,
+	}
+}
@@ -1,20 +1,82 @@
 package handlers
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
 	"testing"
+	"time"
 
+	"github.com/eminsonlu/salystic/pkg/health"
+
+	"github.com/labstack/echo/v4"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestNewHealthHandler(t *testing.T) {
-	handler := NewHealthHandler(nil)
-	
+	handler := NewHealthHandler(health.NewChecker(time.Second))
+
 	assert.NotNil(t, handler)
 }
 
-func TestHealthHandler_Structure(t *testing.T) {
-	handler := &HealthHandler{db: nil}
-	
-	assert.NotNil(t, handler)
-	assert.Nil(t, handler.db)
+func serveHealth(t *testing.T, handlerFunc echo.HandlerFunc) (int, map[string]interface{}) {
+	e := echo.New()
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httptest.NewRequest(http.MethodGet, "/", nil), rec)
+
+	require.NoError(t, handlerFunc(c))
+
+	var response map[string]interface{}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	return rec.Code, response
+}
+
+func TestHealthHandler_LiveIgnoresDependencies(t *testing.T) {
+	checker := health.NewChecker(time.Second)
+	checker.AddCheck("mongo", func(ctx context.Context) error { return errors.New("down") })
+	handler := NewHealthHandler(checker)
+
+	code, response := serveHealth(t, handler.Live)
+
+	assert.Equal(t, http.StatusOK, code)
+	data := response["data"].(map[string]interface{})
+	assert.Equal(t, "ok", data["status"])
+	assert.NotEmpty(t, data["version"])
+	assert.NotEmpty(t, data["commit"])
+}
+
+func TestHealthHandler_ReadyWaitsForStartupTasks(t *testing.T) {
+	checker := health.NewChecker(time.Second)
+	checker.AddCheck("mongo", func(ctx context.Context) error { return nil })
+	checker.AddStartupTask("indexes_created", func(ctx context.Context) error { return nil })
+	handler := NewHealthHandler(checker)
+
+	code, response := serveHealth(t, handler.Ready)
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	checks := response["data"].(map[string]interface{})["checks"].(map[string]interface{})
+	assert.Equal(t, health.StatusPending, checks["indexes_created"])
+	assert.Equal(t, health.StatusOK, checks["mongo"])
+
+	require.NoError(t, checker.RunStartupTasks(context.Background()))
+
+	code, response = serveHealth(t, handler.Ready)
+	assert.Equal(t, http.StatusOK, code)
+	assert.True(t, response["success"].(bool))
+}
+
+func TestHealthHandler_HealthReportsDatabaseDown(t *testing.T) {
+	checker := health.NewChecker(time.Second)
+	checker.AddCheck("mongo", func(ctx context.Context) error { return errors.New("connection refused") })
+	handler := NewHealthHandler(checker)
+
+	code, response := serveHealth(t, handler.Health)
+
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	data := response["data"].(map[string]interface{})
+	assert.Equal(t, "unavailable", data["status"])
+	assert.Equal(t, "disconnected", data["database"])
+	assert.Equal(t, health.StatusFailed, data["checks"].(map[string]interface{})["mongo"])
 }
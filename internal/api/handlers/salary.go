@@ -1,22 +1,28 @@
 package handlers
 
 import (
+	"fmt"
+	"log/slog"
 	"net/http"
+	"strconv"
 
 	"github.com/eminsonlu/salystic/internal/model"
 	"github.com/eminsonlu/salystic/internal/service"
 	"github.com/eminsonlu/salystic/pkg/responses"
 
 	"github.com/labstack/echo/v4"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 type SalaryHandler struct {
 	salaryService service.SalaryEntryService
+	logger        *slog.Logger
 }
 
-func NewSalaryHandler(salaryService service.SalaryEntryService) *SalaryHandler {
+func NewSalaryHandler(salaryService service.SalaryEntryService, logger *slog.Logger) *SalaryHandler {
 	return &SalaryHandler{
 		salaryService: salaryService,
+		logger:        logger,
 	}
 }
 
@@ -34,7 +40,10 @@ func (h *SalaryHandler) CreateEntry(c echo.Context) error {
 
 	entry, err := h.salaryService.CreateEntry(c.Request().Context(), userID, &req)
 	if err != nil {
-		return responses.InternalServerError(c, "Failed to create salary entry")
+		if status, message := periodErrorResponse(err); status != 0 {
+			return responses.Error(c, status, message)
+		}
+		return internalServerError(c, h.logger, "Failed to create salary entry", err)
 	}
 
 	return c.JSON(http.StatusCreated, responses.Response{
@@ -56,21 +65,81 @@ func (h *SalaryHandler) GetEntry(c echo.Context) error {
 		if err.Error() == "salary entry not found" {
 			return responses.NotFound(c, "Salary entry not found")
 		}
-		return responses.InternalServerError(c, "Failed to get salary entry")
+		return internalServerError(c, h.logger, "Failed to get salary entry", err)
 	}
 
 	return responses.Success(c, entry)
 }
 
+const (
+	defaultEntriesPageSize = 20
+	maxEntriesPageSize     = 100
+)
+
 func (h *SalaryHandler) GetUserEntries(c echo.Context) error {
 	userID := c.Get("user_id").(string)
 
-	entries, err := h.salaryService.GetUserEntries(c.Request().Context(), userID)
+	query, err := parseListEntriesQuery(c)
 	if err != nil {
-		return responses.InternalServerError(c, "Failed to get salary entries")
+		return responses.BadRequest(c, err.Error())
 	}
 
-	return responses.Success(c, entries)
+	page, err := h.salaryService.GetUserEntries(c.Request().Context(), userID, query)
+	if err != nil {
+		if err.Error() == "invalid cursor" {
+			return responses.BadRequest(c, "Invalid cursor")
+		}
+		return internalServerError(c, h.logger, "Failed to get salary entries", err)
+	}
+
+	return responses.SuccessWithPagination(c, page.Entries, responses.Pagination{
+		Limit:      query.Limit,
+		HasMore:    page.HasMore,
+		NextCursor: page.NextCursor,
+	})
+}
+
+func parseListEntriesQuery(c echo.Context) (*model.ListEntriesQuery, error) {
+	query := &model.ListEntriesQuery{
+		Cursor:   c.QueryParam("cursor"),
+		Limit:    defaultEntriesPageSize,
+		SortBy:   c.QueryParam("sort"),
+		Order:    c.QueryParam("order"),
+		Currency: c.QueryParam("currency"),
+		Status:   c.QueryParam("status"),
+	}
+
+	if limit := c.QueryParam("limit"); limit != "" {
+		n, err := strconv.Atoi(limit)
+		if err != nil || n < 1 || n > maxEntriesPageSize {
+			return nil, fmt.Errorf("limit must be between 1 and %d", maxEntriesPageSize)
+		}
+		query.Limit = n
+	}
+
+	switch query.SortBy {
+	case "":
+		query.SortBy = model.EntrySortCreatedAt
+	case model.EntrySortStartTime, model.EntrySortSalary, model.EntrySortCreatedAt:
+	default:
+		return nil, fmt.Errorf("sort must be one of start_time, salary or created_at")
+	}
+
+	switch query.Order {
+	case "":
+		query.Order = "desc"
+	case "asc", "desc":
+	default:
+		return nil, fmt.Errorf("order must be asc or desc")
+	}
+
+	switch query.Status {
+	case "", model.EntryStatusCurrent, model.EntryStatusPast:
+	default:
+		return nil, fmt.Errorf("status must be current or past")
+	}
+
+	return query, nil
 }
 
 func (h *SalaryHandler) UpdateEntry(c echo.Context) error {
@@ -86,12 +155,22 @@ func (h *SalaryHandler) UpdateEntry(c echo.Context) error {
 		return responses.BadRequest(c, "Invalid request body")
 	}
 
+	if err := c.Validate(&req); err != nil {
+		return responses.BadRequest(c, err.Error())
+	}
+
 	entry, err := h.salaryService.UpdateEntry(c.Request().Context(), userID, entryID, &req)
 	if err != nil {
 		if err.Error() == "salary entry not found" {
 			return responses.NotFound(c, "Salary entry not found")
 		}
-		return responses.InternalServerError(c, "Failed to update salary entry")
+		if status, message := periodErrorResponse(err); status != 0 {
+			return responses.Error(c, status, message)
+		}
+		if status, message := entryConflictResponse(err); status != 0 {
+			return responses.Error(c, status, message)
+		}
+		return internalServerError(c, h.logger, "Failed to update salary entry", err)
 	}
 
 	return responses.Success(c, entry)
@@ -110,7 +189,7 @@ func (h *SalaryHandler) DeleteEntry(c echo.Context) error {
 		if err.Error() == "salary entry not found" {
 			return responses.NotFound(c, "Salary entry not found")
 		}
-		return responses.InternalServerError(c, "Failed to delete salary entry")
+		return internalServerError(c, h.logger, "Failed to delete salary entry", err)
 	}
 
 	return responses.SuccessWithMessage(c, "Salary entry deleted successfully", nil)
@@ -138,7 +217,13 @@ func (h *SalaryHandler) AddRaise(c echo.Context) error {
 		if err.Error() == "salary entry not found" {
 			return responses.NotFound(c, "Salary entry not found")
 		}
-		return responses.InternalServerError(c, "Failed to add raise")
+		if status, message := periodErrorResponse(err); status != 0 {
+			return responses.Error(c, status, message)
+		}
+		if status, message := entryConflictResponse(err); status != 0 {
+			return responses.Error(c, status, message)
+		}
+		return internalServerError(c, h.logger, "Failed to add raise", err)
 	}
 
 	return responses.SuccessWithMessage(c, "Raise added successfully", nil)
@@ -157,8 +242,226 @@ func (h *SalaryHandler) GetRaises(c echo.Context) error {
 		if err.Error() == "salary entry not found" {
 			return responses.NotFound(c, "Salary entry not found")
 		}
-		return responses.InternalServerError(c, "Failed to get raises")
+		return internalServerError(c, h.logger, "Failed to get raises", err)
 	}
 
 	return responses.Success(c, raises)
 }
+
+func (h *SalaryHandler) UpdateRaise(c echo.Context) error {
+	userID := c.Get("user_id").(string)
+	entryID := c.Param("id")
+	raiseID := c.Param("raiseId")
+
+	if entryID == "" || raiseID == "" {
+		return responses.BadRequest(c, "Entry ID and raise ID are required")
+	}
+
+	if !primitive.IsValidObjectID(raiseID) {
+		return responses.BadRequest(c, "Invalid raise ID")
+	}
+
+	var req model.UpdateRaiseRequest
+	if err := c.Bind(&req); err != nil {
+		return responses.BadRequest(c, "Invalid request body")
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return responses.BadRequest(c, err.Error())
+	}
+
+	err := h.salaryService.UpdateRaise(c.Request().Context(), userID, entryID, raiseID, &req)
+	if err != nil {
+		if status, message := raiseNotFoundResponse(err); status != 0 {
+			return responses.Error(c, status, message)
+		}
+		if status, message := periodErrorResponse(err); status != 0 {
+			return responses.Error(c, status, message)
+		}
+		if status, message := entryConflictResponse(err); status != 0 {
+			return responses.Error(c, status, message)
+		}
+		return internalServerError(c, h.logger, "Failed to update raise", err)
+	}
+
+	return responses.SuccessWithMessage(c, "Raise updated successfully", nil)
+}
+
+func (h *SalaryHandler) DeleteRaise(c echo.Context) error {
+	userID := c.Get("user_id").(string)
+	entryID := c.Param("id")
+	raiseID := c.Param("raiseId")
+
+	if entryID == "" || raiseID == "" {
+		return responses.BadRequest(c, "Entry ID and raise ID are required")
+	}
+
+	if !primitive.IsValidObjectID(raiseID) {
+		return responses.BadRequest(c, "Invalid raise ID")
+	}
+
+	err := h.salaryService.DeleteRaise(c.Request().Context(), userID, entryID, raiseID)
+	if err != nil {
+		if status, message := raiseNotFoundResponse(err); status != 0 {
+			return responses.Error(c, status, message)
+		}
+		if status, message := entryConflictResponse(err); status != 0 {
+			return responses.Error(c, status, message)
+		}
+		return internalServerError(c, h.logger, "Failed to delete raise", err)
+	}
+
+	return responses.SuccessWithMessage(c, "Raise deleted successfully", nil)
+}
+
+func (h *SalaryHandler) GetTimeline(c echo.Context) error {
+	userID := c.Get("user_id").(string)
+
+	timeline, err := h.salaryService.GetTimeline(c.Request().Context(), userID)
+	if err != nil {
+		return internalServerError(c, h.logger, "Failed to get timeline", err)
+	}
+
+	return responses.Success(c, timeline)
+}
+
+func (h *SalaryHandler) GetEntryHistory(c echo.Context) error {
+	userID := c.Get("user_id").(string)
+	entryID := c.Param("id")
+
+	if entryID == "" {
+		return responses.BadRequest(c, "Entry ID is required")
+	}
+
+	revisions, err := h.salaryService.GetEntryHistory(c.Request().Context(), userID, entryID)
+	if err != nil {
+		if err.Error() == "salary entry not found" {
+			return responses.NotFound(c, "Salary entry not found")
+		}
+		return internalServerError(c, h.logger, "Failed to get entry history", err)
+	}
+
+	return responses.Success(c, revisions)
+}
+
+func (h *SalaryHandler) GetEntryHistoryForAdmin(c echo.Context) error {
+	entryID := c.Param("id")
+
+	if entryID == "" {
+		return responses.BadRequest(c, "Entry ID is required")
+	}
+
+	revisions, err := h.salaryService.GetEntryHistoryForAdmin(c.Request().Context(), entryID)
+	if err != nil {
+		return internalServerError(c, h.logger, "Failed to get entry history", err)
+	}
+
+	return responses.Success(c, revisions)
+}
+
+func (h *SalaryHandler) RestoreEntry(c echo.Context) error {
+	userID := c.Get("user_id").(string)
+	entryID := c.Param("id")
+
+	if entryID == "" {
+		return responses.BadRequest(c, "Entry ID is required")
+	}
+
+	version, err := strconv.Atoi(c.Param("version"))
+	if err != nil || version < 1 {
+		return responses.BadRequest(c, "Version must be a positive integer")
+	}
+
+	entry, err := h.salaryService.RestoreEntry(c.Request().Context(), userID, entryID, version)
+	if err != nil {
+		if err.Error() == "revision not found" {
+			return responses.NotFound(c, "Revision not found")
+		}
+		if err.Error() == "restore period has expired" {
+			return responses.Error(c, http.StatusGone, "The entry was deleted too long ago to be restored")
+		}
+		if status, message := periodErrorResponse(err); status != 0 {
+			return responses.Error(c, status, message)
+		}
+		if status, message := entryConflictResponse(err); status != 0 {
+			return responses.Error(c, status, message)
+		}
+		return internalServerError(c, h.logger, "Failed to restore salary entry", err)
+	}
+
+	return responses.Success(c, entry)
+}
+
+func (h *SalaryHandler) GetDeletedEntries(c echo.Context) error {
+	userID := c.Get("user_id").(string)
+
+	entries, err := h.salaryService.GetDeletedEntries(c.Request().Context(), userID)
+	if err != nil {
+		return internalServerError(c, h.logger, "Failed to get deleted entries", err)
+	}
+
+	return responses.Success(c, entries)
+}
+
+func (h *SalaryHandler) UndeleteEntry(c echo.Context) error {
+	userID := c.Get("user_id").(string)
+	entryID := c.Param("id")
+
+	if entryID == "" {
+		return responses.BadRequest(c, "Entry ID is required")
+	}
+
+	entry, err := h.salaryService.UndeleteEntry(c.Request().Context(), userID, entryID)
+	if err != nil {
+		if err.Error() == "salary entry not found" {
+			return responses.NotFound(c, "Deleted salary entry not found")
+		}
+		if err.Error() == "restore period has expired" {
+			return responses.Error(c, http.StatusGone, "The entry was deleted too long ago to be restored")
+		}
+		if status, message := periodErrorResponse(err); status != 0 {
+			return responses.Error(c, status, message)
+		}
+		return internalServerError(c, h.logger, "Failed to undelete salary entry", err)
+	}
+
+	return responses.Success(c, entry)
+}
+
+// periodErrorResponse maps the service's employment period and raise date
+// validation errors to a status and message, returning a zero status for any
+// other error.
+func periodErrorResponse(err error) (int, string) {
+	switch err.Error() {
+	case "end time must be after start time":
+		return http.StatusBadRequest, "End time must be after start time"
+	case "raise date must be within the job period":
+		return http.StatusBadRequest, "Raise date must be between the job's start and end time"
+	case "job period must include all raises":
+		return http.StatusBadRequest, "Job period must include the dates of all raises"
+	case "entry overlaps with another job":
+		return http.StatusConflict, "Entry overlaps with another job, mark it as concurrent if both jobs were held at the same time"
+	}
+	return 0, ""
+}
+
+// entryConflictResponse maps a write that lost a race with another write of
+// the same entry to a 409, returning a zero status for any other error.
+func entryConflictResponse(err error) (int, string) {
+	if err.Error() == "salary entry was modified concurrently" {
+		return http.StatusConflict, "Salary entry was changed by another request, reload it and try again"
+	}
+	return 0, ""
+}
+
+// raiseNotFoundResponse maps a missing entry or raise to a 404, returning a
+// zero status for any other error.
+func raiseNotFoundResponse(err error) (int, string) {
+	switch err.Error() {
+	case "salary entry not found":
+		return http.StatusNotFound, "Salary entry not found"
+	case "raise not found":
+		return http.StatusNotFound, "Raise not found"
+	}
+	return 0, ""
+}
@@ -4,12 +4,14 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 	"time"
 
 	"github.com/eminsonlu/salystic/internal/model"
+	"github.com/eminsonlu/salystic/pkg/logging"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/labstack/echo/v4"
@@ -46,12 +48,12 @@ func (m *MockSalaryEntryService) GetEntry(ctx context.Context, userID, entryID s
 	return args.Get(0).(*model.SalaryEntry), args.Error(1)
 }
 
-func (m *MockSalaryEntryService) GetUserEntries(ctx context.Context, userID string) ([]*model.SalaryEntry, error) {
-	args := m.Called(ctx, userID)
+func (m *MockSalaryEntryService) GetUserEntries(ctx context.Context, userID string, query *model.ListEntriesQuery) (*model.SalaryEntryPage, error) {
+	args := m.Called(ctx, userID, query)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
-	return args.Get(0).([]*model.SalaryEntry), args.Error(1)
+	return args.Get(0).(*model.SalaryEntryPage), args.Error(1)
 }
 
 func (m *MockSalaryEntryService) UpdateEntry(ctx context.Context, userID, entryID string, req *model.UpdateSalaryEntryRequest) (*model.SalaryEntry, error) {
@@ -80,9 +82,67 @@ func (m *MockSalaryEntryService) GetRaises(ctx context.Context, userID, entryID
 	return args.Get(0).([]model.Raise), args.Error(1)
 }
 
+func (m *MockSalaryEntryService) UpdateRaise(ctx context.Context, userID, entryID, raiseID string, req *model.UpdateRaiseRequest) error {
+	args := m.Called(ctx, userID, entryID, raiseID, req)
+	return args.Error(0)
+}
+
+func (m *MockSalaryEntryService) DeleteRaise(ctx context.Context, userID, entryID, raiseID string) error {
+	args := m.Called(ctx, userID, entryID, raiseID)
+	return args.Error(0)
+}
+
+func (m *MockSalaryEntryService) GetTimeline(ctx context.Context, userID string) (*model.Timeline, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Timeline), args.Error(1)
+}
+
+func (m *MockSalaryEntryService) GetEntryHistory(ctx context.Context, userID, entryID string) ([]*model.EntryRevision, error) {
+	args := m.Called(ctx, userID, entryID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*model.EntryRevision), args.Error(1)
+}
+
+func (m *MockSalaryEntryService) GetEntryHistoryForAdmin(ctx context.Context, entryID string) ([]*model.EntryRevision, error) {
+	args := m.Called(ctx, entryID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*model.EntryRevision), args.Error(1)
+}
+
+func (m *MockSalaryEntryService) RestoreEntry(ctx context.Context, userID, entryID string, version int) (*model.SalaryEntry, error) {
+	args := m.Called(ctx, userID, entryID, version)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.SalaryEntry), args.Error(1)
+}
+
+func (m *MockSalaryEntryService) GetDeletedEntries(ctx context.Context, userID string) ([]*model.DeletedSalaryEntry, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*model.DeletedSalaryEntry), args.Error(1)
+}
+
+func (m *MockSalaryEntryService) UndeleteEntry(ctx context.Context, userID, entryID string) (*model.SalaryEntry, error) {
+	args := m.Called(ctx, userID, entryID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.SalaryEntry), args.Error(1)
+}
+
 func TestNewSalaryHandler(t *testing.T) {
 	mockService := &MockSalaryEntryService{}
-	handler := NewSalaryHandler(mockService)
+	handler := NewSalaryHandler(mockService, logging.NewNop())
 
 	assert.NotNil(t, handler)
 	assert.Equal(t, mockService, handler.salaryService)
@@ -92,7 +152,7 @@ func TestCreateEntry_Success(t *testing.T) {
 	e := echo.New()
 	e.Validator = &CustomValidator{validator: validator.New()}
 	mockService := &MockSalaryEntryService{}
-	handler := NewSalaryHandler(mockService)
+	handler := NewSalaryHandler(mockService, logging.NewNop())
 
 	userID := primitive.NewObjectID().Hex()
 	now := time.Now()
@@ -159,7 +219,7 @@ func TestCreateEntry_Success(t *testing.T) {
 func TestGetUserEntries_Success(t *testing.T) {
 	e := echo.New()
 	mockService := &MockSalaryEntryService{}
-	handler := NewSalaryHandler(mockService)
+	handler := NewSalaryHandler(mockService, logging.NewNop())
 
 	userID := primitive.NewObjectID().Hex()
 	salaryMax := int64(150999)
@@ -183,7 +243,9 @@ func TestGetUserEntries_Success(t *testing.T) {
 		},
 	}
 
-	mockService.On("GetUserEntries", mock.Anything, userID).Return(entries, nil)
+	defaultQuery := &model.ListEntriesQuery{Limit: 20, SortBy: model.EntrySortCreatedAt, Order: "desc"}
+	mockService.On("GetUserEntries", mock.Anything, userID, defaultQuery).
+		Return(&model.SalaryEntryPage{Entries: entries, NextCursor: "next", HasMore: true}, nil)
 
 	req := httptest.NewRequest(http.MethodGet, "/api/v1/entries", nil)
 	rec := httptest.NewRecorder()
@@ -199,14 +261,85 @@ func TestGetUserEntries_Success(t *testing.T) {
 	json.Unmarshal(rec.Body.Bytes(), &response)
 	assert.True(t, response["success"].(bool))
 	assert.NotNil(t, response["data"])
+	assert.Equal(t, map[string]interface{}{"limit": float64(20), "has_more": true, "next_cursor": "next"}, response["pagination"])
 
 	mockService.AssertExpectations(t)
 }
 
+func TestGetUserEntries_ParsesQuery(t *testing.T) {
+	e := echo.New()
+	mockService := &MockSalaryEntryService{}
+	handler := NewSalaryHandler(mockService, logging.NewNop())
+
+	userID := primitive.NewObjectID().Hex()
+	expected := &model.ListEntriesQuery{
+		Cursor:   "abc",
+		Limit:    5,
+		SortBy:   model.EntrySortSalary,
+		Order:    "asc",
+		Currency: "USD",
+		Status:   model.EntryStatusPast,
+	}
+	mockService.On("GetUserEntries", mock.Anything, userID, expected).
+		Return(&model.SalaryEntryPage{Entries: []*model.SalaryEntry{}}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/entries?cursor=abc&limit=5&sort=salary&order=asc&currency=USD&status=past", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set("user_id", userID)
+
+	assert.NoError(t, handler.GetUserEntries(c))
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var response map[string]interface{}
+	json.Unmarshal(rec.Body.Bytes(), &response)
+	assert.Equal(t, []interface{}{}, response["data"])
+	assert.Equal(t, map[string]interface{}{"limit": float64(5), "has_more": false}, response["pagination"])
+
+	mockService.AssertExpectations(t)
+}
+
+func TestGetUserEntries_InvalidQuery(t *testing.T) {
+	for _, query := range []string{"limit=0", "limit=101", "limit=x", "sort=level", "order=up", "status=future"} {
+		t.Run(query, func(t *testing.T) {
+			e := echo.New()
+			mockService := &MockSalaryEntryService{}
+			handler := NewSalaryHandler(mockService, logging.NewNop())
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/entries?"+query, nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.Set("user_id", primitive.NewObjectID().Hex())
+
+			assert.NoError(t, handler.GetUserEntries(c))
+			assert.Equal(t, http.StatusBadRequest, rec.Code)
+			mockService.AssertNotCalled(t, "GetUserEntries", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestGetUserEntries_InvalidCursor(t *testing.T) {
+	e := echo.New()
+	mockService := &MockSalaryEntryService{}
+	handler := NewSalaryHandler(mockService, logging.NewNop())
+
+	userID := primitive.NewObjectID().Hex()
+	mockService.On("GetUserEntries", mock.Anything, userID, mock.Anything).Return(nil, errors.New("invalid cursor"))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/entries?cursor=garbage", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set("user_id", userID)
+
+	assert.NoError(t, handler.GetUserEntries(c))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), "Invalid cursor")
+}
+
 func TestDeleteEntry_Success(t *testing.T) {
 	e := echo.New()
 	mockService := &MockSalaryEntryService{}
-	handler := NewSalaryHandler(mockService)
+	handler := NewSalaryHandler(mockService, logging.NewNop())
 
 	userID := primitive.NewObjectID().Hex()
 	entryID := primitive.NewObjectID().Hex()
@@ -231,4 +364,377 @@ func TestDeleteEntry_Success(t *testing.T) {
 	assert.Equal(t, "Salary entry deleted successfully", response["message"])
 
 	mockService.AssertExpectations(t)
-}
\ No newline at end of file
+}
+func TestGetUserEntries_LogsInternalError(t *testing.T) {
+	var logs bytes.Buffer
+	logger, err := logging.New(&logs, "info", "json")
+	assert.NoError(t, err)
+
+	e := echo.New()
+	mockService := &MockSalaryEntryService{}
+	handler := NewSalaryHandler(mockService, logger)
+
+	userID := primitive.NewObjectID().Hex()
+	mockService.On("GetUserEntries", mock.Anything, userID, mock.Anything).Return(nil, errors.New("connection refused"))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/entries", nil)
+	req = req.WithContext(logging.WithRequestID(req.Context(), "req-42"))
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set("user_id", userID)
+
+	err = handler.GetUserEntries(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.NotContains(t, rec.Body.String(), "connection refused")
+	assert.Contains(t, logs.String(), `"error":"connection refused"`)
+	assert.Contains(t, logs.String(), `"request_id":"req-42"`)
+
+	mockService.AssertExpectations(t)
+}
+
+func TestCreateEntry_Overlap(t *testing.T) {
+	e := echo.New()
+	e.Validator = &CustomValidator{validator: validator.New()}
+	mockService := &MockSalaryEntryService{}
+	handler := NewSalaryHandler(mockService, logging.NewNop())
+
+	userID := primitive.NewObjectID().Hex()
+	mockService.On("CreateEntry", mock.Anything, userID, mock.Anything).Return(nil, errors.New("entry overlaps with another job"))
+
+	body, _ := json.Marshal(model.CreateSalaryEntryRequest{
+		Level: "Senior", Position: "Backend Developer", TechStack: []string{"Go"}, Experience: "5 - 7 Yıl",
+		Gender: "Erkek", Company: "Technology", CompanySize: "101 - 249 Kişi", WorkType: "Remote",
+		City: "İstanbul", Currency: "TRY", SalaryMin: 100000, RaisePeriod: 1, StartTime: time.Now(),
+	})
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/entries", bytes.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set("user_id", userID)
+
+	assert.NoError(t, handler.CreateEntry(c))
+	assert.Equal(t, http.StatusConflict, rec.Code)
+	assert.Contains(t, rec.Body.String(), "overlaps with another job")
+}
+
+func TestGetTimeline_Success(t *testing.T) {
+	e := echo.New()
+	mockService := &MockSalaryEntryService{}
+	handler := NewSalaryHandler(mockService, logging.NewNop())
+
+	userID := primitive.NewObjectID().Hex()
+	entry := &model.SalaryEntry{ID: primitive.NewObjectID(), Currency: "TRY", SalaryMin: 100}
+	mockService.On("GetTimeline", mock.Anything, userID).Return(&model.Timeline{
+		Jobs:       []model.TimelineJob{{SalaryEntry: entry, StartingSalary: 100, FinalSalary: 120}},
+		JobChanges: []model.JobChange{},
+	}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/me/timeline", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set("user_id", userID)
+
+	assert.NoError(t, handler.GetTimeline(c))
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var response struct {
+		Data struct {
+			Jobs []map[string]interface{} `json:"jobs"`
+		} `json:"data"`
+	}
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	assert.Len(t, response.Data.Jobs, 1)
+	assert.Equal(t, entry.ID.Hex(), response.Data.Jobs[0]["id"])
+	assert.Equal(t, float64(120), response.Data.Jobs[0]["final_salary"])
+
+	mockService.AssertExpectations(t)
+}
+
+func TestUpdateRaise_OutsideJobPeriod(t *testing.T) {
+	e := echo.New()
+	e.Validator = &CustomValidator{validator: validator.New()}
+	mockService := &MockSalaryEntryService{}
+	handler := NewSalaryHandler(mockService, logging.NewNop())
+
+	userID := primitive.NewObjectID().Hex()
+	entryID := primitive.NewObjectID().Hex()
+	raiseID := primitive.NewObjectID().Hex()
+
+	mockService.On("UpdateRaise", mock.Anything, userID, entryID, raiseID, mock.MatchedBy(func(req *model.UpdateRaiseRequest) bool {
+		return req.NewSalary != nil && *req.NewSalary == 150000 && req.RaiseDate != nil
+	})).Return(errors.New("raise date must be within the job period"))
+
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/entries/"+entryID+"/raises/"+raiseID,
+		bytes.NewReader([]byte(`{"raiseDate":"2019-01-01T00:00:00Z","newSalary":150000}`)))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set("user_id", userID)
+	c.SetParamNames("id", "raiseId")
+	c.SetParamValues(entryID, raiseID)
+
+	assert.NoError(t, handler.UpdateRaise(c))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), "Raise date must be between")
+
+	mockService.AssertExpectations(t)
+}
+
+func TestDeleteRaise_NotFound(t *testing.T) {
+	e := echo.New()
+	mockService := &MockSalaryEntryService{}
+	handler := NewSalaryHandler(mockService, logging.NewNop())
+
+	userID := primitive.NewObjectID().Hex()
+	entryID := primitive.NewObjectID().Hex()
+	raiseID := primitive.NewObjectID().Hex()
+
+	mockService.On("DeleteRaise", mock.Anything, userID, entryID, raiseID).Return(errors.New("raise not found"))
+
+	req := httptest.NewRequest(http.MethodDelete, "/api/v1/entries/"+entryID+"/raises/"+raiseID, nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set("user_id", userID)
+	c.SetParamNames("id", "raiseId")
+	c.SetParamValues(entryID, raiseID)
+
+	assert.NoError(t, handler.DeleteRaise(c))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.Contains(t, rec.Body.String(), "Raise not found")
+
+	mockService.AssertExpectations(t)
+}
+
+func TestRaise_InvalidRaiseID(t *testing.T) {
+	e := echo.New()
+	e.Validator = &CustomValidator{validator: validator.New()}
+	mockService := &MockSalaryEntryService{}
+	handler := NewSalaryHandler(mockService, logging.NewNop())
+
+	userID := primitive.NewObjectID().Hex()
+	entryID := primitive.NewObjectID().Hex()
+
+	tests := []struct {
+		name   string
+		method string
+		body   string
+		handle func(echo.Context) error
+	}{
+		{"update", http.MethodPut, `{"newSalary":120}`, handler.UpdateRaise},
+		{"delete", http.MethodDelete, "", handler.DeleteRaise},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, "/api/v1/entries/"+entryID+"/raises/not-an-id", bytes.NewReader([]byte(tt.body)))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.Set("user_id", userID)
+			c.SetParamNames("id", "raiseId")
+			c.SetParamValues(entryID, "not-an-id")
+
+			assert.NoError(t, tt.handle(c))
+			assert.Equal(t, http.StatusBadRequest, rec.Code)
+			assert.Contains(t, rec.Body.String(), "Invalid raise ID")
+		})
+	}
+
+	mockService.AssertNotCalled(t, "UpdateRaise", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	mockService.AssertNotCalled(t, "DeleteRaise", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestUpdateRaise_NotFound(t *testing.T) {
+	e := echo.New()
+	e.Validator = &CustomValidator{validator: validator.New()}
+	mockService := &MockSalaryEntryService{}
+	handler := NewSalaryHandler(mockService, logging.NewNop())
+
+	userID := primitive.NewObjectID().Hex()
+	entryID := primitive.NewObjectID().Hex()
+	raiseID := primitive.NewObjectID().Hex()
+
+	mockService.On("UpdateRaise", mock.Anything, userID, entryID, raiseID, mock.Anything).Return(errors.New("raise not found"))
+
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/entries/"+entryID+"/raises/"+raiseID, bytes.NewReader([]byte(`{"newSalary":120}`)))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set("user_id", userID)
+	c.SetParamNames("id", "raiseId")
+	c.SetParamValues(entryID, raiseID)
+
+	assert.NoError(t, handler.UpdateRaise(c))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.Contains(t, rec.Body.String(), "Raise not found")
+
+	mockService.AssertExpectations(t)
+}
+
+func TestAddRaise_ConcurrentWrite(t *testing.T) {
+	e := echo.New()
+	e.Validator = &CustomValidator{validator: validator.New()}
+	mockService := &MockSalaryEntryService{}
+	handler := NewSalaryHandler(mockService, logging.NewNop())
+
+	userID := primitive.NewObjectID().Hex()
+	entryID := primitive.NewObjectID().Hex()
+
+	mockService.On("AddRaise", mock.Anything, userID, entryID, mock.Anything).Return(errors.New("salary entry was modified concurrently"))
+
+	body := []byte(`{"raiseDate":"2021-01-01T00:00:00Z","newSalary":120}`)
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/entries/"+entryID+"/raises", bytes.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set("user_id", userID)
+	c.SetParamNames("id")
+	c.SetParamValues(entryID)
+
+	assert.NoError(t, handler.AddRaise(c))
+	assert.Equal(t, http.StatusConflict, rec.Code)
+
+	mockService.AssertExpectations(t)
+}
+
+func TestCreateEntry_InvalidCompensation(t *testing.T) {
+	e := echo.New()
+	e.Validator = &CustomValidator{validator: validator.New()}
+	mockService := &MockSalaryEntryService{}
+	handler := NewSalaryHandler(mockService, logging.NewNop())
+
+	body, _ := json.Marshal(model.CreateSalaryEntryRequest{
+		Level: "Senior", Position: "Backend Developer", TechStack: []string{"Go"}, Experience: "5 - 7 Yıl",
+		Gender: "Erkek", Company: "Technology", CompanySize: "101 - 249 Kişi", WorkType: "Remote",
+		City: "İstanbul", Currency: "TRY", SalaryMin: 100000, RaisePeriod: 1, StartTime: time.Now(),
+		Compensation: &model.Compensation{Bonus: &model.Bonus{Amount: 50000, Months: 2}},
+	})
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/entries", bytes.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set("user_id", primitive.NewObjectID().Hex())
+
+	assert.NoError(t, handler.CreateEntry(c))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), "Bonus.Amount")
+	mockService.AssertNotCalled(t, "CreateEntry", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestCreateEntry_InvalidSalaryBasis(t *testing.T) {
+	e := echo.New()
+	e.Validator = &CustomValidator{validator: validator.New()}
+	mockService := &MockSalaryEntryService{}
+	handler := NewSalaryHandler(mockService, logging.NewNop())
+
+	body, _ := json.Marshal(model.CreateSalaryEntryRequest{
+		Level: "Senior", Position: "Backend Developer", TechStack: []string{"Go"}, Experience: "5 - 7 Yıl",
+		Gender: "Erkek", Company: "Technology", CompanySize: "101 - 249 Kişi", WorkType: "Remote",
+		City: "İstanbul", Currency: "TRY", SalaryBasis: "gross_weekly", SalaryMin: 100000, RaisePeriod: 1, StartTime: time.Now(),
+	})
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/entries", bytes.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set("user_id", primitive.NewObjectID().Hex())
+
+	assert.NoError(t, handler.CreateEntry(c))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), "SalaryBasis")
+	mockService.AssertNotCalled(t, "CreateEntry", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestGetEntryHistory_NotFound(t *testing.T) {
+	e := echo.New()
+	mockService := &MockSalaryEntryService{}
+	handler := NewSalaryHandler(mockService, logging.NewNop())
+
+	userID := primitive.NewObjectID().Hex()
+	entryID := primitive.NewObjectID().Hex()
+
+	mockService.On("GetEntryHistory", mock.Anything, userID, entryID).Return(nil, errors.New("salary entry not found"))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/entries/"+entryID+"/history", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set("user_id", userID)
+	c.SetParamNames("id")
+	c.SetParamValues(entryID)
+
+	assert.NoError(t, handler.GetEntryHistory(c))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	mockService.AssertExpectations(t)
+}
+
+func TestRestoreEntry(t *testing.T) {
+	e := echo.New()
+	mockService := &MockSalaryEntryService{}
+	handler := NewSalaryHandler(mockService, logging.NewNop())
+
+	userID := primitive.NewObjectID().Hex()
+	entryID := primitive.NewObjectID().Hex()
+
+	mockService.On("RestoreEntry", mock.Anything, userID, entryID, 2).Return(&model.SalaryEntry{Level: "Senior"}, nil)
+	mockService.On("RestoreEntry", mock.Anything, userID, entryID, 9).Return(nil, errors.New("revision not found"))
+
+	for _, tc := range []struct {
+		version string
+		status  int
+	}{
+		{"2", http.StatusOK},
+		{"9", http.StatusNotFound},
+		{"0", http.StatusBadRequest},
+		{"latest", http.StatusBadRequest},
+	} {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/entries/"+entryID+"/history/"+tc.version+"/restore", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.Set("user_id", userID)
+		c.SetParamNames("id", "version")
+		c.SetParamValues(entryID, tc.version)
+
+		assert.NoError(t, handler.RestoreEntry(c))
+		assert.Equal(t, tc.status, rec.Code, tc.version)
+	}
+
+	mockService.AssertExpectations(t)
+}
+
+func TestUndeleteEntry(t *testing.T) {
+	e := echo.New()
+	mockService := &MockSalaryEntryService{}
+	handler := NewSalaryHandler(mockService, logging.NewNop())
+
+	userID := primitive.NewObjectID().Hex()
+	restorable := primitive.NewObjectID().Hex()
+	expired := primitive.NewObjectID().Hex()
+	missing := primitive.NewObjectID().Hex()
+
+	mockService.On("UndeleteEntry", mock.Anything, userID, restorable).Return(&model.SalaryEntry{Level: "Senior"}, nil)
+	mockService.On("UndeleteEntry", mock.Anything, userID, expired).Return(nil, errors.New("restore period has expired"))
+	mockService.On("UndeleteEntry", mock.Anything, userID, missing).Return(nil, errors.New("salary entry not found"))
+
+	for _, tc := range []struct {
+		entryID string
+		status  int
+	}{
+		{restorable, http.StatusOK},
+		{expired, http.StatusGone},
+		{missing, http.StatusNotFound},
+	} {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/entries/"+tc.entryID+"/undelete", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.Set("user_id", userID)
+		c.SetParamNames("id")
+		c.SetParamValues(tc.entryID)
+
+		assert.NoError(t, handler.UndeleteEntry(c))
+		assert.Equal(t, tc.status, rec.Code, tc.entryID)
+	}
+
+	mockService.AssertExpectations(t)
+}
@@ -0,0 +1,24 @@
+package middleware
+
+import (
+	"github.com/eminsonlu/salystic/pkg/responses"
+
+	"github.com/labstack/echo/v4"
+)
+
+func RequireAdmin(adminUserIDs []string) echo.MiddlewareFunc {
+	admins := make(map[string]struct{}, len(adminUserIDs))
+	for _, id := range adminUserIDs {
+		admins[id] = struct{}{}
+	}
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			userID, _ := c.Get("user_id").(string)
+			if _, ok := admins[userID]; !ok {
+				return responses.Forbidden(c, "Admin access required")
+			}
+			return next(c)
+		}
+	}
+}
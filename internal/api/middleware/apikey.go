@@ -0,0 +1,62 @@
+package middleware
+
+import (
+	"github.com/eminsonlu/salystic/internal/model"
+	"github.com/eminsonlu/salystic/internal/service"
+	"github.com/eminsonlu/salystic/pkg/responses"
+
+	"github.com/labstack/echo/v4"
+)
+
+const APIKeyHeader = "X-API-Key"
+
+type APIKeyMiddleware struct {
+	apiKeyService service.APIKeyService
+}
+
+func NewAPIKeyMiddleware(apiKeyService service.APIKeyService) *APIKeyMiddleware {
+	return &APIKeyMiddleware{
+		apiKeyService: apiKeyService,
+	}
+}
+
+func (m *APIKeyMiddleware) Optional(scope string) echo.MiddlewareFunc {
+	return m.handle(scope, false)
+}
+
+func (m *APIKeyMiddleware) Require(scope string) echo.MiddlewareFunc {
+	return m.handle(scope, true)
+}
+
+func (m *APIKeyMiddleware) handle(scope string, required bool) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			rawKey := c.Request().Header.Get(APIKeyHeader)
+			if rawKey == "" {
+				if required {
+					return responses.Unauthorized(c, "API key required")
+				}
+				return next(c)
+			}
+
+			key, err := m.apiKeyService.Authenticate(c.Request().Context(), rawKey)
+			if err != nil {
+				return responses.Unauthorized(c, "Invalid or expired API key")
+			}
+
+			if !key.HasScope(scope) {
+				return responses.Forbidden(c, "API key lacks required scope: "+scope)
+			}
+
+			c.Set("api_key", key)
+			c.Set("api_key_id", key.ID.Hex())
+
+			return next(c)
+		}
+	}
+}
+
+func APIKeyFromContext(c echo.Context) *model.APIKey {
+	key, _ := c.Get("api_key").(*model.APIKey)
+	return key
+}
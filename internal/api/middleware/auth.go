@@ -1,9 +1,11 @@
 package middleware
 
 import (
+	"strings"
+
+	"github.com/eminsonlu/salystic/internal/auth"
 	"github.com/eminsonlu/salystic/internal/service"
 	"github.com/eminsonlu/salystic/pkg/responses"
-	"strings"
 
 	"github.com/labstack/echo/v4"
 )
@@ -20,23 +22,31 @@ func NewAuthMiddleware(authService service.AuthService) *AuthMiddleware {
 
 func (m *AuthMiddleware) RequireAuth(next echo.HandlerFunc) echo.HandlerFunc {
 	return func(c echo.Context) error {
+		var token string
 		authHeader := c.Request().Header.Get("Authorization")
-		if authHeader == "" {
+		if authHeader != "" {
+			tokenParts := strings.Split(authHeader, " ")
+			if len(tokenParts) != 2 || tokenParts[0] != "Bearer" {
+				return responses.Unauthorized(c, "Invalid authorization header format")
+			}
+			token = tokenParts[1]
+		} else if cookie, err := c.Cookie(auth.AccessTokenCookieName); err == nil && cookie.Value != "" {
+			token = cookie.Value
+		} else {
 			return responses.Unauthorized(c, "Authorization header required")
 		}
 
-		tokenParts := strings.Split(authHeader, " ")
-		if len(tokenParts) != 2 || tokenParts[0] != "Bearer" {
-			return responses.Unauthorized(c, "Invalid authorization header format")
-		}
-
-		token := tokenParts[1]
 		claims, err := m.authService.ValidateToken(token)
 		if err != nil {
 			return responses.Unauthorized(c, "Invalid or expired token")
 		}
 
+		if err := m.authService.ValidateSession(c.Request().Context(), claims); err != nil {
+			return responses.Unauthorized(c, "Session is no longer valid")
+		}
+
 		c.Set("user_id", claims.UserID)
+		c.Set("session_id", claims.SessionID)
 		c.Set("pseudonymized_id", claims.PseudonymizedID)
 		c.Set("claims", claims)
 
@@ -0,0 +1,55 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+)
+
+var publicPathPrefixes = []string{
+	"/api/v1/analytics",
+	"/api/v1/constants",
+	"/api/v1/health",
+	"/health",
+}
+
+func CORSWithConfig(frontendURLs []string) echo.MiddlewareFunc {
+	publicCORS := middleware.CORSWithConfig(middleware.CORSConfig{
+		AllowOrigins:  []string{"*"},
+		AllowMethods:  []string{http.MethodGet, http.MethodOptions},
+		AllowHeaders:  []string{echo.HeaderContentType, APIKeyHeader},
+		ExposeHeaders: []string{echo.HeaderXRequestID},
+	})
+
+	privateCORS := middleware.CORSWithConfig(middleware.CORSConfig{
+		AllowOrigins:     frontendURLs,
+		AllowMethods:     []string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodDelete, http.MethodOptions},
+		AllowHeaders:     []string{echo.HeaderContentType, echo.HeaderAuthorization},
+		ExposeHeaders:    []string{echo.HeaderXRequestID},
+		AllowCredentials: true,
+		MaxAge:           600,
+	})
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		public := publicCORS(next)
+		private := privateCORS(next)
+
+		return func(c echo.Context) error {
+			if isPublicPath(c.Request().URL.Path) {
+				return public(c)
+			}
+			return private(c)
+		}
+	}
+}
+
+func isPublicPath(path string) bool {
+	for _, prefix := range publicPathPrefixes {
+		if path == prefix || strings.HasPrefix(path, prefix+"/") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,79 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func newCORSTestServer() *echo.Echo {
+	e := echo.New()
+	e.Use(CORSWithConfig([]string{"https://salystic.com", "https://www.salystic.com"}))
+
+	ok := func(c echo.Context) error { return c.NoContent(http.StatusOK) }
+	e.GET("/api/v1/analytics", ok)
+	e.GET("/api/v1/constants/positions", ok)
+	e.POST("/api/v1/entries", ok)
+	e.GET("/auth/me", ok)
+	return e
+}
+
+func preflight(e *echo.Echo, path, origin, method string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodOptions, path, nil)
+	req.Header.Set(echo.HeaderOrigin, origin)
+	req.Header.Set(echo.HeaderAccessControlRequestMethod, method)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestCORS_PublicPreflight(t *testing.T) {
+	e := newCORSTestServer()
+
+	for _, path := range []string{"/api/v1/analytics", "/api/v1/constants/positions"} {
+		rec := preflight(e, path, "https://partner.example.com", http.MethodGet)
+
+		assert.Equal(t, http.StatusNoContent, rec.Code, path)
+		assert.Equal(t, "*", rec.Header().Get(echo.HeaderAccessControlAllowOrigin), path)
+		assert.Equal(t, "GET,OPTIONS", rec.Header().Get(echo.HeaderAccessControlAllowMethods), path)
+		assert.Empty(t, rec.Header().Get(echo.HeaderAccessControlAllowCredentials), path)
+		assert.Contains(t, rec.Header().Get(echo.HeaderAccessControlAllowHeaders), APIKeyHeader, path)
+	}
+}
+
+func TestCORS_PrivatePreflight_AllowedOrigins(t *testing.T) {
+	e := newCORSTestServer()
+
+	for _, origin := range []string{"https://salystic.com", "https://www.salystic.com"} {
+		rec := preflight(e, "/api/v1/entries", origin, http.MethodPost)
+
+		assert.Equal(t, http.StatusNoContent, rec.Code, origin)
+		assert.Equal(t, origin, rec.Header().Get(echo.HeaderAccessControlAllowOrigin), origin)
+		assert.Equal(t, "true", rec.Header().Get(echo.HeaderAccessControlAllowCredentials), origin)
+		assert.Contains(t, rec.Header().Get(echo.HeaderAccessControlAllowMethods), http.MethodDelete, origin)
+	}
+
+	rec := preflight(e, "/auth/me", "https://salystic.com", http.MethodGet)
+	assert.Equal(t, "https://salystic.com", rec.Header().Get(echo.HeaderAccessControlAllowOrigin))
+}
+
+func TestCORS_PrivatePreflight_RejectsUnknownOrigin(t *testing.T) {
+	e := newCORSTestServer()
+
+	rec := preflight(e, "/api/v1/entries", "https://evil.example.com", http.MethodPost)
+
+	assert.Empty(t, rec.Header().Get(echo.HeaderAccessControlAllowOrigin))
+	assert.Empty(t, rec.Header().Get(echo.HeaderAccessControlAllowCredentials))
+}
+
+func TestIsPublicPath(t *testing.T) {
+	assert.True(t, isPublicPath("/api/v1/analytics"))
+	assert.True(t, isPublicPath("/api/v1/analytics/career"))
+	assert.True(t, isPublicPath("/health"))
+	assert.False(t, isPublicPath("/api/v1/analyticsx"))
+	assert.False(t, isPublicPath("/api/v1/entries"))
+	assert.False(t, isPublicPath("/auth/me"))
+}
@@ -0,0 +1,75 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/eminsonlu/salystic/pkg/logging"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequestID_GeneratesAndPropagates(t *testing.T) {
+	e := echo.New()
+	e.Use(RequestID())
+
+	var fromContext string
+	e.GET("/", func(c echo.Context) error {
+		fromContext = logging.RequestIDFromContext(c.Request().Context())
+		return c.NoContent(http.StatusOK)
+	})
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	requestID := rec.Header().Get(echo.HeaderXRequestID)
+	assert.Len(t, requestID, 32)
+	assert.Equal(t, requestID, fromContext)
+}
+
+func TestRequestID_ReusesValidIncomingHeader(t *testing.T) {
+	e := echo.New()
+	e.Use(RequestID())
+	e.GET("/", func(c echo.Context) error { return c.NoContent(http.StatusOK) })
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set(echo.HeaderXRequestID, "upstream-id_1.2")
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	assert.Equal(t, "upstream-id_1.2", rec.Header().Get(echo.HeaderXRequestID))
+
+	req = httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set(echo.HeaderXRequestID, "bad id\nwith newline")
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	assert.NotEqual(t, "bad id\nwith newline", rec.Header().Get(echo.HeaderXRequestID))
+	assert.Len(t, rec.Header().Get(echo.HeaderXRequestID), 32)
+}
+
+func TestRequestLogger_IncludesRequestID(t *testing.T) {
+	var buf bytes.Buffer
+	logger, err := logging.New(&buf, "info", "json")
+	require.NoError(t, err)
+
+	e := echo.New()
+	e.Use(RequestID())
+	e.Use(RequestLogger(logger))
+	e.GET("/boom", func(c echo.Context) error {
+		return c.NoContent(http.StatusInternalServerError)
+	})
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/boom", nil))
+
+	var line map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &line))
+	assert.Equal(t, "ERROR", line["level"])
+	assert.Equal(t, "/boom", line["route"])
+	assert.Equal(t, float64(http.StatusInternalServerError), line["status"])
+	assert.Equal(t, rec.Header().Get(echo.HeaderXRequestID), line[logging.RequestIDKey])
+}
@@ -0,0 +1,57 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/eminsonlu/salystic/internal/metrics"
+
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+)
+
+const unmatchedRoute = "unmatched"
+
+func Metrics(m *metrics.Metrics) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			start := time.Now()
+			err := next(c)
+
+			status := responseStatus(c, err)
+
+			route := c.Path()
+			if route == "" || status == http.StatusNotFound && route == "/*" {
+				route = unmatchedRoute
+			}
+
+			m.ObserveHTTPRequest(c.Request().Method, route, status, time.Since(start))
+			return err
+		}
+	}
+}
+
+func responseStatus(c echo.Context, err error) int {
+	if err == nil {
+		return c.Response().Status
+	}
+
+	var httpErr *echo.HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr.Code
+	}
+	return http.StatusInternalServerError
+}
+
+func MetricsBasicAuth(username, password string) echo.MiddlewareFunc {
+	return middleware.BasicAuthWithConfig(middleware.BasicAuthConfig{
+		Realm: "metrics",
+		Validator: func(u, p string, c echo.Context) (bool, error) {
+			userMatch := subtle.ConstantTimeCompare([]byte(u), []byte(username)) == 1
+			passMatch := subtle.ConstantTimeCompare([]byte(p), []byte(password)) == 1
+			return userMatch && passMatch, nil
+		},
+	})
+}
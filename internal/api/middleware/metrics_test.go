@@ -0,0 +1,39 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/eminsonlu/salystic/internal/metrics"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetrics_RecordsRouteTemplates(t *testing.T) {
+	m := metrics.New()
+	e := echo.New()
+	e.Use(Metrics(m))
+	e.GET("/api/v1/entries/:id", func(c echo.Context) error { return c.NoContent(http.StatusOK) })
+	e.GET("/metrics", echo.WrapHandler(m.Handler()), MetricsBasicAuth("prom", "secret"))
+
+	for _, path := range []string{"/api/v1/entries/abc", "/api/v1/entries/def", "/does/not/exist"} {
+		e.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+	req.SetBasicAuth("prom", "secret")
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	body := rec.Body.String()
+	assert.Contains(t, body, `salystic_http_request_duration_seconds_count{method="GET",route="/api/v1/entries/:id",status="200"} 2`)
+	assert.Contains(t, body, `salystic_http_request_duration_seconds_count{method="GET",route="unmatched",status="404"} 1`)
+	assert.NotContains(t, body, "/api/v1/entries/abc")
+}
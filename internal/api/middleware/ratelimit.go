@@ -0,0 +1,69 @@
+package middleware
+
+import (
+	"log/slog"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/eminsonlu/salystic/pkg/ratelimit"
+	"github.com/eminsonlu/salystic/pkg/responses"
+
+	"github.com/labstack/echo/v4"
+)
+
+type RateLimiter struct {
+	store  ratelimit.Store
+	logger *slog.Logger
+}
+
+func NewRateLimiter(store ratelimit.Store, logger *slog.Logger) *RateLimiter {
+	return &RateLimiter{
+		store:  store,
+		logger: logger,
+	}
+}
+
+func (m *RateLimiter) PerIP(name string, rule ratelimit.Rule) echo.MiddlewareFunc {
+	return m.limit(func(c echo.Context) (string, ratelimit.Rule) {
+		if key := APIKeyFromContext(c); key != nil && key.RateLimit > 0 {
+			return name + ":key:" + key.ID.Hex(), ratelimit.Rule{Limit: key.RateLimit, Window: time.Minute}
+		}
+		return name + ":ip:" + c.RealIP(), rule
+	})
+}
+
+func (m *RateLimiter) PerUser(name string, rule ratelimit.Rule) echo.MiddlewareFunc {
+	return m.limit(func(c echo.Context) (string, ratelimit.Rule) {
+		if userID, ok := c.Get("user_id").(string); ok && userID != "" {
+			return name + ":user:" + userID, rule
+		}
+		return name + ":ip:" + c.RealIP(), rule
+	})
+}
+
+func (m *RateLimiter) limit(keyFunc func(c echo.Context) (string, ratelimit.Rule)) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			key, rule := keyFunc(c)
+
+			result, err := m.store.Allow(c.Request().Context(), key, rule)
+			if err != nil {
+				m.logger.WarnContext(c.Request().Context(), "rate limiter store error, allowing request", "key", key, "error", err)
+				return next(c)
+			}
+
+			header := c.Response().Header()
+			header.Set("X-RateLimit-Limit", strconv.Itoa(result.Limit))
+			header.Set("X-RateLimit-Remaining", strconv.Itoa(result.Remaining))
+
+			if !result.Allowed {
+				retryAfter := int(math.Ceil(result.RetryAfter.Seconds()))
+				header.Set("Retry-After", strconv.Itoa(retryAfter))
+				return responses.TooManyRequests(c, "Rate limit exceeded, try again later")
+			}
+
+			return next(c)
+		}
+	}
+}
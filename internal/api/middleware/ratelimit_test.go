@@ -0,0 +1,139 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/eminsonlu/salystic/pkg/logging"
+	"github.com/eminsonlu/salystic/pkg/ratelimit"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateLimiter_PerIP(t *testing.T) {
+	store := ratelimit.NewMemoryStore(time.Minute)
+	defer store.Stop()
+
+	e := echo.New()
+	limiter := NewRateLimiter(store, logging.NewNop())
+	e.GET("/analytics", func(c echo.Context) error {
+		return c.NoContent(http.StatusOK)
+	}, limiter.PerIP("public", ratelimit.Rule{Limit: 2, Window: time.Minute}))
+
+	doRequest := func(ip string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/analytics", nil)
+		req.Header.Set(echo.HeaderXRealIP, ip)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+
+	assert.Equal(t, http.StatusOK, doRequest("203.0.113.1").Code)
+	assert.Equal(t, http.StatusOK, doRequest("203.0.113.1").Code)
+
+	rec := doRequest("203.0.113.1")
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+	assert.NotEmpty(t, rec.Header().Get("Retry-After"))
+	assert.Equal(t, "0", rec.Header().Get("X-RateLimit-Remaining"))
+
+	assert.Equal(t, http.StatusOK, doRequest("203.0.113.2").Code)
+}
+
+func TestRateLimiter_PerIPIgnoresSpoofedForwardedFor(t *testing.T) {
+	tests := []struct {
+		name           string
+		trustedProxies []string
+	}{
+		{"no trusted proxies", nil},
+		{"untrusted peer", []string{"10.0.0.0/8"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := ratelimit.NewMemoryStore(time.Minute)
+			defer store.Stop()
+
+			e := echo.New()
+			e.IPExtractor = IPExtractor(tt.trustedProxies)
+			limiter := NewRateLimiter(store, logging.NewNop())
+			e.GET("/analytics", func(c echo.Context) error {
+				return c.NoContent(http.StatusOK)
+			}, limiter.PerIP("public", ratelimit.Rule{Limit: 2, Window: time.Minute}))
+
+			doRequest := func(spoofedIP string) int {
+				req := httptest.NewRequest(http.MethodGet, "/analytics", nil)
+				req.RemoteAddr = "203.0.113.1:52000"
+				req.Header.Set(echo.HeaderXForwardedFor, spoofedIP)
+				req.Header.Set(echo.HeaderXRealIP, spoofedIP)
+				rec := httptest.NewRecorder()
+				e.ServeHTTP(rec, req)
+				return rec.Code
+			}
+
+			assert.Equal(t, http.StatusOK, doRequest("198.51.100.1"))
+			assert.Equal(t, http.StatusOK, doRequest("198.51.100.2"))
+			assert.Equal(t, http.StatusTooManyRequests, doRequest("198.51.100.3"))
+		})
+	}
+}
+
+func TestRateLimiter_PerIPBehindTrustedProxy(t *testing.T) {
+	store := ratelimit.NewMemoryStore(time.Minute)
+	defer store.Stop()
+
+	e := echo.New()
+	e.IPExtractor = IPExtractor([]string{"10.0.0.0/8"})
+	limiter := NewRateLimiter(store, logging.NewNop())
+	e.GET("/analytics", func(c echo.Context) error {
+		return c.NoContent(http.StatusOK)
+	}, limiter.PerIP("public", ratelimit.Rule{Limit: 1, Window: time.Minute}))
+
+	doRequest := func(clientIP string) int {
+		req := httptest.NewRequest(http.MethodGet, "/analytics", nil)
+		req.RemoteAddr = "10.0.0.5:52000"
+		req.Header.Set(echo.HeaderXForwardedFor, clientIP)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	assert.Equal(t, http.StatusOK, doRequest("198.51.100.1"))
+	assert.Equal(t, http.StatusTooManyRequests, doRequest("198.51.100.1"))
+	assert.Equal(t, http.StatusOK, doRequest("198.51.100.2"))
+}
+
+func TestRateLimiter_PerUser(t *testing.T) {
+	store := ratelimit.NewMemoryStore(time.Minute)
+	defer store.Stop()
+
+	e := echo.New()
+	limiter := NewRateLimiter(store, logging.NewNop())
+	setUser := func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			c.Set("user_id", c.Request().Header.Get("X-Test-User"))
+			return next(c)
+		}
+	}
+	e.POST("/entries", func(c echo.Context) error {
+		return c.NoContent(http.StatusCreated)
+	}, setUser, limiter.PerUser("entry_writes", ratelimit.Rule{Limit: 1, Window: 24 * time.Hour}))
+
+	doRequest := func(userID string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/entries", nil)
+		req.Header.Set("X-Test-User", userID)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+
+	assert.Equal(t, http.StatusCreated, doRequest("user1").Code)
+
+	rec := doRequest("user1")
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+	assert.NotEmpty(t, rec.Header().Get("Retry-After"))
+
+	assert.Equal(t, http.StatusCreated, doRequest("user2").Code)
+}
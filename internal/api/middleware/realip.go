@@ -0,0 +1,30 @@
+package middleware
+
+import (
+	"net"
+
+	"github.com/labstack/echo/v4"
+)
+
+// IPExtractor decides how c.RealIP finds the client address. Forwarding
+// headers are only honoured when the request arrives through one of the
+// trusted proxy ranges, so clients can't pick the IP used for rate limits and
+// session hashes. Without trusted proxies the connection's address is used.
+func IPExtractor(trustedProxies []string) echo.IPExtractor {
+	if len(trustedProxies) == 0 {
+		return echo.ExtractIPDirect()
+	}
+
+	options := []echo.TrustOption{
+		echo.TrustLoopback(false),
+		echo.TrustLinkLocal(false),
+		echo.TrustPrivateNet(false),
+	}
+	for _, cidr := range trustedProxies {
+		// The ranges are validated when the config is loaded.
+		if _, ipRange, err := net.ParseCIDR(cidr); err == nil {
+			options = append(options, echo.TrustIPRange(ipRange))
+		}
+	}
+	return echo.ExtractIPFromXFFHeader(options...)
+}
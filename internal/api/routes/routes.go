@@ -1,57 +1,99 @@
 package routes
 
 import (
+	"log/slog"
+	"time"
+
 	"github.com/eminsonlu/salystic/internal/api/handlers"
 	authMiddleware "github.com/eminsonlu/salystic/internal/api/middleware"
 	"github.com/eminsonlu/salystic/internal/config"
+	"github.com/eminsonlu/salystic/internal/metrics"
+	"github.com/eminsonlu/salystic/internal/model"
 	"github.com/eminsonlu/salystic/internal/repo"
 	"github.com/eminsonlu/salystic/internal/service"
-	"github.com/eminsonlu/salystic/pkg/database"
+	"github.com/eminsonlu/salystic/pkg/health"
+	"github.com/eminsonlu/salystic/pkg/ratelimit"
 
 	"github.com/labstack/echo/v4"
-	"github.com/labstack/echo/v4/middleware"
 )
 
-func SetupRoutes(e *echo.Echo, db *database.MongoDB, authService service.AuthService, cfg *config.Config) {
-	e.Use(middleware.Logger())
-	e.Use(middleware.Recover())
-	e.Use(middleware.CORS())
+func SetupRoutes(e *echo.Echo, repos *repo.Repositories, authService service.AuthService, cfg *config.Config, rateLimitStore ratelimit.Store, logger *slog.Logger, appMetrics *metrics.Metrics, checker *health.Checker) func() {
+	e.IPExtractor = authMiddleware.IPExtractor(cfg.TrustedProxies)
+
+	e.Use(authMiddleware.RequestID())
+	e.Use(authMiddleware.Tracing())
+	e.Use(authMiddleware.RequestLogger(logger))
+	e.Use(authMiddleware.Metrics(appMetrics))
+	e.Use(authMiddleware.Recover(logger))
+	e.Use(authMiddleware.CORSWithConfig(cfg.FrontendURLs))
 
-	salaryRepo := repo.NewSalaryEntryRepository(db)
-	constantsRepo := repo.NewConstantsRepository(db)
-	analyticsRepo := repo.NewAnalyticsRepo(db.Database)
+	salaryService := service.NewSalaryEntryService(repos.SalaryEntries, repos.EntryRevisions, repos.Transactor, cfg.EntryRetention, logger)
+	analyticsService := service.NewAnalyticsService(repos.Analytics, cfg.ExchangeRates, logger)
+	apiKeyService := service.NewAPIKeyService(repos.APIKeys, logger)
+	entryPurger := service.NewEntryPurger(repos.SalaryEntries, repos.EntryRevisions, cfg.EntryRetention, cfg.EntryPurgeInterval, logger)
 
-	salaryService := service.NewSalaryEntryService(salaryRepo)
-	analyticsService := service.NewAnalyticsService(analyticsRepo)
+	checker.AddStartupTask("analytics_cache_warmup", analyticsService.WarmUp)
 
-	healthHandler := handlers.NewHealthHandler(db)
-	authHandler := handlers.NewAuthHandler(authService, cfg)
-	salaryHandler := handlers.NewSalaryHandler(salaryService)
-	constantsHandler := handlers.NewConstantsHandler(constantsRepo)
-	analyticsHandler := handlers.NewAnalyticsHandler(analyticsService)
+	healthHandler := handlers.NewHealthHandler(checker)
+	authHandler := handlers.NewAuthHandler(authService, cfg, logger)
+	salaryHandler := handlers.NewSalaryHandler(salaryService, logger)
+	constantsHandler := handlers.NewConstantsHandler(repos.Constants, logger)
+	analyticsHandler := handlers.NewAnalyticsHandler(analyticsService, logger)
+	apiKeyHandler := handlers.NewAPIKeyHandler(apiKeyService, logger)
 	authMW := authMiddleware.NewAuthMiddleware(authService)
+	apiKeyMW := authMiddleware.NewAPIKeyMiddleware(apiKeyService)
+	rateLimiter := authMiddleware.NewRateLimiter(rateLimitStore, logger)
 
 	e.GET("/health", healthHandler.Health)
+	e.GET("/livez", healthHandler.Live)
+	e.GET("/readyz", healthHandler.Ready)
+
+	if cfg.MetricsEnabled {
+		appMetrics.RegisterCache("general", analyticsService.GeneralCacheStats)
+		appMetrics.RegisterCache("career", analyticsService.CareerCacheStats)
+		appMetrics.RegisterCache("compensation", analyticsService.CompensationCacheStats)
+		appMetrics.RegisterCache("detailed", analyticsService.DetailedCacheStats)
+		appMetrics.RegisterEntryStats(analyticsService.EntryStats, time.Minute)
+
+		var metricsMiddleware []echo.MiddlewareFunc
+		if cfg.MetricsUsername != "" {
+			metricsMiddleware = append(metricsMiddleware, authMiddleware.MetricsBasicAuth(cfg.MetricsUsername, cfg.MetricsPassword))
+		}
+		e.GET("/metrics", echo.WrapHandler(appMetrics.Handler()), metricsMiddleware...)
+	}
 
-	authGroup := e.Group("/auth")
+	authGroup := e.Group("/auth", rateLimiter.PerIP("auth", cfg.RateLimitAuth))
 	authGroup.GET("/linkedin", authHandler.LinkedInLogin)
 	authGroup.GET("/linkedin/callback", authHandler.LinkedInCallback)
+	authGroup.POST("/token", authHandler.ExchangeCode)
 	authGroup.GET("/me", authHandler.Me, authMW.RequireAuth)
 	authGroup.POST("/logout", authHandler.Logout, authMW.RequireAuth)
+	authGroup.GET("/sessions", authHandler.ListSessions, authMW.RequireAuth)
+	authGroup.DELETE("/sessions/:id", authHandler.RevokeSession, authMW.RequireAuth)
 
 	api := e.Group("/api/v1")
 	api.GET("/health", healthHandler.Health)
 
-	entriesGroup := api.Group("/entries", authMW.RequireAuth)
-	entriesGroup.POST("", salaryHandler.CreateEntry)
+	entryWrites := rateLimiter.PerUser("entry_writes", cfg.RateLimitEntryWrites)
+	entriesGroup := api.Group("/entries", authMW.RequireAuth, rateLimiter.PerUser("private", cfg.RateLimitPrivate))
+	entriesGroup.POST("", salaryHandler.CreateEntry, rateLimiter.PerUser("new_entries", cfg.RateLimitNewEntries))
 	entriesGroup.GET("", salaryHandler.GetUserEntries)
+	entriesGroup.GET("/deleted", salaryHandler.GetDeletedEntries)
 	entriesGroup.GET("/:id", salaryHandler.GetEntry)
-	entriesGroup.PUT("/:id", salaryHandler.UpdateEntry)
-	entriesGroup.DELETE("/:id", salaryHandler.DeleteEntry)
-	entriesGroup.POST("/:id/raises", salaryHandler.AddRaise)
+	entriesGroup.PUT("/:id", salaryHandler.UpdateEntry, entryWrites)
+	entriesGroup.DELETE("/:id", salaryHandler.DeleteEntry, entryWrites)
+	entriesGroup.POST("/:id/raises", salaryHandler.AddRaise, entryWrites)
 	entriesGroup.GET("/:id/raises", salaryHandler.GetRaises)
+	entriesGroup.PUT("/:id/raises/:raiseId", salaryHandler.UpdateRaise, entryWrites)
+	entriesGroup.DELETE("/:id/raises/:raiseId", salaryHandler.DeleteRaise, entryWrites)
+	entriesGroup.GET("/:id/history", salaryHandler.GetEntryHistory)
+	entriesGroup.POST("/:id/history/:version/restore", salaryHandler.RestoreEntry, entryWrites)
+	entriesGroup.POST("/:id/undelete", salaryHandler.UndeleteEntry, entryWrites)
 
-	constantsGroup := api.Group("/constants")
+	meGroup := api.Group("/me", authMW.RequireAuth, rateLimiter.PerUser("private", cfg.RateLimitPrivate))
+	meGroup.GET("/timeline", salaryHandler.GetTimeline)
+
+	constantsGroup := api.Group("/constants", apiKeyMW.Optional(model.ScopeReadConstants), rateLimiter.PerIP("public", cfg.RateLimitPublic))
 	constantsGroup.GET("/positions", constantsHandler.GetPositions)
 	constantsGroup.GET("/levels", constantsHandler.GetLevels)
 	constantsGroup.GET("/tech-stacks", constantsHandler.GetTechStacks)
@@ -62,9 +104,23 @@ func SetupRoutes(e *echo.Echo, db *database.MongoDB, authService service.AuthSer
 	constantsGroup.GET("/cities", constantsHandler.GetCities)
 	constantsGroup.GET("/currencies", constantsHandler.GetCurrencies)
 
-	analyticsGroup := api.Group("/analytics")
+	analyticsGroup := api.Group("/analytics", apiKeyMW.Optional(model.ScopeReadAnalytics), rateLimiter.PerIP("public", cfg.RateLimitPublic))
 	analyticsGroup.GET("", analyticsHandler.GetGeneralAnalytics)
 	analyticsGroup.GET("/career", analyticsHandler.GetCareerAnalytics)
+	analyticsGroup.GET("/compensation", analyticsHandler.GetCompensationAnalytics)
 	analyticsGroup.GET("/positions", analyticsHandler.GetAvailablePositions)
 	analyticsGroup.GET("/levels", analyticsHandler.GetAvailableLevels)
+	analyticsGroup.GET("/export", analyticsHandler.ExportAnalytics, apiKeyMW.Require(model.ScopeExport))
+
+	adminGroup := api.Group("/admin", authMW.RequireAuth, authMiddleware.RequireAdmin(cfg.AdminUserIDs))
+	adminGroup.POST("/api-keys", apiKeyHandler.CreateKey)
+	adminGroup.GET("/api-keys", apiKeyHandler.ListKeys)
+	adminGroup.DELETE("/api-keys/:id", apiKeyHandler.RevokeKey)
+	adminGroup.GET("/entries/:id/history", salaryHandler.GetEntryHistoryForAdmin)
+
+	return func() {
+		analyticsService.Close()
+		apiKeyService.Close()
+		entryPurger.Stop()
+	}
 }
@@ -0,0 +1,51 @@
+package auth
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/eminsonlu/salystic/internal/model"
+
+	"github.com/jellydator/ttlcache/v3"
+)
+
+type HandoffStore struct {
+	codes *ttlcache.Cache[string, *model.AuthResponse]
+}
+
+func NewHandoffStore(ttl time.Duration) *HandoffStore {
+	codes := ttlcache.New(
+		ttlcache.WithTTL[string, *model.AuthResponse](ttl),
+		ttlcache.WithDisableTouchOnHit[string, *model.AuthResponse](),
+	)
+	go codes.Start()
+
+	return &HandoffStore{
+		codes: codes,
+	}
+}
+
+func (s *HandoffStore) Issue(authResponse *model.AuthResponse) (string, error) {
+	bytes := make([]byte, 32)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", fmt.Errorf("failed to generate handoff code: %w", err)
+	}
+
+	code := hex.EncodeToString(bytes)
+	s.codes.Set(code, authResponse, ttlcache.DefaultTTL)
+	return code, nil
+}
+
+func (s *HandoffStore) Redeem(code string) (*model.AuthResponse, error) {
+	item, found := s.codes.GetAndDelete(code)
+	if !found || item.IsExpired() {
+		return nil, fmt.Errorf("invalid or expired handoff code")
+	}
+	return item.Value(), nil
+}
+
+func (s *HandoffStore) Stop() {
+	s.codes.Stop()
+}
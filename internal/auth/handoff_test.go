@@ -0,0 +1,63 @@
+package auth
+
+import (
+	"testing"
+	"time"
+
+	"github.com/eminsonlu/salystic/internal/model"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandoffStore_IssueAndRedeem(t *testing.T) {
+	store := NewHandoffStore(time.Minute)
+	defer store.Stop()
+
+	authResponse := &model.AuthResponse{AccessToken: "access_token"}
+
+	code, err := store.Issue(authResponse)
+	assert.NoError(t, err)
+	assert.Len(t, code, 64)
+
+	redeemed, err := store.Redeem(code)
+	assert.NoError(t, err)
+	assert.Equal(t, authResponse, redeemed)
+}
+
+func TestHandoffStore_RedeemIsOneTime(t *testing.T) {
+	store := NewHandoffStore(time.Minute)
+	defer store.Stop()
+
+	code, err := store.Issue(&model.AuthResponse{AccessToken: "access_token"})
+	assert.NoError(t, err)
+
+	_, err = store.Redeem(code)
+	assert.NoError(t, err)
+
+	redeemed, err := store.Redeem(code)
+	assert.Error(t, err)
+	assert.Nil(t, redeemed)
+}
+
+func TestHandoffStore_Expired(t *testing.T) {
+	store := NewHandoffStore(10 * time.Millisecond)
+	defer store.Stop()
+
+	code, err := store.Issue(&model.AuthResponse{AccessToken: "access_token"})
+	assert.NoError(t, err)
+
+	time.Sleep(20 * time.Millisecond)
+
+	redeemed, err := store.Redeem(code)
+	assert.Error(t, err)
+	assert.Nil(t, redeemed)
+}
+
+func TestHandoffStore_UnknownCode(t *testing.T) {
+	store := NewHandoffStore(time.Minute)
+	defer store.Stop()
+
+	redeemed, err := store.Redeem("unknown")
+	assert.Error(t, err)
+	assert.Nil(t, redeemed)
+}
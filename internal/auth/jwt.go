@@ -6,12 +6,15 @@ import (
 	"encoding/hex"
 	"fmt"
 	"github.com/eminsonlu/salystic/internal/model"
+	"net"
 	"strconv"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
 )
 
+const AccessTokenCookieName = "access_token"
+
 type JWTManager struct {
 	secret     string
 	expiry     time.Duration
@@ -31,13 +34,18 @@ func NewJWTManager(secret, expiryStr, hmacSecret string) (*JWTManager, error) {
 	}, nil
 }
 
-func (j *JWTManager) GenerateToken(user *model.User) (string, int64, error) {
+func (j *JWTManager) Expiry() time.Duration {
+	return j.expiry
+}
+
+func (j *JWTManager) GenerateToken(user *model.User, sessionID string) (string, int64, error) {
 	now := time.Now()
 	expiresAt := now.Add(j.expiry)
 
 	claims := model.JWTClaims{
 		UserID:          user.ID.Hex(),
 		PseudonymizedID: user.PseudonymizedID,
+		SessionID:       sessionID,
 		StandardClaims: model.StandardClaims{
 			IssuedAt:  now.Unix(),
 			ExpiresAt: expiresAt.Unix(),
@@ -49,6 +57,7 @@ func (j *JWTManager) GenerateToken(user *model.User) (string, int64, error) {
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
 		"user_id":          claims.UserID,
 		"pseudonymized_id": claims.PseudonymizedID,
+		"sid":              claims.SessionID,
 		"iat":              claims.IssuedAt,
 		"exp":              claims.ExpiresAt,
 		"iss":              claims.Issuer,
@@ -94,6 +103,8 @@ func (j *JWTManager) ValidateToken(tokenString string) (*model.JWTClaims, error)
 		return nil, fmt.Errorf("invalid pseudonymized_id claim")
 	}
 
+	sessionID, _ := claims["sid"].(string)
+
 	iat, ok := claims["iat"].(float64)
 	if !ok {
 		return nil, fmt.Errorf("invalid iat claim")
@@ -117,6 +128,7 @@ func (j *JWTManager) ValidateToken(tokenString string) (*model.JWTClaims, error)
 	return &model.JWTClaims{
 		UserID:          userID,
 		PseudonymizedID: pseudonymizedID,
+		SessionID:       sessionID,
 		StandardClaims: model.StandardClaims{
 			IssuedAt:  int64(iat),
 			ExpiresAt: int64(exp),
@@ -132,3 +144,18 @@ func (j *JWTManager) PseudonymizeLinkedInID(linkedInID string) string {
 	h.Write([]byte(strconv.FormatInt(time.Now().Unix()/86400, 10)))
 	return hex.EncodeToString(h.Sum(nil))
 }
+
+func (j *JWTManager) HashClientIP(ip string) string {
+	coarse := ip
+	if parsed := net.ParseIP(ip); parsed != nil {
+		if v4 := parsed.To4(); v4 != nil {
+			coarse = v4.Mask(net.CIDRMask(24, 32)).String()
+		} else {
+			coarse = parsed.Mask(net.CIDRMask(48, 128)).String()
+		}
+	}
+
+	h := hmac.New(sha256.New, []byte(j.hmacSecret))
+	h.Write([]byte(coarse))
+	return hex.EncodeToString(h.Sum(nil))[:16]
+}
@@ -49,7 +49,7 @@ func TestGenerateToken_Success(t *testing.T) {
 		PseudonymizedID: "pseudo_id_123",
 	}
 
-	tokenString, expiresAt, err := jwtManager.GenerateToken(user)
+	tokenString, expiresAt, err := jwtManager.GenerateToken(user, "session_id")
 
 	assert.NoError(t, err)
 	assert.NotEmpty(t, tokenString)
@@ -70,7 +70,7 @@ func TestValidateToken_Success(t *testing.T) {
 		PseudonymizedID: "pseudo_id_123",
 	}
 
-	tokenString, _, err := jwtManager.GenerateToken(user)
+	tokenString, _, err := jwtManager.GenerateToken(user, "session_id")
 	assert.NoError(t, err)
 
 	claims, err := jwtManager.ValidateToken(tokenString)
@@ -119,7 +119,7 @@ func TestValidateToken_WrongSecret(t *testing.T) {
 		PseudonymizedID: "pseudo_id_123",
 	}
 
-	tokenString, _, err := jwtManager1.GenerateToken(user)
+	tokenString, _, err := jwtManager1.GenerateToken(user, "session_id")
 	assert.NoError(t, err)
 
 	claims, err := jwtManager2.ValidateToken(tokenString)
@@ -141,7 +141,7 @@ func TestValidateToken_ExpiredToken(t *testing.T) {
 		PseudonymizedID: "pseudo_id_123",
 	}
 
-	tokenString, _, err := jwtManager.GenerateToken(user)
+	tokenString, _, err := jwtManager.GenerateToken(user, "session_id")
 	assert.NoError(t, err)
 
 	time.Sleep(10 * time.Millisecond)
@@ -202,7 +202,7 @@ func TestGenerateToken_ValidateToken_RoundTrip(t *testing.T) {
 		PseudonymizedID: "pseudo_id_456",
 	}
 
-	tokenString, expiresAt, err := jwtManager.GenerateToken(originalUser)
+	tokenString, expiresAt, err := jwtManager.GenerateToken(originalUser, "session_id")
 	assert.NoError(t, err)
 	assert.NotEmpty(t, tokenString)
 	assert.Greater(t, expiresAt, time.Now().Unix())
@@ -213,6 +213,7 @@ func TestGenerateToken_ValidateToken_RoundTrip(t *testing.T) {
 
 	assert.Equal(t, originalUser.ID.Hex(), claims.UserID)
 	assert.Equal(t, originalUser.PseudonymizedID, claims.PseudonymizedID)
+	assert.Equal(t, "session_id", claims.SessionID)
 	assert.Equal(t, "salystic-backend", claims.Issuer)
 	assert.Equal(t, originalUser.ID.Hex(), claims.Subject)
 }
@@ -231,4 +232,21 @@ func TestValidateToken_MissingClaims(t *testing.T) {
 
 	assert.Error(t, err)
 	assert.Nil(t, claims)
-}
\ No newline at end of file
+}
+func TestHashClientIP(t *testing.T) {
+	jwtManager, err := NewJWTManager("test_secret", "1h", "hmac_secret")
+	assert.NoError(t, err)
+
+	hash1 := jwtManager.HashClientIP("203.0.113.10")
+	hash2 := jwtManager.HashClientIP("203.0.113.200")
+	hash3 := jwtManager.HashClientIP("198.51.100.10")
+
+	assert.Len(t, hash1, 16)
+	assert.Equal(t, hash1, hash2)
+	assert.NotEqual(t, hash1, hash3)
+	assert.NotContains(t, hash1, "203.0.113")
+
+	v6Hash1 := jwtManager.HashClientIP("2001:db8:abcd:1::1")
+	v6Hash2 := jwtManager.HashClientIP("2001:db8:abcd:2::1")
+	assert.Equal(t, v6Hash1, v6Hash2)
+}
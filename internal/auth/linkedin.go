@@ -36,12 +36,16 @@ func NewLinkedInOAuth(clientID, clientSecret, redirectURL, hmacSecret string) *L
 	}
 }
 
-func (l *LinkedInOAuth) GetAuthURL(state string) string {
-	return l.config.AuthCodeURL(state, oauth2.AccessTypeOffline)
+func (l *LinkedInOAuth) GetAuthURL(state, codeVerifier string) string {
+	return l.config.AuthCodeURL(state, oauth2.AccessTypeOffline, oauth2.S256ChallengeOption(codeVerifier))
 }
 
-func (l *LinkedInOAuth) ExchangeCodeForToken(ctx context.Context, code string) (*oauth2.Token, error) {
-	return l.config.Exchange(ctx, code)
+func (l *LinkedInOAuth) ExchangeCodeForToken(ctx context.Context, code, codeVerifier string) (*oauth2.Token, error) {
+	return l.config.Exchange(ctx, code, oauth2.VerifierOption(codeVerifier))
+}
+
+func GenerateCodeVerifier() string {
+	return oauth2.GenerateVerifier()
 }
 
 func (l *LinkedInOAuth) GetLinkedInProfile(ctx context.Context, token *oauth2.Token) (*model.LinkedInProfile, error) {
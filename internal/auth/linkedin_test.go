@@ -38,17 +38,23 @@ func TestGetAuthURL(t *testing.T) {
 	linkedinOAuth := NewLinkedInOAuth(clientID, clientSecret, redirectURL, hmacSecret)
 
 	state := "test_state_123"
-	authURL := linkedinOAuth.GetAuthURL(state)
+	verifier := GenerateCodeVerifier()
+	authURL := linkedinOAuth.GetAuthURL(state, verifier)
 
 	assert.NotEmpty(t, authURL)
 	assert.Contains(t, authURL, "linkedin.com")
 	assert.Contains(t, authURL, "client_id="+clientID)
 	assert.Contains(t, authURL, "state="+state)
 	assert.Contains(t, authURL, "scope=openid+profile+email")
+	assert.Contains(t, authURL, "code_challenge_method=S256")
+	assert.Contains(t, authURL, "code_challenge="+oauth2.S256ChallengeFromVerifier(verifier))
 }
 
 func TestExchangeCodeForToken_Success(t *testing.T) {
+	var receivedVerifier string
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		receivedVerifier = r.Form.Get("code_verifier")
 		tokenResponse := map[string]interface{}{
 			"access_token": "mock_access_token",
 			"token_type":   "Bearer",
@@ -74,12 +80,13 @@ func TestExchangeCodeForToken_Success(t *testing.T) {
 	ctx := context.Background()
 	code := "authorization_code"
 
-	token, err := linkedinOAuth.ExchangeCodeForToken(ctx, code)
+	token, err := linkedinOAuth.ExchangeCodeForToken(ctx, code, "test_verifier")
 
 	assert.NoError(t, err)
 	assert.NotNil(t, token)
 	assert.Equal(t, "mock_access_token", token.AccessToken)
 	assert.Equal(t, "Bearer", token.TokenType)
+	assert.Equal(t, "test_verifier", receivedVerifier)
 }
 
 func TestGetLinkedInProfile_Success(t *testing.T) {
@@ -1,50 +1,550 @@
 package config
 
 import (
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
 	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/eminsonlu/salystic/pkg/currency"
+	"github.com/eminsonlu/salystic/pkg/ratelimit"
 
 	"github.com/joho/godotenv"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	EnvDevelopment = "development"
+	EnvProduction  = "production"
+
+	StorageMongo    = "mongo"
+	StoragePostgres = "postgres"
+	StorageMemory   = "memory"
+
+	defaultJWTSecret  = "your_jwt_secret"
+	defaultHMACSecret = "your_hmac_secret"
+	minSecretLength   = 32
 )
 
+var insecureSecrets = map[string]bool{
+	defaultJWTSecret:                    true,
+	defaultHMACSecret:                   true,
+	"your_jwt_secret_here_change_this":  true,
+	"your_hmac_secret_here_change_this": true,
+}
+
 type Config struct {
-	Port                 string
+	Environment          string
+	Port                 int
+	ServerReadTimeout    time.Duration
+	ServerWriteTimeout   time.Duration
+	ServerIdleTimeout    time.Duration
+	ShutdownTimeout      time.Duration
+	LogLevel             string
+	LogFormat            string
+	Storage              string
 	MongoURI             string
 	MongoDB              string
 	MongoUser            string
 	MongoPass            string
+	PostgresURL          string
 	JWTSecret            string
-	JWTExpiry            string
+	JWTExpiry            time.Duration
 	LinkedInClientID     string
 	LinkedInClientSecret string
 	HMACSecret           string
 	LinkedInRedirectURL  string
 	FrontendCallbackURL  string
+	FrontendURLs         []string
+	AuthHandoffMode      string
+	AuthHandoffCodeTTL   time.Duration
+	CookieSecure         bool
+	CookieSameSite       string
+	CookieDomain         string
+	AdminUserIDs         []string
+	TrustedProxies       []string
+	RateLimitPublic      ratelimit.Rule
+	RateLimitAuth        ratelimit.Rule
+	RateLimitPrivate     ratelimit.Rule
+	RateLimitNewEntries  ratelimit.Rule
+	RateLimitEntryWrites ratelimit.Rule
+	EntryRetention       time.Duration
+	EntryPurgeInterval   time.Duration
+	MetricsEnabled       bool
+	MetricsUsername      string
+	MetricsPassword      string
+	TracingExporter      string
+	TracingServiceName   string
+	TracingOTLPEndpoint  string
+	TracingOTLPInsecure  bool
+	TracingSampleRatio   float64
+	MigrationsAutoApply  bool
+	MigrationsLockWait   time.Duration
+	IndexSync            bool
+	ExchangeRates        currency.Rates
 }
 
-func Load() (*Config, error) {
+func Load(configFile string) (*Config, error) {
 	_ = godotenv.Load()
 
+	fileValues, err := readConfigFile(configFile)
+	if err != nil {
+		return nil, err
+	}
+
+	l := &loader{fileValues: fileValues}
+
 	cfg := &Config{
-		Port:                 getEnv("PORT", "8080"),
-		MongoURI:             getEnv("MONGO_URI", "mongodb://localhost:27017"),
-		MongoDB:              getEnv("MONGO_DB", "salarydb"),
-		MongoUser:            getEnv("MONGO_USER", "admin"),
-		MongoPass:            getEnv("MONGO_PASS", "admin"),
-		JWTSecret:            getEnv("JWT_SECRET", "your_jwt_secret"),
-		JWTExpiry:            getEnv("JWT_EXPIRY", "24h"),
-		LinkedInClientID:     getEnv("LINKEDIN_CLIENT_ID", ""),
-		LinkedInClientSecret: getEnv("LINKEDIN_CLIENT_SECRET", ""),
-		HMACSecret:           getEnv("HMAC_SECRET", "your_hmac_secret"),
-		LinkedInRedirectURL:  getEnv("LINKEDIN_REDIRECT_URL", "http://localhost:8080/auth/linkedin/callback"),
-		FrontendCallbackURL:  getEnv("FRONTEND_CALLBACK_URL", "http://localhost:3000/auth/callback"),
+		Environment:          l.string("APP_ENV", EnvDevelopment),
+		Port:                 l.int("PORT", 8080),
+		ServerReadTimeout:    l.duration("SERVER_READ_TIMEOUT", 15*time.Second),
+		ServerWriteTimeout:   l.duration("SERVER_WRITE_TIMEOUT", 30*time.Second),
+		ServerIdleTimeout:    l.duration("SERVER_IDLE_TIMEOUT", 60*time.Second),
+		ShutdownTimeout:      l.duration("SHUTDOWN_TIMEOUT", 20*time.Second),
+		LogLevel:             l.string("LOG_LEVEL", "info"),
+		LogFormat:            l.string("LOG_FORMAT", "text"),
+		Storage:              l.string("STORAGE", StorageMongo),
+		MongoURI:             l.string("MONGO_URI", "mongodb://localhost:27017"),
+		MongoDB:              l.string("MONGO_DB", "salarydb"),
+		MongoUser:            l.string("MONGO_USER", "admin"),
+		MongoPass:            l.string("MONGO_PASS", "admin"),
+		PostgresURL:          l.string("POSTGRES_URL", "postgres://localhost:5432/salarydb?sslmode=disable"),
+		JWTSecret:            l.string("JWT_SECRET", defaultJWTSecret),
+		JWTExpiry:            l.duration("JWT_EXPIRY", 24*time.Hour),
+		LinkedInClientID:     l.string("LINKEDIN_CLIENT_ID", ""),
+		LinkedInClientSecret: l.string("LINKEDIN_CLIENT_SECRET", ""),
+		HMACSecret:           l.string("HMAC_SECRET", defaultHMACSecret),
+		LinkedInRedirectURL:  l.string("LINKEDIN_REDIRECT_URL", "http://localhost:8080/auth/linkedin/callback"),
+		FrontendCallbackURL:  l.string("FRONTEND_CALLBACK_URL", "http://localhost:3000/auth/callback"),
+		FrontendURLs:         l.list("FRONTEND_URL", []string{"http://localhost:3000"}),
+		AuthHandoffMode:      l.string("AUTH_HANDOFF_MODE", "code"),
+		AuthHandoffCodeTTL:   l.duration("AUTH_HANDOFF_CODE_TTL", time.Minute),
+		CookieSecure:         l.bool("COOKIE_SECURE", true),
+		CookieSameSite:       l.string("COOKIE_SAMESITE", "lax"),
+		CookieDomain:         l.string("COOKIE_DOMAIN", ""),
+		AdminUserIDs:         l.list("ADMIN_USER_IDS", nil),
+		TrustedProxies:       l.list("TRUSTED_PROXIES", nil),
+		RateLimitPublic:      l.rule("RATE_LIMIT_PUBLIC", "120/1m"),
+		RateLimitAuth:        l.rule("RATE_LIMIT_AUTH", "20/1m"),
+		RateLimitPrivate:     l.rule("RATE_LIMIT_PRIVATE", "300/1m"),
+		RateLimitNewEntries:  l.rule("RATE_LIMIT_NEW_ENTRIES", "10/24h"),
+		RateLimitEntryWrites: l.rule("RATE_LIMIT_ENTRY_WRITES", "100/1h"),
+		EntryRetention:       l.duration("ENTRY_RETENTION", 30*24*time.Hour),
+		EntryPurgeInterval:   l.duration("ENTRY_PURGE_INTERVAL", time.Hour),
+		MetricsEnabled:       l.bool("METRICS_ENABLED", true),
+		MetricsUsername:      l.string("METRICS_USERNAME", ""),
+		MetricsPassword:      l.string("METRICS_PASSWORD", ""),
+		TracingExporter:      l.string("TRACING_EXPORTER", "none"),
+		TracingServiceName:   l.string("TRACING_SERVICE_NAME", "salystic-api"),
+		TracingOTLPEndpoint:  l.string("TRACING_OTLP_ENDPOINT", "localhost:4318"),
+		TracingOTLPInsecure:  l.bool("TRACING_OTLP_INSECURE", false),
+		TracingSampleRatio:   l.float("TRACING_SAMPLE_RATIO", 1),
+		MigrationsAutoApply:  l.bool("MIGRATIONS_AUTO_APPLY", true),
+		MigrationsLockWait:   l.duration("MIGRATIONS_LOCK_WAIT", 2*time.Minute),
+		IndexSync:            l.bool("INDEX_SYNC", false),
+		ExchangeRates:        l.rates("EXCHANGE_RATES", "TRY=1,USD=34,EUR=37,GBP=43"),
+	}
+
+	if len(l.errs) > 0 {
+		return nil, fmt.Errorf("invalid configuration: %w", errors.Join(l.errs...))
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
 	}
 
 	return cfg, nil
 }
 
-func getEnv(key, defaultValue string) string {
+func (c *Config) IsProduction() bool {
+	return c.Environment == EnvProduction
+}
+
+func (c *Config) Validate() error {
+	var errs []error
+
+	if c.Environment != EnvDevelopment && c.Environment != EnvProduction {
+		errs = append(errs, fmt.Errorf("APP_ENV must be %q or %q", EnvDevelopment, EnvProduction))
+	}
+
+	switch c.Storage {
+	case StorageMongo, StorageMemory:
+	case StoragePostgres:
+		if c.PostgresURL == "" {
+			errs = append(errs, fmt.Errorf("POSTGRES_URL is required when STORAGE=%s", StoragePostgres))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("STORAGE must be %q, %q or %q", StorageMongo, StoragePostgres, StorageMemory))
+	}
+
+	if c.Port < 1 || c.Port > 65535 {
+		errs = append(errs, fmt.Errorf("PORT must be between 1 and 65535"))
+	}
+
+	for key, value := range map[string]time.Duration{
+		"SERVER_READ_TIMEOUT":  c.ServerReadTimeout,
+		"SERVER_WRITE_TIMEOUT": c.ServerWriteTimeout,
+		"SERVER_IDLE_TIMEOUT":  c.ServerIdleTimeout,
+		"SHUTDOWN_TIMEOUT":     c.ShutdownTimeout,
+		"MIGRATIONS_LOCK_WAIT": c.MigrationsLockWait,
+		"ENTRY_RETENTION":      c.EntryRetention,
+		"ENTRY_PURGE_INTERVAL": c.EntryPurgeInterval,
+	} {
+		if value <= 0 {
+			errs = append(errs, fmt.Errorf("%s must be positive", key))
+		}
+	}
+
+	switch strings.ToLower(c.LogLevel) {
+	case "debug", "info", "warn", "error":
+	default:
+		errs = append(errs, fmt.Errorf("LOG_LEVEL must be debug, info, warn or error"))
+	}
+
+	if c.LogFormat != "text" && c.LogFormat != "json" {
+		errs = append(errs, fmt.Errorf("LOG_FORMAT must be \"text\" or \"json\""))
+	}
+
+	if c.JWTExpiry <= 0 {
+		errs = append(errs, fmt.Errorf("JWT_EXPIRY must be positive"))
+	}
+
+	if c.AuthHandoffCodeTTL <= 0 {
+		errs = append(errs, fmt.Errorf("AUTH_HANDOFF_CODE_TTL must be positive"))
+	}
+
+	if c.AuthHandoffMode != "code" && c.AuthHandoffMode != "cookie" {
+		errs = append(errs, fmt.Errorf("AUTH_HANDOFF_MODE must be \"code\" or \"cookie\""))
+	}
+
+	switch strings.ToLower(c.CookieSameSite) {
+	case "lax", "strict", "none":
+	default:
+		errs = append(errs, fmt.Errorf("COOKIE_SAMESITE must be lax, strict or none"))
+	}
+
+	for _, origin := range c.FrontendURLs {
+		if !isAbsoluteURL(origin) {
+			errs = append(errs, fmt.Errorf("FRONTEND_URL contains invalid origin %q", origin))
+		}
+	}
+
+	for _, cidr := range c.TrustedProxies {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			errs = append(errs, fmt.Errorf("TRUSTED_PROXIES contains invalid CIDR %q", cidr))
+		}
+	}
+
+	for key, value := range map[string]string{
+		"FRONTEND_CALLBACK_URL": c.FrontendCallbackURL,
+		"LINKEDIN_REDIRECT_URL": c.LinkedInRedirectURL,
+	} {
+		if !isAbsoluteURL(value) {
+			errs = append(errs, fmt.Errorf("%s must be an absolute URL", key))
+		}
+	}
+
+	if (c.MetricsUsername == "") != (c.MetricsPassword == "") {
+		errs = append(errs, fmt.Errorf("METRICS_USERNAME and METRICS_PASSWORD must be set together"))
+	}
+
+	switch c.TracingExporter {
+	case "none", "stdout", "otlp":
+	default:
+		errs = append(errs, fmt.Errorf("TRACING_EXPORTER must be none, stdout or otlp"))
+	}
+
+	if c.TracingSampleRatio < 0 || c.TracingSampleRatio > 1 {
+		errs = append(errs, fmt.Errorf("TRACING_SAMPLE_RATIO must be between 0 and 1"))
+	}
+
+	if c.IsProduction() {
+		for key, value := range map[string]string{
+			"JWT_SECRET":  c.JWTSecret,
+			"HMAC_SECRET": c.HMACSecret,
+		} {
+			if insecureSecrets[value] {
+				errs = append(errs, fmt.Errorf("%s must be changed from its default value in production", key))
+			} else if len(value) < minSecretLength {
+				errs = append(errs, fmt.Errorf("%s must be at least %d characters in production", key, minSecretLength))
+			}
+		}
+
+		if c.LinkedInClientID == "" || c.LinkedInClientSecret == "" {
+			errs = append(errs, fmt.Errorf("LINKEDIN_CLIENT_ID and LINKEDIN_CLIENT_SECRET are required in production"))
+		}
+
+		if !c.CookieSecure {
+			errs = append(errs, fmt.Errorf("COOKIE_SECURE must be enabled in production"))
+		}
+
+		if c.Storage == StorageMemory {
+			errs = append(errs, fmt.Errorf("STORAGE=%s is not allowed in production", StorageMemory))
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid configuration: %w", errors.Join(errs...))
+	}
+	return nil
+}
+
+func (c *Config) PrintRedacted(w io.Writer) error {
+	values := []struct {
+		key   string
+		value string
+	}{
+		{"app_env", c.Environment},
+		{"port", strconv.Itoa(c.Port)},
+		{"server_read_timeout", c.ServerReadTimeout.String()},
+		{"server_write_timeout", c.ServerWriteTimeout.String()},
+		{"server_idle_timeout", c.ServerIdleTimeout.String()},
+		{"shutdown_timeout", c.ShutdownTimeout.String()},
+		{"log_level", c.LogLevel},
+		{"log_format", c.LogFormat},
+		{"storage", c.Storage},
+		{"mongo_uri", redactURL(c.MongoURI)},
+		{"mongo_db", c.MongoDB},
+		{"mongo_user", c.MongoUser},
+		{"mongo_pass", redact(c.MongoPass)},
+		{"postgres_url", redactURL(c.PostgresURL)},
+		{"jwt_secret", redact(c.JWTSecret)},
+		{"jwt_expiry", c.JWTExpiry.String()},
+		{"linkedin_client_id", c.LinkedInClientID},
+		{"linkedin_client_secret", redact(c.LinkedInClientSecret)},
+		{"hmac_secret", redact(c.HMACSecret)},
+		{"linkedin_redirect_url", c.LinkedInRedirectURL},
+		{"frontend_callback_url", c.FrontendCallbackURL},
+		{"frontend_url", strings.Join(c.FrontendURLs, ",")},
+		{"auth_handoff_mode", c.AuthHandoffMode},
+		{"auth_handoff_code_ttl", c.AuthHandoffCodeTTL.String()},
+		{"cookie_secure", strconv.FormatBool(c.CookieSecure)},
+		{"cookie_samesite", c.CookieSameSite},
+		{"cookie_domain", c.CookieDomain},
+		{"admin_user_ids", strings.Join(c.AdminUserIDs, ",")},
+		{"trusted_proxies", strings.Join(c.TrustedProxies, ",")},
+		{"rate_limit_public", formatRule(c.RateLimitPublic)},
+		{"rate_limit_auth", formatRule(c.RateLimitAuth)},
+		{"rate_limit_private", formatRule(c.RateLimitPrivate)},
+		{"rate_limit_new_entries", formatRule(c.RateLimitNewEntries)},
+		{"rate_limit_entry_writes", formatRule(c.RateLimitEntryWrites)},
+		{"entry_retention", c.EntryRetention.String()},
+		{"entry_purge_interval", c.EntryPurgeInterval.String()},
+		{"metrics_enabled", strconv.FormatBool(c.MetricsEnabled)},
+		{"metrics_username", c.MetricsUsername},
+		{"metrics_password", redact(c.MetricsPassword)},
+		{"tracing_exporter", c.TracingExporter},
+		{"tracing_service_name", c.TracingServiceName},
+		{"tracing_otlp_endpoint", c.TracingOTLPEndpoint},
+		{"tracing_otlp_insecure", strconv.FormatBool(c.TracingOTLPInsecure)},
+		{"tracing_sample_ratio", strconv.FormatFloat(c.TracingSampleRatio, 'f', -1, 64)},
+		{"migrations_auto_apply", strconv.FormatBool(c.MigrationsAutoApply)},
+		{"migrations_lock_wait", c.MigrationsLockWait.String()},
+		{"index_sync", strconv.FormatBool(c.IndexSync)},
+		{"exchange_rates", c.ExchangeRates.String()},
+	}
+
+	for _, v := range values {
+		if _, err := fmt.Fprintf(w, "%s: %q\n", v.key, v.value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type loader struct {
+	fileValues map[string]string
+	errs       []error
+}
+
+func (l *loader) lookup(key string) (string, bool) {
+	if value := os.Getenv(key); value != "" {
+		return value, true
+	}
+
+	if path := l.raw(key + "_FILE"); path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			l.errs = append(l.errs, fmt.Errorf("%s_FILE: %w", key, err))
+			return "", false
+		}
+		return strings.TrimSpace(string(data)), true
+	}
+
+	if value, ok := l.fileValues[key]; ok && value != "" {
+		return value, true
+	}
+
+	return "", false
+}
+
+func (l *loader) raw(key string) string {
 	if value := os.Getenv(key); value != "" {
 		return value
 	}
+	return l.fileValues[key]
+}
+
+func (l *loader) string(key, defaultValue string) string {
+	if value, ok := l.lookup(key); ok {
+		return value
+	}
 	return defaultValue
 }
+
+func (l *loader) int(key string, defaultValue int) int {
+	value, ok := l.lookup(key)
+	if !ok {
+		return defaultValue
+	}
+
+	parsed, err := strconv.Atoi(value)
+	if err != nil {
+		l.errs = append(l.errs, fmt.Errorf("%s must be an integer, got %q", key, value))
+		return defaultValue
+	}
+	return parsed
+}
+
+func (l *loader) float(key string, defaultValue float64) float64 {
+	value, ok := l.lookup(key)
+	if !ok {
+		return defaultValue
+	}
+
+	parsed, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		l.errs = append(l.errs, fmt.Errorf("%s must be a number, got %q", key, value))
+		return defaultValue
+	}
+	return parsed
+}
+
+func (l *loader) bool(key string, defaultValue bool) bool {
+	value, ok := l.lookup(key)
+	if !ok {
+		return defaultValue
+	}
+
+	parsed, err := strconv.ParseBool(value)
+	if err != nil {
+		l.errs = append(l.errs, fmt.Errorf("%s must be a boolean, got %q", key, value))
+		return defaultValue
+	}
+	return parsed
+}
+
+func (l *loader) duration(key string, defaultValue time.Duration) time.Duration {
+	value, ok := l.lookup(key)
+	if !ok {
+		return defaultValue
+	}
+
+	parsed, err := time.ParseDuration(value)
+	if err != nil {
+		l.errs = append(l.errs, fmt.Errorf("%s must be a duration, got %q", key, value))
+		return defaultValue
+	}
+	return parsed
+}
+
+func (l *loader) list(key string, defaultValue []string) []string {
+	value, ok := l.lookup(key)
+	if !ok {
+		return defaultValue
+	}
+
+	var values []string
+	for _, item := range strings.Split(value, ",") {
+		if trimmed := strings.TrimSpace(item); trimmed != "" {
+			values = append(values, trimmed)
+		}
+	}
+	return values
+}
+
+func (l *loader) rule(key, defaultValue string) ratelimit.Rule {
+	value := l.string(key, defaultValue)
+
+	rule, err := ratelimit.ParseRule(value)
+	if err != nil {
+		l.errs = append(l.errs, fmt.Errorf("%s: %w", key, err))
+	}
+	return rule
+}
+
+func (l *loader) rates(key, defaultValue string) currency.Rates {
+	value := l.string(key, defaultValue)
+
+	rates, err := currency.ParseRates(value)
+	if err != nil {
+		l.errs = append(l.errs, fmt.Errorf("%s: %w", key, err))
+	}
+	return rates
+}
+
+func readConfigFile(path string) (map[string]string, error) {
+	values := map[string]string{}
+	if path == "" {
+		return values, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	var raw map[string]interface{}
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse config file: %w", err)
+	}
+
+	for key, value := range raw {
+		normalized := strings.ToUpper(key)
+		switch v := value.(type) {
+		case nil:
+		case []interface{}:
+			items := make([]string, len(v))
+			for i, item := range v {
+				items[i] = fmt.Sprint(item)
+			}
+			values[normalized] = strings.Join(items, ",")
+		default:
+			values[normalized] = fmt.Sprint(v)
+		}
+	}
+
+	return values, nil
+}
+
+func isAbsoluteURL(value string) bool {
+	parsed, err := url.Parse(value)
+	return err == nil && parsed.Scheme != "" && parsed.Host != ""
+}
+
+func redact(value string) string {
+	if value == "" {
+		return ""
+	}
+	return "[REDACTED]"
+}
+
+func redactURL(value string) string {
+	parsed, err := url.Parse(value)
+	if err != nil || parsed.User == nil {
+		return value
+	}
+	if _, hasPassword := parsed.User.Password(); hasPassword {
+		parsed.User = url.UserPassword(parsed.User.Username(), "REDACTED")
+	}
+	return parsed.String()
+}
+
+func formatRule(rule ratelimit.Rule) string {
+	return fmt.Sprintf("%d/%s", rule.Limit, rule.Window)
+}
@@ -0,0 +1,182 @@
+package metrics
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.mongodb.org/mongo-driver/event"
+)
+
+const namespace = "salystic"
+
+type CacheStats struct {
+	Hits   uint64
+	Misses uint64
+}
+
+type EntryStats struct {
+	Total      int64
+	ByCurrency map[string]int64
+}
+
+type Metrics struct {
+	registry      *prometheus.Registry
+	httpDuration  *prometheus.HistogramVec
+	mongoDuration *prometheus.HistogramVec
+}
+
+func New() *Metrics {
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+
+	m := &Metrics{
+		registry: registry,
+		httpDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "http_request_duration_seconds",
+			Help:      "HTTP request latency by route, method and status code.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "route", "status"}),
+		mongoDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "mongo_operation_duration_seconds",
+			Help:      "MongoDB command latency by collection, command and outcome.",
+			Buckets:   []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5},
+		}, []string{"collection", "operation", "outcome"}),
+	}
+
+	registry.MustRegister(m.httpDuration, m.mongoDuration)
+	return m
+}
+
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{Registry: m.registry})
+}
+
+func (m *Metrics) ObserveHTTPRequest(method, route string, status int, duration time.Duration) {
+	m.httpDuration.WithLabelValues(method, route, strconv.Itoa(status)).Observe(duration.Seconds())
+}
+
+func (m *Metrics) ObserveMongoOperation(collection, operation string, failed bool, duration time.Duration) {
+	outcome := "success"
+	if failed {
+		outcome = "error"
+	}
+	m.mongoDuration.WithLabelValues(collection, operation, outcome).Observe(duration.Seconds())
+}
+
+func (m *Metrics) MongoMonitor() *event.CommandMonitor {
+	var collections sync.Map
+
+	return &event.CommandMonitor{
+		Started: func(_ context.Context, e *event.CommandStartedEvent) {
+			collection, _ := e.Command.Lookup(e.CommandName).StringValueOK()
+			collections.Store(e.RequestID, collection)
+		},
+		Succeeded: func(_ context.Context, e *event.CommandSucceededEvent) {
+			collection, _ := collections.LoadAndDelete(e.RequestID)
+			name, _ := collection.(string)
+			m.ObserveMongoOperation(name, e.CommandName, false, e.Duration)
+		},
+		Failed: func(_ context.Context, e *event.CommandFailedEvent) {
+			collection, _ := collections.LoadAndDelete(e.RequestID)
+			name, _ := collection.(string)
+			m.ObserveMongoOperation(name, e.CommandName, true, e.Duration)
+		},
+	}
+}
+
+func (m *Metrics) RegisterCache(name string, stats func() CacheStats) {
+	labels := prometheus.Labels{"cache": name}
+
+	m.registry.MustRegister(
+		prometheus.NewCounterFunc(prometheus.CounterOpts{
+			Namespace:   namespace,
+			Name:        "analytics_cache_hits_total",
+			Help:        "Analytics cache lookups served from the cache.",
+			ConstLabels: labels,
+		}, func() float64 { return float64(stats().Hits) }),
+		prometheus.NewCounterFunc(prometheus.CounterOpts{
+			Namespace:   namespace,
+			Name:        "analytics_cache_misses_total",
+			Help:        "Analytics cache lookups that had to query MongoDB.",
+			ConstLabels: labels,
+		}, func() float64 { return float64(stats().Misses) }),
+	)
+}
+
+func (m *Metrics) RegisterEntryStats(fetch func(ctx context.Context) (*EntryStats, error), refreshInterval time.Duration) {
+	m.registry.MustRegister(&entryStatsCollector{
+		fetch:           fetch,
+		refreshInterval: refreshInterval,
+		totalDesc: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "salary_entries"),
+			"Total number of salary entries.",
+			nil, nil,
+		),
+		currencyDesc: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "salary_entries_by_currency"),
+			"Number of salary entries per currency.",
+			[]string{"currency"}, nil,
+		),
+	})
+}
+
+type entryStatsCollector struct {
+	fetch           func(ctx context.Context) (*EntryStats, error)
+	refreshInterval time.Duration
+	totalDesc       *prometheus.Desc
+	currencyDesc    *prometheus.Desc
+
+	mu        sync.Mutex
+	stats     *EntryStats
+	fetchedAt time.Time
+}
+
+func (c *entryStatsCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.totalDesc
+	ch <- c.currencyDesc
+}
+
+func (c *entryStatsCollector) Collect(ch chan<- prometheus.Metric) {
+	stats, err := c.current()
+	if err != nil {
+		ch <- prometheus.NewInvalidMetric(c.totalDesc, err)
+		return
+	}
+
+	ch <- prometheus.MustNewConstMetric(c.totalDesc, prometheus.GaugeValue, float64(stats.Total))
+	for currency, count := range stats.ByCurrency {
+		ch <- prometheus.MustNewConstMetric(c.currencyDesc, prometheus.GaugeValue, float64(count), currency)
+	}
+}
+
+func (c *entryStatsCollector) current() (*EntryStats, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.stats != nil && time.Since(c.fetchedAt) < c.refreshInterval {
+		return c.stats, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	stats, err := c.fetch(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	c.stats = stats
+	c.fetchedAt = time.Now()
+	return stats, nil
+}
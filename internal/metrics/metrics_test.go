@@ -0,0 +1,75 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func scrape(t *testing.T, m *Metrics) string {
+	rec := httptest.NewRecorder()
+	m.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	body, err := io.ReadAll(rec.Body)
+	require.NoError(t, err)
+	return string(body)
+}
+
+func TestMetrics_HTTPAndMongoHistograms(t *testing.T) {
+	m := New()
+
+	m.ObserveHTTPRequest(http.MethodGet, "/api/v1/entries/:id", http.StatusOK, 20*time.Millisecond)
+	m.ObserveMongoOperation("salary_entries", "find", false, 3*time.Millisecond)
+	m.ObserveMongoOperation("salary_entries", "insert", true, time.Millisecond)
+
+	body := scrape(t, m)
+	assert.Contains(t, body, `salystic_http_request_duration_seconds_count{method="GET",route="/api/v1/entries/:id",status="200"} 1`)
+	assert.Contains(t, body, `salystic_mongo_operation_duration_seconds_count{collection="salary_entries",operation="find",outcome="success"} 1`)
+	assert.Contains(t, body, `salystic_mongo_operation_duration_seconds_count{collection="salary_entries",operation="insert",outcome="error"} 1`)
+}
+
+func TestMetrics_CacheCounters(t *testing.T) {
+	m := New()
+	m.RegisterCache("general", func() CacheStats { return CacheStats{Hits: 7, Misses: 3} })
+
+	body := scrape(t, m)
+	assert.Contains(t, body, `salystic_analytics_cache_hits_total{cache="general"} 7`)
+	assert.Contains(t, body, `salystic_analytics_cache_misses_total{cache="general"} 3`)
+}
+
+func TestMetrics_EntryStatsAreCachedBetweenScrapes(t *testing.T) {
+	m := New()
+	calls := 0
+	m.RegisterEntryStats(func(ctx context.Context) (*EntryStats, error) {
+		calls++
+		return &EntryStats{Total: 5, ByCurrency: map[string]int64{"TRY": 4, "USD": 1}}, nil
+	}, time.Minute)
+
+	body := scrape(t, m)
+	scrape(t, m)
+
+	assert.Equal(t, 1, calls)
+	assert.Contains(t, body, "salystic_salary_entries 5")
+	assert.Contains(t, body, `salystic_salary_entries_by_currency{currency="TRY"} 4`)
+	assert.Contains(t, body, `salystic_salary_entries_by_currency{currency="USD"} 1`)
+}
+
+func TestMetrics_EntryStatsErrorFailsScrape(t *testing.T) {
+	m := New()
+	m.RegisterEntryStats(func(ctx context.Context) (*EntryStats, error) {
+		return nil, errors.New("mongo unavailable")
+	}, time.Minute)
+
+	rec := httptest.NewRecorder()
+	m.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+}
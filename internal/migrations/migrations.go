@@ -0,0 +1,314 @@
+package migrations
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"time"
+
+	"github.com/eminsonlu/salystic/internal/model"
+	"github.com/eminsonlu/salystic/internal/repo"
+	"github.com/eminsonlu/salystic/pkg/database"
+	"github.com/eminsonlu/salystic/pkg/migrate"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const (
+	namespaceNotFoundCode = 26
+	indexNotFoundCode     = 27
+)
+
+var constantCollections = []string{
+	"positions",
+	"levels",
+	"tech_stacks",
+	"experiences",
+	"companies",
+	"company_sizes",
+	"work_types",
+	"cities",
+	"currencies",
+}
+
+var indexedCollections = []string{
+	"salary_entries",
+	"users",
+	"sessions",
+	"api_keys",
+}
+
+func All(db *database.MongoDB, logger *slog.Logger) []migrate.Migration {
+	constantsRepo := repo.NewConstantsRepository(db, logger)
+	indexRepo := repo.NewIndexRepo(db.Database, logger)
+
+	return []migrate.Migration{
+		{
+			Version:     1,
+			Description: "seed reference constants",
+			Up:          constantsRepo.SeedConstants,
+			Down: func(ctx context.Context) error {
+				for _, name := range constantCollections {
+					if err := db.Database.Collection(name).Drop(ctx); err != nil {
+						return fmt.Errorf("failed to drop %s: %w", name, err)
+					}
+				}
+				return nil
+			},
+		},
+		{
+			Version:     2,
+			Description: "create initial indexes",
+			Up: func(ctx context.Context) error {
+				for _, def := range initialIndexes() {
+					if err := indexRepo.CreateIndexes(ctx, def.Collection, def.Indexes); err != nil {
+						return err
+					}
+				}
+				return nil
+			},
+			Down: func(ctx context.Context) error {
+				for _, name := range indexedCollections {
+					if _, err := db.Database.Collection(name).Indexes().DropAll(ctx); err != nil {
+						return fmt.Errorf("failed to drop indexes on %s: %w", name, err)
+					}
+				}
+				return nil
+			},
+		},
+		{
+			Version:     3,
+			Description: "replace linkedin_id and camelCase career analytics indexes",
+			Up: func(ctx context.Context) error {
+				if err := dropIndexIfExists(ctx, db, "users", "linkedin_id_idx"); err != nil {
+					return err
+				}
+				if err := dropIndexIfExists(ctx, db, "salary_entries", "career_analytics_idx"); err != nil {
+					return err
+				}
+				if err := indexRepo.CreateIndexes(ctx, "salary_entries", []repo.IndexSpec{{
+					Name: "career_analytics_idx",
+					Keys: bson.D{{Key: "raises", Value: 1}, {Key: "start_time", Value: 1}, {Key: "end_time", Value: 1}},
+				}}); err != nil {
+					return err
+				}
+				return indexRepo.CreateIndexes(ctx, "users", []repo.IndexSpec{pseudonymizedIDIndex(false)})
+			},
+			Down: func(ctx context.Context) error {
+				if err := dropIndexIfExists(ctx, db, "users", "pseudonymized_id_idx"); err != nil {
+					return err
+				}
+				if err := dropIndexIfExists(ctx, db, "salary_entries", "career_analytics_idx"); err != nil {
+					return err
+				}
+				if err := indexRepo.CreateIndexes(ctx, "salary_entries", []repo.IndexSpec{{
+					Name: "career_analytics_idx",
+					Keys: bson.D{{Key: "raises", Value: 1}, {Key: "startTime", Value: 1}, {Key: "endTime", Value: 1}},
+				}}); err != nil {
+					return err
+				}
+				return indexRepo.CreateIndexes(ctx, "users", []repo.IndexSpec{{
+					Name:   "linkedin_id_idx",
+					Keys:   bson.D{{Key: "linkedin_id", Value: 1}},
+					Unique: true,
+					Sparse: true,
+				}})
+			},
+		},
+		{
+			Version:     4,
+			Description: "merge duplicate users and make pseudonymized_id unique",
+			Up: func(ctx context.Context) error {
+				if err := mergeDuplicateUsers(ctx, db.Database, logger); err != nil {
+					return err
+				}
+				if err := dropIndexIfExists(ctx, db, "users", "pseudonymized_id_idx"); err != nil {
+					return err
+				}
+				return indexRepo.CreateIndexes(ctx, "users", []repo.IndexSpec{pseudonymizedIDIndex(true)})
+			},
+			Down: func(ctx context.Context) error {
+				if err := dropIndexIfExists(ctx, db, "users", "pseudonymized_id_idx"); err != nil {
+					return err
+				}
+				return indexRepo.CreateIndexes(ctx, "users", []repo.IndexSpec{pseudonymizedIDIndex(false)})
+			},
+		},
+		{
+			Version:     5,
+			Description: "index salary entries by user for paginated listing",
+			Up: func(ctx context.Context) error {
+				return indexRepo.CreateIndexes(ctx, "salary_entries", []repo.IndexSpec{{
+					Name: "user_entries_idx",
+					Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "created_at", Value: -1}},
+				}})
+			},
+			Down: func(ctx context.Context) error {
+				return dropIndexIfExists(ctx, db, "salary_entries", "user_entries_idx")
+			},
+		},
+		{
+			Version:     6,
+			Description: "record net monthly salary basis on existing salary entries",
+			Up: func(ctx context.Context) error {
+				_, err := db.Database.Collection("salary_entries").UpdateMany(ctx,
+					bson.M{"salary_basis": bson.M{"$exists": false}},
+					bson.M{"$set": bson.M{"salary_basis": model.SalaryBasisNetMonthly}},
+				)
+				if err != nil {
+					return fmt.Errorf("failed to set salary basis: %w", err)
+				}
+				return nil
+			},
+			// Entries saved with another basis since then lose it, and are
+			// read as net monthly again.
+			Down: func(ctx context.Context) error {
+				_, err := db.Database.Collection("salary_entries").UpdateMany(ctx,
+					bson.M{"salary_basis": bson.M{"$exists": true}},
+					bson.M{"$unset": bson.M{"salary_basis": ""}},
+				)
+				if err != nil {
+					return fmt.Errorf("failed to unset salary basis: %w", err)
+				}
+				return nil
+			},
+		},
+		{
+			Version:     7,
+			Description: "index entry revisions by entry and version",
+			Up: func(ctx context.Context) error {
+				return indexRepo.CreateIndexes(ctx, "entry_revisions", []repo.IndexSpec{{
+					Name:   "entry_revision_version_idx",
+					Keys:   bson.D{{Key: "entry_id", Value: 1}, {Key: "version", Value: 1}},
+					Unique: true,
+				}})
+			},
+			Down: func(ctx context.Context) error {
+				return dropIndexIfExists(ctx, db, "entry_revisions", "entry_revision_version_idx")
+			},
+		},
+		{
+			Version:     8,
+			Description: "index deleted salary entries for purging",
+			Up: func(ctx context.Context) error {
+				return indexRepo.CreateIndexes(ctx, "salary_entries", []repo.IndexSpec{{
+					Name:   "deleted_entries_idx",
+					Keys:   bson.D{{Key: "deleted_at", Value: 1}},
+					Sparse: true,
+				}})
+			},
+			Down: func(ctx context.Context) error {
+				return dropIndexIfExists(ctx, db, "salary_entries", "deleted_entries_idx")
+			},
+		},
+		{
+			Version:     9,
+			Description: "start raise versions of existing salary entries at zero",
+			Up: func(ctx context.Context) error {
+				_, err := db.Database.Collection("salary_entries").UpdateMany(ctx,
+					bson.M{"raises_version": bson.M{"$exists": false}},
+					bson.M{"$set": bson.M{"raises_version": 0}},
+				)
+				if err != nil {
+					return fmt.Errorf("failed to set raises version: %w", err)
+				}
+				return nil
+			},
+			Down: func(ctx context.Context) error {
+				_, err := db.Database.Collection("salary_entries").UpdateMany(ctx,
+					bson.M{},
+					bson.M{"$unset": bson.M{"raises_version": ""}},
+				)
+				if err != nil {
+					return fmt.Errorf("failed to unset raises version: %w", err)
+				}
+				return nil
+			},
+		},
+	}
+}
+
+// initialIndexes are the indexes migration 2 created when it was added. The
+// list is frozen so the migration stays reproducible; later indexes are added
+// by their own migrations.
+func initialIndexes() []repo.CollectionIndexes {
+	ttl := int32(0)
+
+	return []repo.CollectionIndexes{
+		{
+			Collection: "salary_entries",
+			Indexes: []repo.IndexSpec{
+				{Name: "analytics_filter_idx", Keys: bson.D{{Key: "position", Value: 1}, {Key: "level", Value: 1}, {Key: "currency", Value: 1}}},
+				{Name: "tech_analytics_idx", Keys: bson.D{{Key: "tech_stack", Value: 1}, {Key: "position", Value: 1}, {Key: "level", Value: 1}}},
+				{Name: "career_analytics_idx", Keys: bson.D{{Key: "raises", Value: 1}, {Key: "startTime", Value: 1}, {Key: "endTime", Value: 1}}},
+				{Name: "position_salary_idx", Keys: bson.D{{Key: "position", Value: 1}, {Key: "salary_min", Value: 1}}},
+				{Name: "level_salary_idx", Keys: bson.D{{Key: "level", Value: 1}, {Key: "salary_min", Value: 1}}},
+				{Name: "experience_salary_idx", Keys: bson.D{{Key: "experience", Value: 1}, {Key: "salary_min", Value: 1}}},
+				{Name: "company_salary_idx", Keys: bson.D{{Key: "company", Value: 1}, {Key: "salary_min", Value: 1}}},
+				{Name: "city_salary_idx", Keys: bson.D{{Key: "city", Value: 1}, {Key: "salary_min", Value: 1}}},
+				{Name: "company_size_salary_idx", Keys: bson.D{{Key: "company_size", Value: 1}, {Key: "salary_min", Value: 1}}},
+				{Name: "work_type_salary_idx", Keys: bson.D{{Key: "work_type", Value: 1}, {Key: "salary_min", Value: 1}}},
+				{Name: "currency_salary_idx", Keys: bson.D{{Key: "currency", Value: 1}, {Key: "salary_min", Value: 1}}},
+				{Name: "position_idx", Keys: bson.D{{Key: "position", Value: 1}}},
+				{Name: "level_idx", Keys: bson.D{{Key: "level", Value: 1}}},
+			},
+		},
+		{
+			Collection: "users",
+			Indexes: []repo.IndexSpec{
+				{Name: "linkedin_id_idx", Keys: bson.D{{Key: "linkedin_id", Value: 1}}, Unique: true, Sparse: true},
+			},
+		},
+		{
+			Collection: "sessions",
+			Indexes: []repo.IndexSpec{
+				{Name: "user_sessions_idx", Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "last_seen_at", Value: -1}}},
+				{Name: "session_expiry_ttl_idx", Keys: bson.D{{Key: "expires_at", Value: 1}}, ExpireAfterSeconds: &ttl},
+			},
+		},
+		{
+			Collection: "api_keys",
+			Indexes: []repo.IndexSpec{
+				{Name: "key_hash_idx", Keys: bson.D{{Key: "key_hash", Value: 1}}, Unique: true},
+			},
+		},
+	}
+}
+
+func pseudonymizedIDIndex(unique bool) repo.IndexSpec {
+	return repo.IndexSpec{
+		Name:   "pseudonymized_id_idx",
+		Keys:   bson.D{{Key: "pseudonymized_id", Value: 1}},
+		Unique: unique,
+	}
+}
+
+func dropIndexIfExists(ctx context.Context, db *database.MongoDB, collection, name string) error {
+	_, err := db.Database.Collection(collection).Indexes().DropOne(ctx, name)
+	var cmdErr mongo.CommandError
+	if errors.As(err, &cmdErr) && (cmdErr.Code == indexNotFoundCode || cmdErr.Code == namespaceNotFoundCode) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to drop index %s on %s: %w", name, collection, err)
+	}
+	return nil
+}
+
+func NewMigrator(db *database.MongoDB, logger *slog.Logger, lockWait time.Duration) (*migrate.Migrator, error) {
+	return migrate.New(migrate.NewMongoStore(db.Database), All(db, logger), logger, migrate.Options{
+		Owner:    lockOwner(),
+		LockWait: lockWait,
+	})
+}
+
+func lockOwner() string {
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "unknown"
+	}
+	return fmt.Sprintf("%s:%d", hostname, os.Getpid())
+}
@@ -0,0 +1,117 @@
+package migrations
+
+import (
+	"context"
+	"embed"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/eminsonlu/salystic/internal/repo"
+	"github.com/eminsonlu/salystic/pkg/database"
+	"github.com/eminsonlu/salystic/pkg/migrate"
+)
+
+//go:embed postgres/*.sql
+var postgresFiles embed.FS
+
+var sqlFilePattern = regexp.MustCompile(`^(\d+)_([a-z0-9_]+)\.(up|down)\.sql$`)
+
+func PostgresAll(db *database.Postgres, logger *slog.Logger) ([]migrate.Migration, error) {
+	migrations, err := sqlMigrations(postgresFiles, "postgres", func(ctx context.Context, sql string) error {
+		_, err := db.Pool.Exec(ctx, sql)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	constantsRepo := repo.NewPostgresConstantsRepository(db, logger)
+	migrations = append(migrations, migrate.Migration{
+		Version:     2,
+		Description: "seed reference constants",
+		Up:          constantsRepo.SeedConstants,
+		Down: func(ctx context.Context) error {
+			if _, err := db.Pool.Exec(ctx, `DELETE FROM constants`); err != nil {
+				return fmt.Errorf("failed to delete constants: %w", err)
+			}
+			return nil
+		},
+	})
+
+	return migrations, nil
+}
+
+func NewPostgresMigrator(db *database.Postgres, logger *slog.Logger, lockWait time.Duration) (*migrate.Migrator, error) {
+	all, err := PostgresAll(db, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	return migrate.New(migrate.NewPostgresStore(db.Pool), all, logger, migrate.Options{
+		Owner:    lockOwner(),
+		LockWait: lockWait,
+	})
+}
+
+func sqlMigrations(files fs.FS, dir string, exec func(ctx context.Context, sql string) error) ([]migrate.Migration, error) {
+	entries, err := fs.ReadDir(files, dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s migrations: %w", dir, err)
+	}
+
+	byVersion := make(map[int64]*migrate.Migration)
+	var versions []int64
+
+	for _, entry := range entries {
+		match := sqlFilePattern.FindStringSubmatch(entry.Name())
+		if match == nil {
+			return nil, fmt.Errorf("unexpected migration file name %q", entry.Name())
+		}
+
+		version, err := strconv.ParseInt(match[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid migration version in %q: %w", entry.Name(), err)
+		}
+
+		content, err := fs.ReadFile(files, path.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", entry.Name(), err)
+		}
+
+		migration, ok := byVersion[version]
+		if !ok {
+			migration = &migrate.Migration{
+				Version:     version,
+				Description: strings.ReplaceAll(match[2], "_", " "),
+			}
+			byVersion[version] = migration
+			versions = append(versions, version)
+		}
+
+		sql := string(content)
+		run := func(ctx context.Context) error {
+			if err := exec(ctx, sql); err != nil {
+				return fmt.Errorf("failed to run %s: %w", entry.Name(), err)
+			}
+			return nil
+		}
+
+		if match[3] == "up" {
+			migration.Up = run
+		} else {
+			migration.Down = run
+		}
+	}
+
+	migrations := make([]migrate.Migration, 0, len(versions))
+	for _, version := range versions {
+		migrations = append(migrations, *byVersion[version])
+	}
+	return migrations, nil
+}
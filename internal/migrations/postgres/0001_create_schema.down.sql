@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS constants;
+DROP TABLE IF EXISTS raises;
+DROP TABLE IF EXISTS salary_entry_tech_stacks;
+DROP TABLE IF EXISTS salary_entries;
+DROP TABLE IF EXISTS api_keys;
+DROP TABLE IF EXISTS sessions;
+DROP TABLE IF EXISTS users;
@@ -0,0 +1,91 @@
+CREATE TABLE users (
+    id               CHAR(24) PRIMARY KEY,
+    pseudonymized_id TEXT NOT NULL,
+    created_at       TIMESTAMPTZ NOT NULL,
+    updated_at       TIMESTAMPTZ NOT NULL,
+    last_login       TIMESTAMPTZ NOT NULL
+);
+
+CREATE UNIQUE INDEX users_pseudonymized_id_idx ON users (pseudonymized_id);
+
+CREATE TABLE sessions (
+    id           CHAR(24) PRIMARY KEY,
+    user_id      CHAR(24) NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    user_agent   TEXT NOT NULL DEFAULT '',
+    ip_hash      TEXT NOT NULL DEFAULT '',
+    created_at   TIMESTAMPTZ NOT NULL,
+    last_seen_at TIMESTAMPTZ NOT NULL,
+    expires_at   TIMESTAMPTZ NOT NULL,
+    revoked_at   TIMESTAMPTZ
+);
+
+CREATE INDEX sessions_user_active_idx ON sessions (user_id, expires_at) WHERE revoked_at IS NULL;
+
+CREATE TABLE api_keys (
+    id           CHAR(24) PRIMARY KEY,
+    name         TEXT NOT NULL,
+    prefix       TEXT NOT NULL,
+    key_hash     TEXT NOT NULL,
+    scopes       TEXT[] NOT NULL DEFAULT '{}',
+    rate_limit   INTEGER NOT NULL DEFAULT 0,
+    created_by   TEXT NOT NULL DEFAULT '',
+    created_at   TIMESTAMPTZ NOT NULL,
+    expires_at   TIMESTAMPTZ,
+    revoked_at   TIMESTAMPTZ,
+    last_used_at TIMESTAMPTZ,
+    usage_count  BIGINT NOT NULL DEFAULT 0
+);
+
+CREATE UNIQUE INDEX api_keys_key_hash_idx ON api_keys (key_hash);
+
+CREATE TABLE salary_entries (
+    id           CHAR(24) PRIMARY KEY,
+    user_id      CHAR(24) NOT NULL,
+    level        TEXT NOT NULL DEFAULT '',
+    position     TEXT NOT NULL DEFAULT '',
+    experience   TEXT NOT NULL DEFAULT '',
+    gender       TEXT NOT NULL DEFAULT '',
+    company      TEXT NOT NULL DEFAULT '',
+    company_size TEXT NOT NULL DEFAULT '',
+    work_type    TEXT NOT NULL DEFAULT '',
+    city         TEXT NOT NULL DEFAULT '',
+    currency     TEXT NOT NULL DEFAULT '',
+    salary_range TEXT NOT NULL DEFAULT '',
+    salary_min   BIGINT NOT NULL,
+    salary_max   BIGINT,
+    raise_period INTEGER NOT NULL DEFAULT 0,
+    start_time   TIMESTAMPTZ NOT NULL,
+    end_time     TIMESTAMPTZ,
+    created_at   TIMESTAMPTZ NOT NULL,
+    updated_at   TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX salary_entries_user_idx ON salary_entries (user_id, created_at DESC);
+CREATE INDEX salary_entries_analytics_idx ON salary_entries (position, level, currency);
+
+CREATE TABLE salary_entry_tech_stacks (
+    entry_id CHAR(24) NOT NULL REFERENCES salary_entries (id) ON DELETE CASCADE,
+    ordinal  INTEGER NOT NULL,
+    tech     TEXT NOT NULL,
+    PRIMARY KEY (entry_id, ordinal)
+);
+
+CREATE INDEX salary_entry_tech_stacks_tech_idx ON salary_entry_tech_stacks (tech);
+
+CREATE TABLE raises (
+    id         CHAR(24) PRIMARY KEY,
+    entry_id   CHAR(24) NOT NULL REFERENCES salary_entries (id) ON DELETE CASCADE,
+    raise_date TIMESTAMPTZ NOT NULL,
+    new_salary BIGINT NOT NULL,
+    percentage DOUBLE PRECISION NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX raises_entry_idx ON raises (entry_id, created_at);
+
+CREATE TABLE constants (
+    kind    TEXT NOT NULL,
+    name    TEXT NOT NULL,
+    ordinal INTEGER NOT NULL,
+    PRIMARY KEY (kind, name)
+);
@@ -0,0 +1 @@
+ALTER TABLE salary_entries ADD COLUMN concurrent BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1 @@
+ALTER TABLE salary_entries ADD COLUMN change_reason TEXT NOT NULL DEFAULT '';
@@ -0,0 +1 @@
+ALTER TABLE salary_entries ADD COLUMN compensation JSONB;
@@ -0,0 +1 @@
+ALTER TABLE salary_entries ADD COLUMN salary_basis TEXT NOT NULL DEFAULT 'net_monthly';
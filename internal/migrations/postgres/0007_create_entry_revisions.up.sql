@@ -0,0 +1,14 @@
+CREATE TABLE entry_revisions (
+    id            CHAR(24) PRIMARY KEY,
+    entry_id      CHAR(24) NOT NULL,
+    user_id       CHAR(24) NOT NULL,
+    version       INTEGER NOT NULL,
+    action        TEXT NOT NULL,
+    restored_from INTEGER NOT NULL DEFAULT 0,
+    changed_by    CHAR(24) NOT NULL,
+    changed_at    TIMESTAMPTZ NOT NULL,
+    changes       JSONB NOT NULL,
+    snapshot      JSONB NOT NULL
+);
+
+CREATE UNIQUE INDEX entry_revisions_version_idx ON entry_revisions (entry_id, version);
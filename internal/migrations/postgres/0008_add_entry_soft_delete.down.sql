@@ -0,0 +1 @@
+ALTER TABLE salary_entries DROP COLUMN deleted_at;
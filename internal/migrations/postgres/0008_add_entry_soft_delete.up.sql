@@ -0,0 +1,3 @@
+ALTER TABLE salary_entries ADD COLUMN deleted_at TIMESTAMPTZ;
+
+CREATE INDEX salary_entries_deleted_idx ON salary_entries (deleted_at) WHERE deleted_at IS NOT NULL;
@@ -0,0 +1 @@
+ALTER TABLE salary_entries ADD COLUMN raises_version INTEGER NOT NULL DEFAULT 0;
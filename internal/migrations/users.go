@@ -0,0 +1,76 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type duplicateUsers struct {
+	PseudonymizedID string               `bson:"_id"`
+	IDs             []primitive.ObjectID `bson:"ids"`
+	LastLogin       time.Time            `bson:"last_login"`
+}
+
+func mergeDuplicateUsers(ctx context.Context, db *mongo.Database, logger *slog.Logger) error {
+	users := db.Collection("users")
+
+	cursor, err := users.Aggregate(ctx, mongo.Pipeline{
+		{{Key: "$sort", Value: bson.D{{Key: "created_at", Value: 1}, {Key: "_id", Value: 1}}}},
+		{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: "$pseudonymized_id"},
+			{Key: "ids", Value: bson.D{{Key: "$push", Value: "$_id"}}},
+			{Key: "last_login", Value: bson.D{{Key: "$max", Value: "$last_login"}}},
+		}}},
+		{{Key: "$match", Value: bson.D{{Key: "ids.1", Value: bson.D{{Key: "$exists", Value: true}}}}}},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to find duplicate users: %w", err)
+	}
+
+	var groups []duplicateUsers
+	if err := cursor.All(ctx, &groups); err != nil {
+		return fmt.Errorf("failed to decode duplicate users: %w", err)
+	}
+
+	for _, group := range groups {
+		keep, duplicates := group.IDs[0], group.IDs[1:]
+
+		hexIDs := make([]string, 0, len(duplicates))
+		for _, id := range duplicates {
+			hexIDs = append(hexIDs, id.Hex())
+		}
+
+		reassign := []struct {
+			collection string
+			filter     bson.M
+			update     bson.M
+		}{
+			{"salary_entries", bson.M{"user_id": bson.M{"$in": duplicates}}, bson.M{"$set": bson.M{"user_id": keep}}},
+			{"sessions", bson.M{"user_id": bson.M{"$in": duplicates}}, bson.M{"$set": bson.M{"user_id": keep}}},
+			{"api_keys", bson.M{"created_by": bson.M{"$in": hexIDs}}, bson.M{"$set": bson.M{"created_by": keep.Hex()}}},
+		}
+		for _, r := range reassign {
+			if _, err := db.Collection(r.collection).UpdateMany(ctx, r.filter, r.update); err != nil {
+				return fmt.Errorf("failed to reassign %s to user %s: %w", r.collection, keep.Hex(), err)
+			}
+		}
+
+		if _, err := users.UpdateOne(ctx, bson.M{"_id": keep}, bson.M{"$max": bson.M{"last_login": group.LastLogin}}); err != nil {
+			return fmt.Errorf("failed to update user %s: %w", keep.Hex(), err)
+		}
+
+		if _, err := users.DeleteMany(ctx, bson.M{"_id": bson.M{"$in": duplicates}}); err != nil {
+			return fmt.Errorf("failed to delete duplicate users: %w", err)
+		}
+
+		logger.InfoContext(ctx, "Merged duplicate users", "user_id", keep.Hex(), "merged", len(duplicates))
+	}
+
+	return nil
+}
@@ -1,8 +1,13 @@
 package model
 
-import "time"
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
 
 type Analytics struct {
+	SalaryBasis               string             `json:"salaryBasis"`
 	TotalEntries              int64              `json:"totalEntries"`
 	AverageSalary             float64            `json:"averageSalary"`
 	AverageSalaryByPosition   map[string]float64 `json:"averageSalaryByPosition"`
@@ -35,9 +40,17 @@ type Analytics struct {
 	TopPayingPositions        []ChartDataPoint   `json:"topPayingPositions"`
 	TopPayingTechs            []ChartDataPoint   `json:"topPayingTechs"`
 	SalaryRanges              []ChartDataPoint   `json:"salaryRanges"`
+	SalaryPercentiles         SalaryPercentiles  `json:"salaryPercentiles"`
 	LastUpdated               time.Time          `json:"lastUpdated"`
 }
 
+type SalaryPercentiles struct {
+	P25 float64 `json:"p25"`
+	P50 float64 `json:"p50"`
+	P75 float64 `json:"p75"`
+	P90 float64 `json:"p90"`
+}
+
 type ChartDataPoint struct {
 	Name  string `json:"name"`
 	Value int    `json:"value"`
@@ -50,16 +63,83 @@ type CareerAnalytics struct {
 }
 
 type JobChangeAnalytics struct {
+	TotalChanges              int                  `json:"totalChanges"`
+	AverageSalaryIncrease     float64              `json:"averageSalaryIncrease"`
+	MedianSalaryIncrease      float64              `json:"medianSalaryIncrease"`
+	PercentageWithIncrease    float64              `json:"percentageWithIncrease"`
+	SalaryMotivatedPercentage float64              `json:"salaryMotivatedPercentage"`
+	ByPosition                []JobChangeBreakdown `json:"byPosition"`
+	ByLevel                   []JobChangeBreakdown `json:"byLevel"`
+}
+
+type JobChangeBreakdown struct {
+	Category               string  `json:"category"`
+	Count                  int     `json:"count"`
 	AverageSalaryIncrease  float64 `json:"averageSalaryIncrease"`
 	PercentageWithIncrease float64 `json:"percentageWithIncrease"`
 }
 
 type RaiseAnalytics struct {
-	AveragePerYear          float64 `json:"averagePerYear"`
+	TotalRaises             int                   `json:"totalRaises"`
+	AveragePerYear          float64               `json:"averagePerYear"`
+	AveragePercentage       float64               `json:"averagePercentage"`
+	PercentagePercentiles   RaisePercentiles      `json:"percentagePercentiles"`
+	MedianTimeBetweenRaises int                   `json:"medianTimeBetweenRaises"`
+	IntervalDistribution    []RaiseIntervalBucket `json:"intervalDistribution"`
+	ByCompanySize           []RaiseBreakdown      `json:"byCompanySize"`
+	BySector                []RaiseBreakdown      `json:"bySector"`
+	ByLevel                 []RaiseBreakdown      `json:"byLevel"`
+}
+
+type RaisePercentiles struct {
+	P10 float64 `json:"p10"`
+	P25 float64 `json:"p25"`
+	P50 float64 `json:"p50"`
+	P75 float64 `json:"p75"`
+	P90 float64 `json:"p90"`
+}
+
+// RaiseIntervalBucket counts the raises that came within a range of months
+// after the previous raise, or after the job started for the first raise.
+type RaiseIntervalBucket struct {
+	Range      string  `json:"range"`
+	Count      int     `json:"count"`
+	Percentage float64 `json:"percentage"`
+}
+
+type RaiseBreakdown struct {
+	Category                string  `json:"category"`
+	Count                   int     `json:"count"`
 	AveragePercentage       float64 `json:"averagePercentage"`
 	MedianTimeBetweenRaises int     `json:"medianTimeBetweenRaises"`
 }
 
+// CompensationAnalytics compares base salary with total compensation,
+// converted to Currency and normalized to Basis.
+type CompensationAnalytics struct {
+	Currency                      string               `json:"currency"`
+	Basis                         string               `json:"basis"`
+	TotalEntries                  int                  `json:"totalEntries"`
+	AverageBase                   float64              `json:"averageBase"`
+	AverageTotal                  float64              `json:"averageTotal"`
+	WithBonusPercentage           float64              `json:"withBonusPercentage"`
+	WithEquityPercentage          float64              `json:"withEquityPercentage"`
+	WithMealCardPercentage        float64              `json:"withMealCardPercentage"`
+	WithHealthInsurancePercentage float64              `json:"withHealthInsurancePercentage"`
+	ByPosition                    []CompensationCohort `json:"byPosition"`
+	ByLevel                       []CompensationCohort `json:"byLevel"`
+	ByExperience                  []CompensationCohort `json:"byExperience"`
+}
+
+type CompensationCohort struct {
+	Category     string  `json:"category"`
+	Count        int     `json:"count"`
+	AverageBase  float64 `json:"averageBase"`
+	AverageTotal float64 `json:"averageTotal"`
+	MedianBase   float64 `json:"medianBase"`
+	MedianTotal  float64 `json:"medianTotal"`
+}
+
 type SalaryByCategory struct {
 	Category string  `bson:"_id" json:"category"`
 	Average  float64 `bson:"average" json:"average"`
@@ -76,15 +156,55 @@ type SalaryByTech struct {
 	Count   int64   `bson:"count" json:"count"`
 }
 
+// JobChangeData is one non-concurrent job. Repositories return them ordered
+// by user and start time so consecutive items of a user are job changes.
 type JobChangeData struct {
-	SalaryMin int64   `bson:"salary_min"`
-	SalaryMax *int64  `bson:"salary_max"`
-	Raises    []Raise `bson:"raises"`
+	UserID       primitive.ObjectID `bson:"user_id"`
+	Position     string             `bson:"position"`
+	Level        string             `bson:"level"`
+	Currency     string             `bson:"currency"`
+	SalaryBasis  string             `bson:"salary_basis"`
+	SalaryMin    int64              `bson:"salary_min"`
+	StartTime    time.Time          `bson:"start_time"`
+	ChangeReason string             `bson:"change_reason"`
+	Raises       []Raise            `bson:"raises"`
 }
 
 type RaiseData struct {
-	EntryID   string     `bson:"_id"`
-	Raises    []Raise    `bson:"raises"`
-	StartTime time.Time  `bson:"startTime"`
-	EndTime   *time.Time `bson:"endTime"`
-}
\ No newline at end of file
+	EntryID     string     `bson:"_id"`
+	Level       string     `bson:"level"`
+	Company     string     `bson:"company"`
+	CompanySize string     `bson:"company_size"`
+	SalaryMin   int64      `bson:"salary_min"`
+	Raises      []Raise    `bson:"raises"`
+	StartTime   time.Time  `bson:"start_time"`
+	EndTime     *time.Time `bson:"end_time"`
+}
+
+type CompensationData struct {
+	Position     string        `bson:"position"`
+	Level        string        `bson:"level"`
+	Experience   string        `bson:"experience"`
+	Currency     string        `bson:"currency"`
+	SalaryBasis  string        `bson:"salary_basis"`
+	SalaryMin    int64         `bson:"salary_min"`
+	StartTime    time.Time     `bson:"start_time"`
+	Raises       []Raise       `bson:"raises"`
+	Compensation *Compensation `bson:"compensation"`
+}
+// DetailedAnalytics extends Analytics with the breakdowns only served to API
+// keys with the read:analytics scope.
+type DetailedAnalytics struct {
+	*Analytics
+	SalaryByPositionAndLevel []SalaryByPositionLevel      `json:"salaryByPositionAndLevel"`
+	PercentilesByPosition    map[string]SalaryPercentiles `json:"percentilesByPosition"`
+}
+
+type SalaryByPositionLevel struct {
+	Position string  `json:"position"`
+	Level    string  `json:"level"`
+	Average  float64 `json:"average"`
+	Min      float64 `json:"min"`
+	Max      float64 `json:"max"`
+	Count    int64   `json:"count"`
+}
@@ -0,0 +1,41 @@
+package model
+
+// Compensation holds the optional components paid on top of the base salary.
+// Amounts are in the entry's currency.
+type Compensation struct {
+	Bonus           *Bonus           `bson:"bonus,omitempty" json:"bonus,omitempty"`
+	Equity          *EquityGrant     `bson:"equity,omitempty" json:"equity,omitempty"`
+	MealCard        int64            `bson:"meal_card,omitempty" json:"meal_card,omitempty" validate:"min=0"`
+	HealthInsurance *HealthInsurance `bson:"health_insurance,omitempty" json:"health_insurance,omitempty"`
+	OtherBenefits   []Benefit        `bson:"other_benefits,omitempty" json:"other_benefits,omitempty" validate:"max=20,dive"`
+}
+
+// Bonus is the annual bonus, either as an amount or as a number of monthly
+// salaries.
+type Bonus struct {
+	Amount int64   `bson:"amount,omitempty" json:"amount,omitempty" validate:"required_without=Months,excluded_with=Months,min=0"`
+	Months float64 `bson:"months,omitempty" json:"months,omitempty" validate:"min=0,max=24"`
+}
+
+// EquityGrant is a stock grant whose total value vests over VestingMonths.
+type EquityGrant struct {
+	Type          string `bson:"type" json:"type" validate:"required,oneof=rsu stock_options"`
+	GrantValue    int64  `bson:"grant_value" json:"grant_value" validate:"required,min=1"`
+	VestingMonths int    `bson:"vesting_months" json:"vesting_months" validate:"required,min=1,max=120"`
+	CliffMonths   int    `bson:"cliff_months,omitempty" json:"cliff_months,omitempty" validate:"min=0,ltefield=VestingMonths"`
+}
+
+type HealthInsurance struct {
+	CoversFamily bool  `bson:"covers_family" json:"covers_family"`
+	MonthlyValue int64 `bson:"monthly_value,omitempty" json:"monthly_value,omitempty" validate:"min=0"`
+}
+
+type Benefit struct {
+	Name         string `bson:"name" json:"name" validate:"required,max=100"`
+	MonthlyValue int64  `bson:"monthly_value,omitempty" json:"monthly_value,omitempty" validate:"min=0"`
+}
+
+const (
+	EquityTypeRSU          = "rsu"
+	EquityTypeStockOptions = "stock_options"
+)
@@ -0,0 +1,43 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// EntryRevision is an immutable record of one change to a salary entry or its
+// raises. Snapshot is the entry after the change, or before it for a delete,
+// so any revision can be restored.
+type EntryRevision struct {
+	ID           primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	EntryID      primitive.ObjectID `bson:"entry_id" json:"entry_id"`
+	UserID       primitive.ObjectID `bson:"user_id" json:"user_id"`
+	Version      int                `bson:"version" json:"version"`
+	Action       string             `bson:"action" json:"action"`
+	RestoredFrom int                `bson:"restored_from,omitempty" json:"restored_from,omitempty"`
+	ChangedBy    primitive.ObjectID `bson:"changed_by" json:"changed_by"`
+	ChangedAt    time.Time          `bson:"changed_at" json:"changed_at"`
+	Changes      []FieldChange      `bson:"changes" json:"changes"`
+	Snapshot     *SalaryEntry       `bson:"snapshot" json:"snapshot"`
+}
+
+// FieldChange is the JSON encoded value of an entry field before and after a
+// change. Old is empty for a created entry and New for a deleted one.
+type FieldChange struct {
+	Field string          `bson:"field" json:"field"`
+	Old   json.RawMessage `bson:"old,omitempty" json:"old,omitempty"`
+	New   json.RawMessage `bson:"new,omitempty" json:"new,omitempty"`
+}
+
+const (
+	RevisionActionCreate      = "create"
+	RevisionActionUpdate      = "update"
+	RevisionActionDelete      = "delete"
+	RevisionActionRaiseAdd    = "raise_add"
+	RevisionActionRaiseUpdate = "raise_update"
+	RevisionActionRaiseDelete = "raise_delete"
+	RevisionActionRestore     = "restore"
+	RevisionActionUndelete    = "undelete"
+)
@@ -0,0 +1,24 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type Session struct {
+	ID         primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	UserID     primitive.ObjectID `bson:"user_id" json:"-"`
+	UserAgent  string             `bson:"user_agent" json:"user_agent"`
+	IPHash     string             `bson:"ip_hash" json:"ip_hash"`
+	CreatedAt  time.Time          `bson:"created_at" json:"created_at"`
+	LastSeenAt time.Time          `bson:"last_seen_at" json:"last_seen_at"`
+	ExpiresAt  time.Time          `bson:"expires_at" json:"expires_at"`
+	RevokedAt  *time.Time         `bson:"revoked_at,omitempty" json:"revoked_at,omitempty"`
+	Current    bool               `bson:"-" json:"current"`
+}
+
+type SessionMetadata struct {
+	UserAgent string
+	IPAddress string
+}
@@ -0,0 +1,38 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type Timeline struct {
+	Jobs       []TimelineJob `json:"jobs"`
+	JobChanges []JobChange   `json:"job_changes"`
+}
+
+// TimelineJob is an entry placed in the user's career history. Concurrent
+// jobs are listed but not linked to the jobs around them.
+type TimelineJob struct {
+	*SalaryEntry
+	PreviousJobID  *primitive.ObjectID `json:"previous_job_id,omitempty"`
+	NextJobID      *primitive.ObjectID `json:"next_job_id,omitempty"`
+	StartingSalary int64               `json:"starting_salary"`
+	FinalSalary    int64               `json:"final_salary"`
+}
+
+// JobChange compares the final salary of a job with the starting salary of
+// the one that followed it. Increase and Percentage are only set when both
+// jobs were paid in the same currency.
+type JobChange struct {
+	FromEntryID  primitive.ObjectID `json:"from_entry_id"`
+	ToEntryID    primitive.ObjectID `json:"to_entry_id"`
+	ChangedAt    time.Time          `json:"changed_at"`
+	GapDays      int                `json:"gap_days"`
+	FromSalary   int64              `json:"from_salary"`
+	ToSalary     int64              `json:"to_salary"`
+	FromCurrency string             `json:"from_currency"`
+	ToCurrency   string             `json:"to_currency"`
+	Increase     *int64             `json:"increase,omitempty"`
+	Percentage   *float64           `json:"percentage,omitempty"`
+}
@@ -17,6 +17,7 @@ type User struct {
 type JWTClaims struct {
 	UserID          string `json:"user_id"`
 	PseudonymizedID string `json:"pseudonymized_id"`
+	SessionID       string `json:"sid"`
 	StandardClaims
 }
 
@@ -45,3 +46,7 @@ type AuthResponse struct {
 	TokenType   string           `json:"token_type"`
 	ExpiresIn   int64            `json:"expires_in"`
 }
+
+type TokenExchangeRequest struct {
+	Code string `json:"code" validate:"required"`
+}
@@ -3,6 +3,7 @@ package repo
 import (
 	"context"
 	"fmt"
+	"math"
 	"strings"
 
 	"github.com/eminsonlu/salystic/internal/model"
@@ -11,11 +12,35 @@ import (
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
+type AnalyticsRepository interface {
+	GetTotalEntries(ctx context.Context, filter *AnalyticsFilter) (int64, error)
+	CountEntriesByCurrency(ctx context.Context) (map[string]int64, error)
+	GetAverageSalaryByPosition(ctx context.Context, filter *AnalyticsFilter) ([]model.SalaryByCategory, error)
+	GetAverageSalaryByLevel(ctx context.Context, filter *AnalyticsFilter) ([]model.SalaryByCategory, error)
+	GetAverageSalaryByExperience(ctx context.Context, filter *AnalyticsFilter) ([]model.SalaryByCategory, error)
+	GetAverageSalaryByCompany(ctx context.Context, filter *AnalyticsFilter) ([]model.SalaryByCategory, error)
+	GetAverageSalaryByCity(ctx context.Context, filter *AnalyticsFilter) ([]model.SalaryByCategory, error)
+	GetAverageSalaryByCompanySize(ctx context.Context, filter *AnalyticsFilter) ([]model.SalaryByCategory, error)
+	GetAverageSalaryByWorkType(ctx context.Context, filter *AnalyticsFilter) ([]model.SalaryByCategory, error)
+	GetAverageSalaryByCurrency(ctx context.Context, filter *AnalyticsFilter) ([]model.SalaryByCategory, error)
+	GetAverageSalaryByTech(ctx context.Context, filter *AnalyticsFilter) ([]model.SalaryByTech, error)
+	GetJobChangeData(ctx context.Context) ([]model.JobChangeData, error)
+	GetRaiseData(ctx context.Context) ([]model.RaiseData, error)
+	GetCompensationData(ctx context.Context) ([]model.CompensationData, error)
+	GetOverallAverageSalary(ctx context.Context, filter *AnalyticsFilter) (float64, error)
+	GetSalaryPercentiles(ctx context.Context, filter *AnalyticsFilter) (*model.SalaryPercentiles, error)
+	GetAverageSalaryByPositionAndLevel(ctx context.Context, filter *AnalyticsFilter) ([]model.SalaryByPositionLevel, error)
+	GetSalaryPercentilesByPosition(ctx context.Context, filter *AnalyticsFilter) (map[string]model.SalaryPercentiles, error)
+	GetAvailablePositions(ctx context.Context) ([]string, error)
+	GetAvailableLevels(ctx context.Context) ([]string, error)
+	GetCombinedAnalytics(ctx context.Context, filter *AnalyticsFilter) (*CombinedAnalyticsResult, error)
+}
+
 type AnalyticsRepo struct {
 	db *mongo.Database
 }
 
-func NewAnalyticsRepo(db *mongo.Database) *AnalyticsRepo {
+func NewAnalyticsRepo(db *mongo.Database) AnalyticsRepository {
 	return &AnalyticsRepo{
 		db: db,
 	}
@@ -25,15 +50,15 @@ type AnalyticsFilter struct {
 	Position string
 	Level    string
 	Currency string
+	// SalaryBasis keeps salaries recorded on other bases out of the
+	// aggregates; empty matches every basis.
+	SalaryBasis string
 }
 
 func (r *AnalyticsRepo) GetTotalEntries(ctx context.Context, filter *AnalyticsFilter) (int64, error) {
 	collection := r.db.Collection("salary_entries")
 
-	query := bson.M{}
-	if filter != nil {
-		query = r.buildFilterQuery(filter)
-	}
+	query := r.buildFilterQuery(filter)
 
 	count, err := collection.CountDocuments(ctx, query)
 	if err != nil {
@@ -42,6 +67,35 @@ func (r *AnalyticsRepo) GetTotalEntries(ctx context.Context, filter *AnalyticsFi
 	return count, nil
 }
 
+func (r *AnalyticsRepo) CountEntriesByCurrency(ctx context.Context) (map[string]int64, error) {
+	collection := r.db.Collection("salary_entries")
+
+	pipeline := []bson.M{
+		{"$match": bson.M{"deleted_at": nil}},
+		{"$group": bson.M{"_id": "$currency", "count": bson.M{"$sum": 1}}},
+	}
+
+	cursor, err := collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count entries by currency: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var results []struct {
+		Currency string `bson:"_id"`
+		Count    int64  `bson:"count"`
+	}
+	if err := cursor.All(ctx, &results); err != nil {
+		return nil, fmt.Errorf("failed to decode entry counts: %w", err)
+	}
+
+	counts := make(map[string]int64, len(results))
+	for _, result := range results {
+		counts[result.Currency] = result.Count
+	}
+	return counts, nil
+}
+
 func (r *AnalyticsRepo) GetAverageSalaryByPosition(ctx context.Context, filter *AnalyticsFilter) ([]model.SalaryByCategory, error) {
 	return r.getAverageSalaryByField(ctx, "$position", filter)
 }
@@ -83,11 +137,8 @@ func (r *AnalyticsRepo) GetAverageSalaryByTech(ctx context.Context, filter *Anal
 		"tech_stack": bson.M{"$exists": true, "$ne": nil, "$not": bson.M{"$size": 0}},
 	}
 
-	if filter != nil {
-		additionalFilters := r.buildFilterQuery(filter)
-		for key, value := range additionalFilters {
-			baseMatch[key] = value
-		}
+	for key, value := range r.buildFilterQuery(filter) {
+		baseMatch[key] = value
 	}
 
 	pipeline = append(pipeline, bson.M{"$match": baseMatch})
@@ -139,11 +190,8 @@ func (r *AnalyticsRepo) getAverageSalaryByField(ctx context.Context, field strin
 		fieldName: bson.M{"$ne": "", "$exists": true},
 	}
 
-	if filter != nil {
-		additionalFilters := r.buildFilterQuery(filter)
-		for key, value := range additionalFilters {
-			baseMatch[key] = value
-		}
+	for key, value := range r.buildFilterQuery(filter) {
+		baseMatch[key] = value
 	}
 
 	pipeline = append(pipeline, bson.M{"$match": baseMatch})
@@ -180,17 +228,25 @@ func (r *AnalyticsRepo) getAverageSalaryByField(ctx context.Context, field strin
 func (r *AnalyticsRepo) GetJobChangeData(ctx context.Context) ([]model.JobChangeData, error) {
 	collection := r.db.Collection("salary_entries")
 
-	filter := bson.M{
-		"raises": bson.M{"$exists": true, "$ne": nil, "$not": bson.M{"$size": 0}},
-	}
+	filter := bson.M{"concurrent": bson.M{"$ne": true}, "deleted_at": nil}
 
 	projection := bson.M{
-		"salary_min": 1,
-		"salary_max": 1,
-		"raises":     1,
-	}
-
-	cursor, err := collection.Find(ctx, filter, options.Find().SetProjection(projection))
+		"user_id":       1,
+		"position":      1,
+		"level":         1,
+		"currency":      1,
+		"salary_basis":  1,
+		"salary_min":    1,
+		"start_time":    1,
+		"change_reason": 1,
+		"raises":        1,
+	}
+
+	opts := options.Find().
+		SetProjection(projection).
+		SetSort(bson.D{{Key: "user_id", Value: 1}, {Key: "start_time", Value: 1}, {Key: "created_at", Value: 1}})
+
+	cursor, err := collection.Find(ctx, filter, opts)
 	if err != nil {
 		return nil, fmt.Errorf("failed to find job change data: %w", err)
 	}
@@ -204,17 +260,49 @@ func (r *AnalyticsRepo) GetJobChangeData(ctx context.Context) ([]model.JobChange
 	return results, nil
 }
 
+func newJobChangeData(entry *model.SalaryEntry) model.JobChangeData {
+	return model.JobChangeData{
+		UserID:       entry.UserID,
+		Position:     entry.Position,
+		Level:        entry.Level,
+		Currency:     entry.Currency,
+		SalaryBasis:  entry.SalaryBasis,
+		SalaryMin:    entry.SalaryMin,
+		StartTime:    entry.StartTime,
+		ChangeReason: entry.ChangeReason,
+		Raises:       entry.Raises,
+	}
+}
+
+func newRaiseData(entry *model.SalaryEntry) model.RaiseData {
+	return model.RaiseData{
+		EntryID:     entry.ID.Hex(),
+		Level:       entry.Level,
+		Company:     entry.Company,
+		CompanySize: entry.CompanySize,
+		SalaryMin:   entry.SalaryMin,
+		Raises:      entry.Raises,
+		StartTime:   entry.StartTime,
+		EndTime:     entry.EndTime,
+	}
+}
+
 func (r *AnalyticsRepo) GetRaiseData(ctx context.Context) ([]model.RaiseData, error) {
 	collection := r.db.Collection("salary_entries")
 
 	filter := bson.M{
-		"raises": bson.M{"$exists": true, "$ne": nil, "$not": bson.M{"$size": 0}},
+		"raises":     bson.M{"$exists": true, "$ne": nil, "$not": bson.M{"$size": 0}},
+		"deleted_at": nil,
 	}
 
 	projection := bson.M{
-		"raises":    1,
-		"startTime": 1,
-		"endTime":   1,
+		"raises":       1,
+		"level":        1,
+		"company":      1,
+		"company_size": 1,
+		"salary_min":   1,
+		"start_time":   1,
+		"end_time":     1,
 	}
 
 	cursor, err := collection.Find(ctx, filter, options.Find().SetProjection(projection))
@@ -231,22 +319,61 @@ func (r *AnalyticsRepo) GetRaiseData(ctx context.Context) ([]model.RaiseData, er
 	return results, nil
 }
 
-func (r *AnalyticsRepo) GetOverallAverageSalary(ctx context.Context, filter *AnalyticsFilter) (float64, error) {
+func newCompensationData(entry *model.SalaryEntry) model.CompensationData {
+	return model.CompensationData{
+		Position:     entry.Position,
+		Level:        entry.Level,
+		Experience:   entry.Experience,
+		Currency:     entry.Currency,
+		SalaryBasis:  entry.SalaryBasis,
+		SalaryMin:    entry.SalaryMin,
+		StartTime:    entry.StartTime,
+		Raises:       entry.Raises,
+		Compensation: entry.Compensation,
+	}
+}
+
+func (r *AnalyticsRepo) GetCompensationData(ctx context.Context) ([]model.CompensationData, error) {
 	collection := r.db.Collection("salary_entries")
 
-	pipeline := []bson.M{}
+	projection := bson.M{
+		"position":     1,
+		"level":        1,
+		"experience":   1,
+		"currency":     1,
+		"salary_basis": 1,
+		"salary_min":   1,
+		"start_time":   1,
+		"raises":       1,
+		"compensation": 1,
+	}
+
+	cursor, err := collection.Find(ctx, bson.M{"deleted_at": nil}, options.Find().SetProjection(projection))
+	if err != nil {
+		return nil, fmt.Errorf("failed to find compensation data: %w", err)
+	}
+	defer cursor.Close(ctx)
 
-	if filter != nil {
-		matchStage := bson.M{"$match": r.buildFilterQuery(filter)}
-		pipeline = append(pipeline, matchStage)
+	var results []model.CompensationData
+	if err = cursor.All(ctx, &results); err != nil {
+		return nil, fmt.Errorf("failed to decode compensation data: %w", err)
 	}
 
-	pipeline = append(pipeline, bson.M{
-		"$group": bson.M{
-			"_id":     nil,
-			"average": bson.M{"$avg": "$salary_min"},
+	return results, nil
+}
+
+func (r *AnalyticsRepo) GetOverallAverageSalary(ctx context.Context, filter *AnalyticsFilter) (float64, error) {
+	collection := r.db.Collection("salary_entries")
+
+	pipeline := []bson.M{
+		{"$match": r.buildFilterQuery(filter)},
+		{
+			"$group": bson.M{
+				"_id":     nil,
+				"average": bson.M{"$avg": "$salary_min"},
+			},
 		},
-	})
+	}
 
 	cursor, err := collection.Aggregate(ctx, pipeline)
 	if err != nil {
@@ -266,13 +393,131 @@ func (r *AnalyticsRepo) GetOverallAverageSalary(ctx context.Context, filter *Ana
 	return result.Average, nil
 }
 
+func (r *AnalyticsRepo) GetSalaryPercentiles(ctx context.Context, filter *AnalyticsFilter) (*model.SalaryPercentiles, error) {
+	collection := r.db.Collection("salary_entries")
+
+	opts := options.Find().
+		SetProjection(bson.M{"salary_min": 1}).
+		SetSort(bson.M{"salary_min": 1})
+
+	cursor, err := collection.Find(ctx, r.buildFilterQuery(filter), opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find salaries for percentiles: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var results []struct {
+		SalaryMin int64 `bson:"salary_min"`
+	}
+	if err := cursor.All(ctx, &results); err != nil {
+		return nil, fmt.Errorf("failed to decode salaries for percentiles: %w", err)
+	}
+
+	salaries := make([]float64, len(results))
+	for i, result := range results {
+		salaries[i] = float64(result.SalaryMin)
+	}
+	return salaryPercentiles(salaries), nil
+}
+
+func (r *AnalyticsRepo) GetAverageSalaryByPositionAndLevel(ctx context.Context, filter *AnalyticsFilter) ([]model.SalaryByPositionLevel, error) {
+	collection := r.db.Collection("salary_entries")
+
+	match := r.buildFilterQuery(filter)
+	if _, ok := match["position"]; !ok {
+		match["position"] = bson.M{"$ne": "", "$exists": true}
+	}
+	if _, ok := match["level"]; !ok {
+		match["level"] = bson.M{"$ne": "", "$exists": true}
+	}
+
+	pipeline := []bson.M{
+		{"$match": match},
+		{
+			"$group": bson.M{
+				"_id":     bson.M{"position": "$position", "level": "$level"},
+				"average": bson.M{"$avg": "$salary_min"},
+				"min":     bson.M{"$min": "$salary_min"},
+				"max":     bson.M{"$max": "$salary_min"},
+				"count":   bson.M{"$sum": 1},
+			},
+		},
+		{"$sort": bson.D{{Key: "_id.position", Value: 1}, {Key: "_id.level", Value: 1}}},
+	}
+
+	cursor, err := collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, fmt.Errorf("failed to aggregate salary by position and level: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var groups []struct {
+		ID struct {
+			Position string `bson:"position"`
+			Level    string `bson:"level"`
+		} `bson:"_id"`
+		Average float64 `bson:"average"`
+		Min     float64 `bson:"min"`
+		Max     float64 `bson:"max"`
+		Count   int64   `bson:"count"`
+	}
+	if err := cursor.All(ctx, &groups); err != nil {
+		return nil, fmt.Errorf("failed to decode results: %w", err)
+	}
+
+	results := make([]model.SalaryByPositionLevel, 0, len(groups))
+	for _, group := range groups {
+		results = append(results, model.SalaryByPositionLevel{
+			Position: group.ID.Position,
+			Level:    group.ID.Level,
+			Average:  group.Average,
+			Min:      group.Min,
+			Max:      group.Max,
+			Count:    group.Count,
+		})
+	}
+	return results, nil
+}
+
+func (r *AnalyticsRepo) GetSalaryPercentilesByPosition(ctx context.Context, filter *AnalyticsFilter) (map[string]model.SalaryPercentiles, error) {
+	collection := r.db.Collection("salary_entries")
+
+	opts := options.Find().
+		SetProjection(bson.M{"position": 1, "salary_min": 1}).
+		SetSort(bson.M{"salary_min": 1})
+
+	cursor, err := collection.Find(ctx, r.buildFilterQuery(filter), opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find salaries for percentiles: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var results []struct {
+		Position  string `bson:"position"`
+		SalaryMin int64  `bson:"salary_min"`
+	}
+	if err := cursor.All(ctx, &results); err != nil {
+		return nil, fmt.Errorf("failed to decode salaries for percentiles: %w", err)
+	}
+
+	salaries := make(map[string][]float64)
+	for _, result := range results {
+		if result.Position == "" {
+			continue
+		}
+		salaries[result.Position] = append(salaries[result.Position], float64(result.SalaryMin))
+	}
+	return percentilesByCategory(salaries), nil
+}
+
 func (r *AnalyticsRepo) GetAvailablePositions(ctx context.Context) ([]string, error) {
 	collection := r.db.Collection("salary_entries")
 
 	pipeline := []bson.M{
 		{
 			"$match": bson.M{
-				"position": bson.M{"$ne": "", "$exists": true},
+				"position":   bson.M{"$ne": "", "$exists": true},
+				"deleted_at": nil,
 			},
 		},
 		{
@@ -312,7 +557,8 @@ func (r *AnalyticsRepo) GetAvailableLevels(ctx context.Context) ([]string, error
 	pipeline := []bson.M{
 		{
 			"$match": bson.M{
-				"level": bson.M{"$ne": "", "$exists": true},
+				"level":      bson.M{"$ne": "", "$exists": true},
+				"deleted_at": nil,
 			},
 		},
 		{
@@ -346,30 +592,31 @@ func (r *AnalyticsRepo) GetAvailableLevels(ctx context.Context) ([]string, error
 	return levels, nil
 }
 
+type TotalCount struct {
+	Total int64 `bson:"total"`
+}
+
+type OverallAverage struct {
+	Average float64 `bson:"average"`
+}
+
 type CombinedAnalyticsResult struct {
-	TotalCount []struct {
-		Total int64 `bson:"total"`
-	} `bson:"totalCount"`
-	OverallAverage []struct {
-		Average float64 `bson:"average"`
-	} `bson:"overallAverage"`
-	ByPosition    []model.SalaryByCategory `bson:"byPosition"`
-	ByLevel       []model.SalaryByCategory `bson:"byLevel"`
-	ByExperience  []model.SalaryByCategory `bson:"byExperience"`
-	ByCompany     []model.SalaryByCategory `bson:"byCompany"`
-	ByCity        []model.SalaryByCategory `bson:"byCity"`
-	ByCompanySize []model.SalaryByCategory `bson:"byCompanySize"`
-	ByWorkType    []model.SalaryByCategory `bson:"byWorkType"`
-	ByCurrency    []model.SalaryByCategory `bson:"byCurrency"`
+	TotalCount     []TotalCount             `bson:"totalCount"`
+	OverallAverage []OverallAverage         `bson:"overallAverage"`
+	ByPosition     []model.SalaryByCategory `bson:"byPosition"`
+	ByLevel        []model.SalaryByCategory `bson:"byLevel"`
+	ByExperience   []model.SalaryByCategory `bson:"byExperience"`
+	ByCompany      []model.SalaryByCategory `bson:"byCompany"`
+	ByCity         []model.SalaryByCategory `bson:"byCity"`
+	ByCompanySize  []model.SalaryByCategory `bson:"byCompanySize"`
+	ByWorkType     []model.SalaryByCategory `bson:"byWorkType"`
+	ByCurrency     []model.SalaryByCategory `bson:"byCurrency"`
 }
 
 func (r *AnalyticsRepo) GetCombinedAnalytics(ctx context.Context, filter *AnalyticsFilter) (*CombinedAnalyticsResult, error) {
 	collection := r.db.Collection("salary_entries")
 
-	baseMatch := bson.M{}
-	if filter != nil {
-		baseMatch = r.buildFilterQuery(filter)
-	}
+	baseMatch := r.buildFilterQuery(filter)
 
 	pipeline := []bson.M{
 		{"$match": baseMatch},
@@ -542,8 +789,59 @@ func (r *AnalyticsRepo) GetCombinedAnalytics(ctx context.Context, filter *Analyt
 	return &result, nil
 }
 
+var salaryPercentileRanks = []float64{0.25, 0.5, 0.75, 0.9}
+
+// salaryPercentiles expects salaries in ascending order and interpolates
+// between neighbours the same way PostgreSQL's percentile_cont does.
+func salaryPercentiles(sorted []float64) *model.SalaryPercentiles {
+	values := make([]float64, len(salaryPercentileRanks))
+	for i, rank := range salaryPercentileRanks {
+		values[i] = percentileCont(sorted, rank)
+	}
+	return percentilesFromValues(values)
+}
+
+// percentilesByCategory expects the salaries of each category in ascending
+// order.
+func percentilesByCategory(salaries map[string][]float64) map[string]model.SalaryPercentiles {
+	percentiles := make(map[string]model.SalaryPercentiles, len(salaries))
+	for category, sorted := range salaries {
+		percentiles[category] = *salaryPercentiles(sorted)
+	}
+	return percentiles
+}
+
+func percentilesFromValues(values []float64) *model.SalaryPercentiles {
+	if len(values) != len(salaryPercentileRanks) {
+		return &model.SalaryPercentiles{}
+	}
+	return &model.SalaryPercentiles{P25: values[0], P50: values[1], P75: values[2], P90: values[3]}
+}
+
+func percentileCont(sorted []float64, rank float64) float64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+
+	position := rank * float64(len(sorted)-1)
+	lower := int(math.Floor(position))
+	upper := int(math.Ceil(position))
+	return sorted[lower] + (sorted[upper]-sorted[lower])*(position-float64(lower))
+}
+
+// salaryBasisValues lists the stored salary_basis values that mean basis.
+// Entries saved without a basis count as net monthly.
+func salaryBasisValues(basis string) []string {
+	if basis == model.SalaryBasisNetMonthly {
+		return []string{basis, ""}
+	}
+	return []string{basis}
+}
+
+// buildFilterQuery matches the entries selected by filter, leaving out
+// deleted ones.
 func (r *AnalyticsRepo) buildFilterQuery(filter *AnalyticsFilter) bson.M {
-	query := bson.M{}
+	query := bson.M{"deleted_at": nil}
 
 	if filter == nil {
 		return query
@@ -561,5 +859,9 @@ func (r *AnalyticsRepo) buildFilterQuery(filter *AnalyticsFilter) bson.M {
 		query["currency"] = filter.Currency
 	}
 
+	if filter.SalaryBasis != "" {
+		query["salary_basis"] = bson.M{"$in": salaryBasisValues(filter.SalaryBasis)}
+	}
+
 	return query
 }
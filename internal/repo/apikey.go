@@ -0,0 +1,101 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/eminsonlu/salystic/internal/model"
+	"github.com/eminsonlu/salystic/pkg/database"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type APIKeyRepository interface {
+	Create(ctx context.Context, key *model.APIKey) error
+	GetByHash(ctx context.Context, keyHash string) (*model.APIKey, error)
+	List(ctx context.Context) ([]*model.APIKey, error)
+	Revoke(ctx context.Context, id primitive.ObjectID) error
+	RecordUsage(ctx context.Context, id primitive.ObjectID, count int64, usedAt time.Time) error
+}
+
+type apiKeyRepository struct {
+	collection *mongo.Collection
+}
+
+func NewAPIKeyRepository(db *database.MongoDB) APIKeyRepository {
+	return &apiKeyRepository{
+		collection: db.Database.Collection("api_keys"),
+	}
+}
+
+func (r *apiKeyRepository) Create(ctx context.Context, key *model.APIKey) error {
+	key.CreatedAt = time.Now()
+
+	result, err := r.collection.InsertOne(ctx, key)
+	if err != nil {
+		return fmt.Errorf("failed to create API key: %w", err)
+	}
+
+	key.ID = result.InsertedID.(primitive.ObjectID)
+	return nil
+}
+
+func (r *apiKeyRepository) GetByHash(ctx context.Context, keyHash string) (*model.APIKey, error) {
+	var key model.APIKey
+	err := r.collection.FindOne(ctx, bson.M{"key_hash": keyHash}).Decode(&key)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get API key: %w", err)
+	}
+	return &key, nil
+}
+
+func (r *apiKeyRepository) List(ctx context.Context) ([]*model.APIKey, error) {
+	opts := options.Find().SetSort(bson.M{"created_at": -1})
+
+	cursor, err := r.collection.Find(ctx, bson.M{}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find API keys: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	keys := []*model.APIKey{}
+	if err = cursor.All(ctx, &keys); err != nil {
+		return nil, fmt.Errorf("failed to decode API keys: %w", err)
+	}
+
+	return keys, nil
+}
+
+func (r *apiKeyRepository) Revoke(ctx context.Context, id primitive.ObjectID) error {
+	filter := bson.M{"_id": id, "revoked_at": bson.M{"$exists": false}}
+
+	result, err := r.collection.UpdateOne(ctx, filter, bson.M{"$set": bson.M{"revoked_at": time.Now()}})
+	if err != nil {
+		return fmt.Errorf("failed to revoke API key: %w", err)
+	}
+
+	if result.MatchedCount == 0 {
+		return fmt.Errorf("api key not found")
+	}
+
+	return nil
+}
+
+func (r *apiKeyRepository) RecordUsage(ctx context.Context, id primitive.ObjectID, count int64, usedAt time.Time) error {
+	update := bson.M{
+		"$inc": bson.M{"usage_count": count},
+		"$max": bson.M{"last_used_at": usedAt},
+	}
+
+	if _, err := r.collection.UpdateOne(ctx, bson.M{"_id": id}, update); err != nil {
+		return fmt.Errorf("failed to record API key usage: %w", err)
+	}
+	return nil
+}
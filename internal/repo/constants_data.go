@@ -0,0 +1,391 @@
+package repo
+
+import "github.com/eminsonlu/salystic/internal/model"
+
+var defaultPositions = []model.Position{
+	{Name: "AI Engineer"},
+	{Name: "Agile Coach"},
+	{Name: "Back-end Developer"},
+	{Name: "Blockchain Engineer"},
+	{Name: "Business Analyst"},
+	{Name: "Business Intelligence"},
+	{Name: "CTO"},
+	{Name: "Chief Data Officer"},
+	{Name: "Cloud Engineer"},
+	{Name: "Computer Vision Engineer"},
+	{Name: "Consultant"},
+	{Name: "Cyber Security"},
+	{Name: "Data Analyst"},
+	{Name: "Data Architect"},
+	{Name: "Data Engineer"},
+	{Name: "Data Scientist"},
+	{Name: "Database Administrator (DBA)"},
+	{Name: "DevOps Engineer"},
+	{Name: "Digital Transformation Executive"},
+	{Name: "Director of Software Development"},
+	{Name: "ERP Developer & Consultant"},
+	{Name: "Embedded Software Developer"},
+	{Name: "Front-end Developer"},
+	{Name: "Full Stack Developer"},
+	{Name: "Game Developer"},
+	{Name: "HR"},
+	{Name: "IT"},
+	{Name: "IT Manager"},
+	{Name: "Lead Product"},
+	{Name: "Machine Learning Engineer"},
+	{Name: "Mobile Application Developer (Android)"},
+	{Name: "Mobile Application Developer (Full Stack & Cross)"},
+	{Name: "Mobile Application Developer (iOS)"},
+	{Name: "Network Engineer"},
+	{Name: "Playable Ads Developer"},
+	{Name: "No-Code Developer"},
+	{Name: "Platform Engineer"},
+	{Name: "Product Manager"},
+	{Name: "Product Owner"},
+	{Name: "Program Manager"},
+	{Name: "Project Manager"},
+	{Name: "QA / Automation"},
+	{Name: "QA / Manuel Test"},
+	{Name: "QA Manager"},
+	{Name: "R&D Engineer"},
+	{Name: "R&D Manager"},
+	{Name: "RPA Developer"},
+	{Name: "Robotic Software Engineer"},
+	{Name: "SAP / ABAP Developer & Consultant"},
+	{Name: "Site Reliability Engineer"},
+	{Name: "Software Architect"},
+	{Name: "Software Development Manager / Engineering Manager"},
+	{Name: "Software Engineer"},
+	{Name: "Solution Architect"},
+	{Name: "Solution Developer & Architect"},
+	{Name: "Support Engineer"},
+	{Name: "System Admin"},
+	{Name: "System Engineer"},
+	{Name: "Team / Tech Lead"},
+	{Name: "UI/UX Designer"},
+}
+
+var defaultLevels = []model.Level{
+	{Name: "Junior"},
+	{Name: "Middle"},
+	{Name: "Senior"},
+}
+
+var defaultTechStacks = []model.TechStack{
+	{Name: ".Net", Category: "Backend"},
+	{Name: ".Net Core", Category: "Backend"},
+	{Name: ".Net Framework", Category: "Backend"},
+	{Name: "Java", Category: "Backend"},
+	{Name: "Python", Category: "Backend"},
+	{Name: "Go", Category: "Backend"},
+	{Name: "C#", Category: "Backend"},
+	{Name: "PHP", Category: "Backend"},
+	{Name: "NodeJS", Category: "Backend"},
+	{Name: "Spring Boot", Category: "Backend"},
+	{Name: "Django", Category: "Backend"},
+	{Name: "Laravel", Category: "Backend"},
+	{Name: "Rust", Category: "Backend"},
+	{Name: "Kotlin", Category: "Backend"},
+
+	{Name: "JavaScript", Category: "Frontend"},
+	{Name: "TypeScript", Category: "Frontend"},
+	{Name: "React", Category: "Frontend"},
+	{Name: "Angular", Category: "Frontend"},
+	{Name: "Vue", Category: "Frontend"},
+	{Name: "Next.js", Category: "Frontend"},
+	{Name: "HTML", Category: "Frontend"},
+	{Name: "CSS", Category: "Frontend"},
+	{Name: "jQuery", Category: "Frontend"},
+	{Name: "Svelte", Category: "Frontend"},
+
+	{Name: "Swift", Category: "Mobile"},
+	{Name: "Kotlin", Category: "Mobile"},
+	{Name: "Flutter", Category: "Mobile"},
+	{Name: "React Native", Category: "Mobile"},
+	{Name: "Xamarin", Category: "Mobile"},
+
+	{Name: "MySQL", Category: "Database"},
+	{Name: "PostgreSQL", Category: "Database"},
+	{Name: "MongoDB", Category: "Database"},
+	{Name: "Oracle", Category: "Database"},
+	{Name: "MSSQL", Category: "Database"},
+	{Name: "SQL", Category: "Database"},
+	{Name: "PL/SQL", Category: "Database"},
+	{Name: "Elasticsearch", Category: "Database"},
+
+	{Name: "AWS", Category: "Cloud"},
+	{Name: "Azure", Category: "Cloud"},
+	{Name: "GCP", Category: "Cloud"},
+	{Name: "Docker", Category: "DevOps"},
+	{Name: "Kubernetes", Category: "DevOps"},
+	{Name: "Jenkins", Category: "DevOps"},
+	{Name: "Terraform", Category: "DevOps"},
+	{Name: "Ansible", Category: "DevOps"},
+
+	{Name: "SAP", Category: "Enterprise"},
+	{Name: "ABAP", Category: "Enterprise"},
+	{Name: "SAP UI5", Category: "Enterprise"},
+	{Name: "Dynamics 365", Category: "Enterprise"},
+
+	{Name: "Selenium", Category: "Testing"},
+	{Name: "Cypress", Category: "Testing"},
+	{Name: "JMeter", Category: "Testing"},
+	{Name: "Postman", Category: "Testing"},
+
+	{Name: "Power BI", Category: "Analytics"},
+	{Name: "Tableau", Category: "Analytics"},
+	{Name: "Apache Spark", Category: "BigData"},
+	{Name: "Kafka", Category: "BigData"},
+	{Name: "R", Category: "Analytics"},
+	{Name: "MATLAB", Category: "Analytics"},
+
+	{Name: "Linux", Category: "System"},
+	{Name: "Git", Category: "VersionControl"},
+	{Name: "Redis", Category: "Cache"},
+	{Name: "RabbitMQ", Category: "MessageQueue"},
+}
+
+var defaultExperiences = []model.Experience{
+	{Range: "0 - 1 Yıl", MinYears: 0, MaxYears: &[]int{1}[0]},
+	{Range: "1 - 3 Yıl", MinYears: 1, MaxYears: &[]int{3}[0]},
+	{Range: "3 - 5 Yıl", MinYears: 3, MaxYears: &[]int{5}[0]},
+	{Range: "5 - 7 Yıl", MinYears: 5, MaxYears: &[]int{7}[0]},
+	{Range: "7 - 10 Yıl", MinYears: 7, MaxYears: &[]int{10}[0]},
+	{Range: "10 - 12 Yıl", MinYears: 10, MaxYears: &[]int{12}[0]},
+	{Range: "12 - 14 Yıl", MinYears: 12, MaxYears: &[]int{14}[0]},
+	{Range: "15 Yıl ve üzeri", MinYears: 15, MaxYears: nil},
+}
+
+var defaultCompanies = []model.Company{
+	{Name: "AI (Yapay Zeka)", Sector: "AI (Yapay Zeka)"},
+	{Name: "Akıllı Şehirler", Sector: "Akıllı Şehirler"},
+	{Name: "Arge", Sector: "Arge"},
+	{Name: "Banka", Sector: "Banka"},
+	{Name: "Beyaz Eşya", Sector: "Beyaz Eşya"},
+	{Name: "Borsa", Sector: "Borsa"},
+	{Name: "Cloud", Sector: "Cloud"},
+	{Name: "Coğrafi Bilgi sistemleri", Sector: "Coğrafi Bilgi sistemleri"},
+	{Name: "Danışmanlık", Sector: "Danışmanlık"},
+	{Name: "Data", Sector: "Data"},
+	{Name: "Dernek & Vakıf", Sector: "Dernek & Vakıf"},
+	{Name: "Dijital / Reklam Ajansı", Sector: "Dijital / Reklam Ajansı"},
+	{Name: "Diğer", Sector: "Diğer"},
+	{Name: "E-Ticaret", Sector: "E-Ticaret"},
+	{Name: "ERP", Sector: "ERP"},
+	{Name: "ERP & CRM", Sector: "ERP & CRM"},
+	{Name: "Elektronik", Sector: "Elektronik"},
+	{Name: "Emlak", Sector: "Emlak"},
+	{Name: "Endüstri", Sector: "Endüstri"},
+	{Name: "Enerji", Sector: "Enerji"},
+	{Name: "Entegratör", Sector: "Entegratör"},
+	{Name: "Eğitim", Sector: "Eğitim"},
+	{Name: "Eğlence", Sector: "Eğlence"},
+	{Name: "Fabrika", Sector: "Fabrika"},
+	{Name: "Fintech", Sector: "Fintech"},
+	{Name: "Freelancer", Sector: "Freelancer"},
+	{Name: "Gıda & Yemek", Sector: "Gıda & Yemek"},
+	{Name: "Havacılık", Sector: "Havacılık"},
+	{Name: "Hizmet", Sector: "Hizmet"},
+	{Name: "Holding", Sector: "Holding"},
+	{Name: "Hukuk", Sector: "Hukuk"},
+	{Name: "IoT", Sector: "IoT"},
+	{Name: "Kamu", Sector: "Kamu"},
+	{Name: "Kripto", Sector: "Kripto"},
+	{Name: "Kurumsal", Sector: "Kurumsal"},
+	{Name: "Lojistik", Sector: "Lojistik"},
+	{Name: "Makina", Sector: "Makina"},
+	{Name: "Medya", Sector: "Medya"},
+	{Name: "Mobil Uygulama", Sector: "Mobil Uygulama"},
+	{Name: "Mobilya", Sector: "Mobilya"},
+	{Name: "Muhasebe", Sector: "Muhasebe"},
+	{Name: "Otomasyon", Sector: "Otomasyon"},
+	{Name: "Otomotiv", Sector: "Otomotiv"},
+	{Name: "Outsource", Sector: "Outsource"},
+	{Name: "Oyun", Sector: "Oyun"},
+	{Name: "Pazarlama", Sector: "Pazarlama"},
+	{Name: "Perakende", Sector: "Perakende"},
+	{Name: "Reklam", Sector: "Reklam"},
+	{Name: "SaaS", Sector: "SaaS"},
+	{Name: "Saas", Sector: "Saas"},
+	{Name: "Sanayi", Sector: "Sanayi"},
+	{Name: "Savunma Sanayi", Sector: "Savunma Sanayi"},
+	{Name: "Sağlık", Sector: "Sağlık"},
+	{Name: "Siber Güvenlik", Sector: "Siber Güvenlik"},
+	{Name: "Sigorta", Sector: "Sigorta"},
+	{Name: "Startup", Sector: "Startup"},
+	{Name: "Tarım", Sector: "Tarım"},
+	{Name: "Tekstil & Giyim & Moda", Sector: "Tekstil & Giyim & Moda"},
+	{Name: "Telekomünikasyon", Sector: "Telekomünikasyon"},
+	{Name: "Turizm", Sector: "Turizm"},
+	{Name: "Yazılım Evi & Danışmanlık", Sector: "Yazılım Evi & Danışmanlık"},
+	{Name: "Üniversite", Sector: "Üniversite"},
+	{Name: "Üretim", Sector: "Üretim"},
+	{Name: "İK & Danışmanlık", Sector: "İK & Danışmanlık"},
+	{Name: "İnşaat", Sector: "İnşaat"},
+	{Name: "Şans Oyunları & Bahis", Sector: "Şans Oyunları & Bahis"},
+}
+
+var defaultCompanySizes = []model.CompanySize{
+	{Range: "1 - 5 Kişi", MinSize: 1, MaxSize: &[]int{5}[0]},
+	{Range: "6 - 10 Kişi", MinSize: 6, MaxSize: &[]int{10}[0]},
+	{Range: "11 - 20 Kişi", MinSize: 11, MaxSize: &[]int{20}[0]},
+	{Range: "21 - 50 Kişi", MinSize: 21, MaxSize: &[]int{50}[0]},
+	{Range: "51 - 100 Kişi", MinSize: 51, MaxSize: &[]int{100}[0]},
+	{Range: "101 - 249 Kişi", MinSize: 101, MaxSize: &[]int{249}[0]},
+	{Range: "250+", MinSize: 250, MaxSize: nil},
+}
+
+var defaultWorkTypes = []model.WorkType{
+	{Name: "Ofis"},
+	{Name: "Remote"},
+	{Name: "Hibrit (Ofis + Remote)"},
+}
+
+var defaultCities = []model.City{
+	{Name: "* ABD", Country: "ABD"},
+	{Name: "* Almanya", Country: "Almanya"},
+	{Name: "* Arnavutluk", Country: "Arnavutluk"},
+	{Name: "* Avrupa", Country: "Avrupa"},
+	{Name: "* Avusturya", Country: "Avusturya"},
+	{Name: "* Azerbaycan", Country: "Azerbaycan"},
+	{Name: "* Belçika", Country: "Belçika"},
+	{Name: "* Birleşik Arap Emirlikleri", Country: "Birleşik Arap Emirlikleri"},
+	{Name: "* Bosna Hersek", Country: "Bosna Hersek"},
+	{Name: "* Dubai", Country: "Dubai"},
+	{Name: "* Estonya", Country: "Estonya"},
+	{Name: "* Finlandiya", Country: "Finlandiya"},
+	{Name: "* Fransa", Country: "Fransa"},
+	{Name: "* Güney Kore", Country: "Güney Kore"},
+	{Name: "* Hollanda", Country: "Hollanda"},
+	{Name: "* Hong Kong", Country: "Hong Kong"},
+	{Name: "* Japonya", Country: "Japonya"},
+	{Name: "* KKTC", Country: "KKTC"},
+	{Name: "* Kanada", Country: "Kanada"},
+	{Name: "* Karadağ", Country: "Karadağ"},
+	{Name: "* Letonya", Country: "Letonya"},
+	{Name: "* Lüksemburg", Country: "Lüksemburg"},
+	{Name: "* Malta", Country: "Malta"},
+	{Name: "* Meksika", Country: "Meksika"},
+	{Name: "* Polonya", Country: "Polonya"},
+	{Name: "* Portekiz", Country: "Portekiz"},
+	{Name: "* Slovakya", Country: "Slovakya"},
+	{Name: "* Tayland", Country: "Tayland"},
+	{Name: "* Türkiye", Country: "Türkiye"},
+	{Name: "* Çekya", Country: "Çekya"},
+	{Name: "* İngiltere", Country: "İngiltere"},
+	{Name: "* İrlanda", Country: "İrlanda"},
+	{Name: "* İspanya", Country: "İspanya"},
+	{Name: "* İsveç", Country: "İsveç"},
+	{Name: "* İsviçre", Country: "İsviçre"},
+	{Name: "* İtalya", Country: "İtalya"},
+	{Name: "Adana", Country: "Türkiye"},
+	{Name: "Adıyaman", Country: "Türkiye"},
+	{Name: "Afyonkarahisar", Country: "Türkiye"},
+	{Name: "Aksaray", Country: "Türkiye"},
+	{Name: "Amasya", Country: "Türkiye"},
+	{Name: "Ankara", Country: "Türkiye"},
+	{Name: "Antalya", Country: "Türkiye"},
+	{Name: "Ardahan", Country: "Türkiye"},
+	{Name: "Artvin", Country: "Türkiye"},
+	{Name: "Aydın", Country: "Türkiye"},
+	{Name: "Ağrı", Country: "Türkiye"},
+	{Name: "Balıkesir", Country: "Türkiye"},
+	{Name: "Batman", Country: "Türkiye"},
+	{Name: "Bilecik", Country: "Türkiye"},
+	{Name: "Bitlis", Country: "Türkiye"},
+	{Name: "Bolu", Country: "Türkiye"},
+	{Name: "Bursa", Country: "Türkiye"},
+	{Name: "Denizli", Country: "Türkiye"},
+	{Name: "Diyarbakır", Country: "Türkiye"},
+	{Name: "Düzce", Country: "Türkiye"},
+	{Name: "Edirne", Country: "Türkiye"},
+	{Name: "Elazığ", Country: "Türkiye"},
+	{Name: "Erzincan", Country: "Türkiye"},
+	{Name: "Erzurum", Country: "Türkiye"},
+	{Name: "Eskişehir", Country: "Türkiye"},
+	{Name: "Gaziantep", Country: "Türkiye"},
+	{Name: "Giresun", Country: "Türkiye"},
+	{Name: "Hakkari", Country: "Türkiye"},
+	{Name: "Hatay", Country: "Türkiye"},
+	{Name: "Isparta", Country: "Türkiye"},
+	{Name: "Iğdır", Country: "Türkiye"},
+	{Name: "Kahramanmaraş", Country: "Türkiye"},
+	{Name: "Karabük", Country: "Türkiye"},
+	{Name: "Karaman", Country: "Türkiye"},
+	{Name: "Kars", Country: "Türkiye"},
+	{Name: "Kastamonu", Country: "Türkiye"},
+	{Name: "Kayseri", Country: "Türkiye"},
+	{Name: "Kilis", Country: "Türkiye"},
+	{Name: "Kocaeli", Country: "Türkiye"},
+	{Name: "Konya", Country: "Türkiye"},
+	{Name: "Kütahya", Country: "Türkiye"},
+	{Name: "Kırklareli", Country: "Türkiye"},
+	{Name: "Kırşehir", Country: "Türkiye"},
+	{Name: "Malatya", Country: "Türkiye"},
+	{Name: "Manisa", Country: "Türkiye"},
+	{Name: "Mardin", Country: "Türkiye"},
+	{Name: "Mersin", Country: "Türkiye"},
+	{Name: "Muğla", Country: "Türkiye"},
+	{Name: "Muş", Country: "Türkiye"},
+	{Name: "Nevşehir", Country: "Türkiye"},
+	{Name: "Niğde", Country: "Türkiye"},
+	{Name: "Ordu", Country: "Türkiye"},
+	{Name: "Osmaniye", Country: "Türkiye"},
+	{Name: "Rize", Country: "Türkiye"},
+	{Name: "Sakarya", Country: "Türkiye"},
+	{Name: "Samsun", Country: "Türkiye"},
+	{Name: "Sinop", Country: "Türkiye"},
+	{Name: "Sivas", Country: "Türkiye"},
+	{Name: "Tekirdağ", Country: "Türkiye"},
+	{Name: "Tokat", Country: "Türkiye"},
+	{Name: "Trabzon", Country: "Türkiye"},
+	{Name: "Uşak", Country: "Türkiye"},
+	{Name: "Van", Country: "Türkiye"},
+	{Name: "Yalova", Country: "Türkiye"},
+	{Name: "Yozgat", Country: "Türkiye"},
+	{Name: "Zonguldak", Country: "Türkiye"},
+	{Name: "Çanakkale", Country: "Türkiye"},
+	{Name: "Çorum", Country: "Türkiye"},
+	{Name: "İstanbul", Country: "Türkiye"},
+	{Name: "İzmir", Country: "Türkiye"},
+	{Name: "Şanlıurfa", Country: "Türkiye"},
+}
+
+var defaultCurrencies = []model.Currency{
+	{Name: "Türk Lirası", Code: "TRY", Symbol: "₺"},
+	{Name: "Dolar", Code: "USD", Symbol: "$"},
+	{Name: "Euro", Code: "EUR", Symbol: "€"},
+	{Name: "Sterlin", Code: "GBP", Symbol: "£"},
+}
+
+type constantNames struct {
+	kind  string
+	names []string
+}
+
+func defaultConstantNames() []constantNames {
+	return []constantNames{
+		{"positions", uniqueNames(defaultPositions, func(p model.Position) string { return p.Name })},
+		{"levels", uniqueNames(defaultLevels, func(l model.Level) string { return l.Name })},
+		{"tech_stacks", uniqueNames(defaultTechStacks, func(t model.TechStack) string { return t.Name })},
+		{"experiences", uniqueNames(defaultExperiences, func(e model.Experience) string { return e.Range })},
+		{"companies", uniqueNames(defaultCompanies, func(c model.Company) string { return c.Name })},
+		{"company_sizes", uniqueNames(defaultCompanySizes, func(c model.CompanySize) string { return c.Range })},
+		{"work_types", uniqueNames(defaultWorkTypes, func(w model.WorkType) string { return w.Name })},
+		{"cities", uniqueNames(defaultCities, func(c model.City) string { return c.Name })},
+		{"currencies", uniqueNames(defaultCurrencies, func(c model.Currency) string { return c.Code })},
+	}
+}
+
+func uniqueNames[T any](items []T, name func(T) string) []string {
+	seen := make(map[string]bool, len(items))
+	names := make([]string, 0, len(items))
+	for _, item := range items {
+		n := name(item)
+		if !seen[n] {
+			seen[n] = true
+			names = append(names, n)
+		}
+	}
+	return names
+}
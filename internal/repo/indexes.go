@@ -3,186 +3,357 @@ package repo
 import (
 	"context"
 	"fmt"
-	"log"
+	"log/slog"
+	"reflect"
+	"sort"
+	"strings"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
-type IndexRepo struct {
-	db *mongo.Database
+const (
+	DriftMissing    = "missing"
+	DriftExtra      = "extra"
+	DriftMismatched = "mismatched"
+)
+
+type IndexSpec struct {
+	Name               string
+	Keys               bson.D
+	Unique             bool
+	Sparse             bool
+	ExpireAfterSeconds *int32
 }
 
-func NewIndexRepo(db *mongo.Database) *IndexRepo {
-	return &IndexRepo{
-		db: db,
-	}
+type CollectionIndexes struct {
+	Collection string
+	Indexes    []IndexSpec
 }
 
-func (r *IndexRepo) CreateAnalyticsIndexes(ctx context.Context) error {
-	salaryCollection := r.db.Collection("salary_entries")
+type IndexDrift struct {
+	Collection string `json:"collection"`
+	Name       string `json:"name"`
+	Kind       string `json:"kind"`
+	Detail     string `json:"detail,omitempty"`
+}
 
-	indexes := []mongo.IndexModel{
-		{
-			Keys: bson.D{
-				{Key: "position", Value: 1},
-				{Key: "level", Value: 1},
-				{Key: "currency", Value: 1},
-			},
-			Options: options.Index().SetName("analytics_filter_idx"),
-		},
-		{
-			Keys: bson.D{
-				{Key: "tech_stack", Value: 1},
-				{Key: "position", Value: 1},
-				{Key: "level", Value: 1},
-			},
-			Options: options.Index().SetName("tech_analytics_idx"),
-		},
-		{
-			Keys: bson.D{
-				{Key: "raises", Value: 1},
-				{Key: "startTime", Value: 1},
-				{Key: "endTime", Value: 1},
-			},
-			Options: options.Index().SetName("career_analytics_idx"),
-		},
-		{
-			Keys: bson.D{
-				{Key: "position", Value: 1},
-				{Key: "salary_min", Value: 1},
-			},
-			Options: options.Index().SetName("position_salary_idx"),
-		},
-		{
-			Keys: bson.D{
-				{Key: "level", Value: 1},
-				{Key: "salary_min", Value: 1},
-			},
-			Options: options.Index().SetName("level_salary_idx"),
-		},
-		{
-			Keys: bson.D{
-				{Key: "experience", Value: 1},
-				{Key: "salary_min", Value: 1},
-			},
-			Options: options.Index().SetName("experience_salary_idx"),
-		},
-		{
-			Keys: bson.D{
-				{Key: "company", Value: 1},
-				{Key: "salary_min", Value: 1},
-			},
-			Options: options.Index().SetName("company_salary_idx"),
-		},
-		{
-			Keys: bson.D{
-				{Key: "city", Value: 1},
-				{Key: "salary_min", Value: 1},
-			},
-			Options: options.Index().SetName("city_salary_idx"),
-		},
+type existingIndex struct {
+	Name               string `bson:"name"`
+	Key                bson.D `bson:"key"`
+	Unique             bool   `bson:"unique"`
+	Sparse             bool   `bson:"sparse"`
+	ExpireAfterSeconds *int32 `bson:"expireAfterSeconds"`
+}
+
+func IndexDefinitions() []CollectionIndexes {
+	return []CollectionIndexes{
 		{
-			Keys: bson.D{
-				{Key: "company_size", Value: 1},
-				{Key: "salary_min", Value: 1},
+			Collection: "salary_entries",
+			Indexes: []IndexSpec{
+				{Name: "analytics_filter_idx", Keys: bson.D{{Key: "position", Value: 1}, {Key: "level", Value: 1}, {Key: "currency", Value: 1}}},
+				{Name: "tech_analytics_idx", Keys: bson.D{{Key: "tech_stack", Value: 1}, {Key: "position", Value: 1}, {Key: "level", Value: 1}}},
+				{Name: "career_analytics_idx", Keys: bson.D{{Key: "raises", Value: 1}, {Key: "start_time", Value: 1}, {Key: "end_time", Value: 1}}},
+				{Name: "position_salary_idx", Keys: bson.D{{Key: "position", Value: 1}, {Key: "salary_min", Value: 1}}},
+				{Name: "level_salary_idx", Keys: bson.D{{Key: "level", Value: 1}, {Key: "salary_min", Value: 1}}},
+				{Name: "experience_salary_idx", Keys: bson.D{{Key: "experience", Value: 1}, {Key: "salary_min", Value: 1}}},
+				{Name: "company_salary_idx", Keys: bson.D{{Key: "company", Value: 1}, {Key: "salary_min", Value: 1}}},
+				{Name: "city_salary_idx", Keys: bson.D{{Key: "city", Value: 1}, {Key: "salary_min", Value: 1}}},
+				{Name: "company_size_salary_idx", Keys: bson.D{{Key: "company_size", Value: 1}, {Key: "salary_min", Value: 1}}},
+				{Name: "work_type_salary_idx", Keys: bson.D{{Key: "work_type", Value: 1}, {Key: "salary_min", Value: 1}}},
+				{Name: "currency_salary_idx", Keys: bson.D{{Key: "currency", Value: 1}, {Key: "salary_min", Value: 1}}},
+				{Name: "position_idx", Keys: bson.D{{Key: "position", Value: 1}}},
+				{Name: "level_idx", Keys: bson.D{{Key: "level", Value: 1}}},
+				{Name: "user_entries_idx", Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "created_at", Value: -1}}},
+				{Name: "deleted_entries_idx", Keys: bson.D{{Key: "deleted_at", Value: 1}}, Sparse: true},
 			},
-			Options: options.Index().SetName("company_size_salary_idx"),
 		},
 		{
-			Keys: bson.D{
-				{Key: "work_type", Value: 1},
-				{Key: "salary_min", Value: 1},
+			Collection: "users",
+			Indexes: []IndexSpec{
+				{Name: "pseudonymized_id_idx", Keys: bson.D{{Key: "pseudonymized_id", Value: 1}}, Unique: true},
 			},
-			Options: options.Index().SetName("work_type_salary_idx"),
 		},
 		{
-			Keys: bson.D{
-				{Key: "currency", Value: 1},
-				{Key: "salary_min", Value: 1},
+			Collection: "sessions",
+			Indexes: []IndexSpec{
+				{Name: "user_sessions_idx", Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "last_seen_at", Value: -1}}},
+				{Name: "session_expiry_ttl_idx", Keys: bson.D{{Key: "expires_at", Value: 1}}, ExpireAfterSeconds: int32Ptr(0)},
 			},
-			Options: options.Index().SetName("currency_salary_idx"),
 		},
 		{
-			Keys: bson.D{
-				{Key: "position", Value: 1},
+			Collection: "api_keys",
+			Indexes: []IndexSpec{
+				{Name: "key_hash_idx", Keys: bson.D{{Key: "key_hash", Value: 1}}, Unique: true},
 			},
-			Options: options.Index().SetName("position_idx"),
 		},
 		{
-			Keys: bson.D{
-				{Key: "level", Value: 1},
+			Collection: "entry_revisions",
+			Indexes: []IndexSpec{
+				{Name: "entry_revision_version_idx", Keys: bson.D{{Key: "entry_id", Value: 1}, {Key: "version", Value: 1}}, Unique: true},
 			},
-			Options: options.Index().SetName("level_idx"),
 		},
 	}
+}
+
+func (s IndexSpec) model() mongo.IndexModel {
+	opts := options.Index().SetName(s.Name)
+	if s.Unique {
+		opts.SetUnique(true)
+	}
+	if s.Sparse {
+		opts.SetSparse(true)
+	}
+	if s.ExpireAfterSeconds != nil {
+		opts.SetExpireAfterSeconds(*s.ExpireAfterSeconds)
+	}
+	return mongo.IndexModel{Keys: s.Keys, Options: opts}
+}
 
-	log.Println("Creating analytics indexes for salary_entries collection...")
+type IndexRepo struct {
+	db          *mongo.Database
+	logger      *slog.Logger
+	definitions []CollectionIndexes
+}
 
-	indexNames, err := salaryCollection.Indexes().CreateMany(ctx, indexes)
-	if err != nil {
-		return fmt.Errorf("failed to create analytics indexes: %w", err)
+func NewIndexRepo(db *mongo.Database, logger *slog.Logger) *IndexRepo {
+	return &IndexRepo{
+		db:          db,
+		logger:      logger,
+		definitions: IndexDefinitions(),
+	}
+}
+
+func (r *IndexRepo) CreateAllIndexes(ctx context.Context) error {
+	for _, def := range r.definitions {
+		if err := r.CreateIndexes(ctx, def.Collection, def.Indexes); err != nil {
+			return err
+		}
 	}
 
-	log.Printf("Successfully created %d indexes: %v", len(indexNames), indexNames)
+	r.logger.Info("All database indexes created successfully")
 	return nil
 }
 
-func (r *IndexRepo) CreateUserIndexes(ctx context.Context) error {
-	userCollection := r.db.Collection("users")
+func (r *IndexRepo) CreateIndexes(ctx context.Context, collectionName string, specs []IndexSpec) error {
+	if len(specs) == 0 {
+		return nil
+	}
 
-	indexes := []mongo.IndexModel{
-		{
-			Keys: bson.D{
-				{Key: "linkedin_id", Value: 1},
-			},
-			Options: options.Index().SetName("linkedin_id_idx").SetUnique(true).SetSparse(true),
-		},
+	models := make([]mongo.IndexModel, 0, len(specs))
+	for _, spec := range specs {
+		models = append(models, spec.model())
 	}
 
-	log.Println("Creating user indexes...")
+	r.logger.Info("Creating indexes", "collection", collectionName, "count", len(models))
 
-	indexNames, err := userCollection.Indexes().CreateMany(ctx, indexes)
+	indexNames, err := r.db.Collection(collectionName).Indexes().CreateMany(ctx, models)
 	if err != nil {
-		return fmt.Errorf("failed to create user indexes: %w", err)
+		return fmt.Errorf("failed to create indexes on %s: %w", collectionName, err)
 	}
 
-	log.Printf("Successfully created user indexes: %v", indexNames)
+	r.logger.Info("Created indexes", "collection", collectionName, "indexes", indexNames)
 	return nil
 }
 
-func (r *IndexRepo) CreateAllIndexes(ctx context.Context) error {
-	if err := r.CreateAnalyticsIndexes(ctx); err != nil {
-		return fmt.Errorf("failed to create analytics indexes: %w", err)
+func (r *IndexRepo) CheckIndexes(ctx context.Context) ([]IndexDrift, error) {
+	var drift []IndexDrift
+
+	for _, def := range r.definitions {
+		existing, err := r.listIndexes(ctx, def.Collection)
+		if err != nil {
+			return nil, err
+		}
+		drift = append(drift, diffIndexes(def.Collection, def.Indexes, existing)...)
+	}
+
+	return drift, nil
+}
+
+func (r *IndexRepo) SyncIndexes(ctx context.Context, drift []IndexDrift) error {
+	specs := make(map[string]IndexSpec)
+	for _, def := range r.definitions {
+		for _, spec := range def.Indexes {
+			specs[def.Collection+"."+spec.Name] = spec
+		}
 	}
 
-	if err := r.CreateUserIndexes(ctx); err != nil {
-		return fmt.Errorf("failed to create user indexes: %w", err)
+	for _, d := range drift {
+		indexes := r.db.Collection(d.Collection).Indexes()
+
+		if d.Kind == DriftExtra || d.Kind == DriftMismatched {
+			r.logger.Info("Dropping index", "collection", d.Collection, "name", d.Name, "reason", d.Kind)
+			if _, err := indexes.DropOne(ctx, d.Name); err != nil {
+				return fmt.Errorf("failed to drop index %s on %s: %w", d.Name, d.Collection, err)
+			}
+		}
+
+		if d.Kind == DriftMissing || d.Kind == DriftMismatched {
+			spec, ok := specs[d.Collection+"."+d.Name]
+			if !ok {
+				return fmt.Errorf("no definition for index %s on %s", d.Name, d.Collection)
+			}
+			r.logger.Info("Building index", "collection", d.Collection, "name", d.Name, "reason", d.Kind)
+			if _, err := indexes.CreateOne(ctx, spec.model()); err != nil {
+				return fmt.Errorf("failed to create index %s on %s: %w", d.Name, d.Collection, err)
+			}
+		}
 	}
 
-	log.Println("All database indexes created successfully")
 	return nil
 }
 
-func (r *IndexRepo) ListIndexes(ctx context.Context, collectionName string) error {
-	collection := r.db.Collection(collectionName)
+func (r *IndexRepo) VerifyIndexes(ctx context.Context, sync bool) error {
+	drift, err := r.CheckIndexes(ctx)
+	if err != nil {
+		return err
+	}
 
-	cursor, err := collection.Indexes().List(ctx)
+	if len(drift) == 0 {
+		r.logger.Info("Indexes match their definitions")
+		return nil
+	}
+
+	for _, d := range drift {
+		r.logger.Warn("Index drift detected", "collection", d.Collection, "name", d.Name, "kind", d.Kind, "detail", d.Detail)
+	}
+
+	if !sync {
+		r.logger.Warn("Index sync is disabled, set INDEX_SYNC=true or run cmd/migrate indexes -sync to apply", "drift", len(drift))
+		return nil
+	}
+
+	if err := r.SyncIndexes(ctx, drift); err != nil {
+		return err
+	}
+	r.logger.Info("Indexes synced with their definitions", "changes", len(drift))
+	return nil
+}
+
+func (r *IndexRepo) listIndexes(ctx context.Context, collectionName string) ([]existingIndex, error) {
+	cursor, err := r.db.Collection(collectionName).Indexes().List(ctx)
 	if err != nil {
-		return fmt.Errorf("failed to list indexes for %s: %w", collectionName, err)
+		return nil, fmt.Errorf("failed to list indexes for %s: %w", collectionName, err)
 	}
 	defer cursor.Close(ctx)
 
-	log.Printf("Indexes for collection '%s':", collectionName)
-	for cursor.Next(ctx) {
-		var index bson.M
-		if err := cursor.Decode(&index); err != nil {
-			log.Printf("Error decoding index: %v", err)
+	var existing []existingIndex
+	if err := cursor.All(ctx, &existing); err != nil {
+		return nil, fmt.Errorf("failed to decode indexes for %s: %w", collectionName, err)
+	}
+	return existing, nil
+}
+
+func diffIndexes(collection string, specs []IndexSpec, existing []existingIndex) []IndexDrift {
+	byName := make(map[string]existingIndex, len(existing))
+	for _, index := range existing {
+		byName[index.Name] = index
+	}
+
+	var drift []IndexDrift
+	declared := make(map[string]bool, len(specs))
+
+	for _, spec := range specs {
+		declared[spec.Name] = true
+
+		index, ok := byName[spec.Name]
+		if !ok {
+			drift = append(drift, IndexDrift{Collection: collection, Name: spec.Name, Kind: DriftMissing})
 			continue
 		}
-		log.Printf("  - %s: %v", index["name"], index["key"])
+
+		if differences := compareIndex(spec, index); len(differences) > 0 {
+			drift = append(drift, IndexDrift{
+				Collection: collection,
+				Name:       spec.Name,
+				Kind:       DriftMismatched,
+				Detail:     strings.Join(differences, "; "),
+			})
+		}
 	}
 
-	return nil
+	var extra []string
+	for name := range byName {
+		if name != "_id_" && !declared[name] {
+			extra = append(extra, name)
+		}
+	}
+	sort.Strings(extra)
+
+	for _, name := range extra {
+		drift = append(drift, IndexDrift{
+			Collection: collection,
+			Name:       name,
+			Kind:       DriftExtra,
+			Detail:     "keys " + formatKeys(byName[name].Key),
+		})
+	}
+
+	return drift
+}
+
+func compareIndex(spec IndexSpec, index existingIndex) []string {
+	var differences []string
+
+	if !keysEqual(spec.Keys, index.Key) {
+		differences = append(differences, fmt.Sprintf("keys %s, want %s", formatKeys(index.Key), formatKeys(spec.Keys)))
+	}
+	if spec.Unique != index.Unique {
+		differences = append(differences, fmt.Sprintf("unique %t, want %t", index.Unique, spec.Unique))
+	}
+	if spec.Sparse != index.Sparse {
+		differences = append(differences, fmt.Sprintf("sparse %t, want %t", index.Sparse, spec.Sparse))
+	}
+	if !reflect.DeepEqual(spec.ExpireAfterSeconds, index.ExpireAfterSeconds) {
+		differences = append(differences, fmt.Sprintf("expireAfterSeconds %s, want %s", formatTTL(index.ExpireAfterSeconds), formatTTL(spec.ExpireAfterSeconds)))
+	}
+
+	return differences
+}
+
+func keysEqual(a, b bson.D) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].Key != b[i].Key || normalizeKeyValue(a[i].Value) != normalizeKeyValue(b[i].Value) {
+			return false
+		}
+	}
+	return true
+}
+
+func normalizeKeyValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case int:
+		return float64(v)
+	case int32:
+		return float64(v)
+	case int64:
+		return float64(v)
+	case float64:
+		return v
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+func formatKeys(keys bson.D) string {
+	parts := make([]string, 0, len(keys))
+	for _, key := range keys {
+		parts = append(parts, fmt.Sprintf("%s:%v", key.Key, key.Value))
+	}
+	return "{" + strings.Join(parts, ", ") + "}"
+}
+
+func formatTTL(ttl *int32) string {
+	if ttl == nil {
+		return "none"
+	}
+	return fmt.Sprint(*ttl)
+}
+
+func int32Ptr(v int32) *int32 {
+	return &v
 }
@@ -0,0 +1,80 @@
+package repo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestIndexDefinitions_UseDocumentFieldNames(t *testing.T) {
+	specs := make(map[string]IndexSpec)
+	for _, def := range IndexDefinitions() {
+		for _, spec := range def.Indexes {
+			key := def.Collection + "." + spec.Name
+			_, duplicate := specs[key]
+			require.False(t, duplicate, "duplicate index %s", key)
+			specs[key] = spec
+		}
+	}
+
+	career := specs["salary_entries.career_analytics_idx"]
+	assert.Equal(t, bson.D{{Key: "raises", Value: 1}, {Key: "start_time", Value: 1}, {Key: "end_time", Value: 1}}, career.Keys)
+
+	users, ok := specs["users.pseudonymized_id_idx"]
+	require.True(t, ok)
+	assert.Equal(t, bson.D{{Key: "pseudonymized_id", Value: 1}}, users.Keys)
+	_, stale := specs["users.linkedin_id_idx"]
+	assert.False(t, stale)
+}
+
+func TestDiffIndexes(t *testing.T) {
+	specs := []IndexSpec{
+		{Name: "career_analytics_idx", Keys: bson.D{{Key: "raises", Value: 1}, {Key: "start_time", Value: 1}}},
+		{Name: "key_hash_idx", Keys: bson.D{{Key: "key_hash", Value: 1}}, Unique: true},
+		{Name: "session_expiry_ttl_idx", Keys: bson.D{{Key: "expires_at", Value: 1}}, ExpireAfterSeconds: int32Ptr(0)},
+		{Name: "position_idx", Keys: bson.D{{Key: "position", Value: 1}}},
+	}
+	existing := []existingIndex{
+		{Name: "_id_", Key: bson.D{{Key: "_id", Value: int32(1)}}},
+		{Name: "career_analytics_idx", Key: bson.D{{Key: "raises", Value: int32(1)}, {Key: "startTime", Value: int32(1)}}},
+		{Name: "key_hash_idx", Key: bson.D{{Key: "key_hash", Value: int32(1)}}},
+		{Name: "session_expiry_ttl_idx", Key: bson.D{{Key: "expires_at", Value: float64(1)}}, ExpireAfterSeconds: int32Ptr(0)},
+		{Name: "linkedin_id_idx", Key: bson.D{{Key: "linkedin_id", Value: int32(1)}}, Unique: true, Sparse: true},
+	}
+
+	drift := diffIndexes("test", specs, existing)
+
+	require.Len(t, drift, 4)
+	assert.Equal(t, IndexDrift{Collection: "test", Name: "career_analytics_idx", Kind: DriftMismatched, Detail: "keys {raises:1, startTime:1}, want {raises:1, start_time:1}"}, drift[0])
+	assert.Equal(t, IndexDrift{Collection: "test", Name: "key_hash_idx", Kind: DriftMismatched, Detail: "unique false, want true"}, drift[1])
+	assert.Equal(t, IndexDrift{Collection: "test", Name: "position_idx", Kind: DriftMissing}, drift[2])
+	assert.Equal(t, IndexDrift{Collection: "test", Name: "linkedin_id_idx", Kind: DriftExtra, Detail: "keys {linkedin_id:1}"}, drift[3])
+}
+
+func TestDiffIndexes_NoDrift(t *testing.T) {
+	specs := []IndexSpec{
+		{Name: "user_sessions_idx", Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "last_seen_at", Value: -1}}},
+	}
+	existing := []existingIndex{
+		{Name: "_id_", Key: bson.D{{Key: "_id", Value: int32(1)}}},
+		{Name: "user_sessions_idx", Key: bson.D{{Key: "user_id", Value: int32(1)}, {Key: "last_seen_at", Value: int32(-1)}}},
+	}
+
+	assert.Empty(t, diffIndexes("sessions", specs, existing))
+}
+
+func TestDiffIndexes_KeyOrderMatters(t *testing.T) {
+	specs := []IndexSpec{
+		{Name: "analytics_filter_idx", Keys: bson.D{{Key: "position", Value: 1}, {Key: "level", Value: 1}}},
+	}
+	existing := []existingIndex{
+		{Name: "analytics_filter_idx", Key: bson.D{{Key: "level", Value: int32(1)}, {Key: "position", Value: int32(1)}}},
+	}
+
+	drift := diffIndexes("salary_entries", specs, existing)
+
+	require.Len(t, drift, 1)
+	assert.Equal(t, DriftMismatched, drift[0].Kind)
+}
@@ -0,0 +1,120 @@
+package repo
+
+import (
+	"sync"
+
+	"github.com/eminsonlu/salystic/internal/model"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type MemoryStore struct {
+	mu             sync.RWMutex
+	txMu           sync.Mutex
+	salaryEntries  map[primitive.ObjectID]*model.SalaryEntry
+	users          map[primitive.ObjectID]*model.User
+	sessions       map[primitive.ObjectID]*model.Session
+	apiKeys        map[primitive.ObjectID]*model.APIKey
+	entryRevisions map[primitive.ObjectID][]*model.EntryRevision
+	constants      map[string][]string
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		salaryEntries:  make(map[primitive.ObjectID]*model.SalaryEntry),
+		users:          make(map[primitive.ObjectID]*model.User),
+		sessions:       make(map[primitive.ObjectID]*model.Session),
+		apiKeys:        make(map[primitive.ObjectID]*model.APIKey),
+		entryRevisions: make(map[primitive.ObjectID][]*model.EntryRevision),
+		constants:      make(map[string][]string),
+	}
+}
+
+func cloneSalaryEntry(entry *model.SalaryEntry) *model.SalaryEntry {
+	clone := *entry
+	if entry.TechStack != nil {
+		clone.TechStack = append([]string(nil), entry.TechStack...)
+	}
+	if entry.SalaryMax != nil {
+		salaryMax := *entry.SalaryMax
+		clone.SalaryMax = &salaryMax
+	}
+	if entry.EndTime != nil {
+		endTime := *entry.EndTime
+		clone.EndTime = &endTime
+	}
+	if entry.Raises != nil {
+		clone.Raises = append([]model.Raise(nil), entry.Raises...)
+	}
+	if entry.DeletedAt != nil {
+		deletedAt := *entry.DeletedAt
+		clone.DeletedAt = &deletedAt
+	}
+	clone.Compensation = cloneCompensation(entry.Compensation)
+	return &clone
+}
+
+func cloneCompensation(compensation *model.Compensation) *model.Compensation {
+	if compensation == nil {
+		return nil
+	}
+	clone := *compensation
+	if compensation.Bonus != nil {
+		bonus := *compensation.Bonus
+		clone.Bonus = &bonus
+	}
+	if compensation.Equity != nil {
+		equity := *compensation.Equity
+		clone.Equity = &equity
+	}
+	if compensation.HealthInsurance != nil {
+		healthInsurance := *compensation.HealthInsurance
+		clone.HealthInsurance = &healthInsurance
+	}
+	if compensation.OtherBenefits != nil {
+		clone.OtherBenefits = append([]model.Benefit(nil), compensation.OtherBenefits...)
+	}
+	return &clone
+}
+
+func cloneSession(session *model.Session) *model.Session {
+	clone := *session
+	if session.RevokedAt != nil {
+		revokedAt := *session.RevokedAt
+		clone.RevokedAt = &revokedAt
+	}
+	return &clone
+}
+
+func cloneAPIKey(key *model.APIKey) *model.APIKey {
+	clone := *key
+	if key.Scopes != nil {
+		clone.Scopes = append([]string(nil), key.Scopes...)
+	}
+	if key.ExpiresAt != nil {
+		expiresAt := *key.ExpiresAt
+		clone.ExpiresAt = &expiresAt
+	}
+	if key.RevokedAt != nil {
+		revokedAt := *key.RevokedAt
+		clone.RevokedAt = &revokedAt
+	}
+	if key.LastUsedAt != nil {
+		lastUsedAt := *key.LastUsedAt
+		clone.LastUsedAt = &lastUsedAt
+	}
+	return &clone
+}
+
+// cloneEntryRevision copies the revision and its snapshot. The encoded values
+// of its changes are never modified, so they are shared.
+func cloneEntryRevision(revision *model.EntryRevision) *model.EntryRevision {
+	clone := *revision
+	if revision.Changes != nil {
+		clone.Changes = append([]model.FieldChange(nil), revision.Changes...)
+	}
+	if revision.Snapshot != nil {
+		clone.Snapshot = cloneSalaryEntry(revision.Snapshot)
+	}
+	return &clone
+}
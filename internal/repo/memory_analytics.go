@@ -0,0 +1,317 @@
+package repo
+
+import (
+	"bytes"
+	"context"
+	"math"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/eminsonlu/salystic/internal/model"
+)
+
+type memoryAnalyticsRepository struct {
+	store *MemoryStore
+}
+
+func NewMemoryAnalyticsRepository(store *MemoryStore) AnalyticsRepository {
+	return &memoryAnalyticsRepository{store: store}
+}
+
+func (r *memoryAnalyticsRepository) GetTotalEntries(ctx context.Context, filter *AnalyticsFilter) (int64, error) {
+	return int64(len(r.entries(filter))), nil
+}
+
+func (r *memoryAnalyticsRepository) CountEntriesByCurrency(ctx context.Context) (map[string]int64, error) {
+	counts := make(map[string]int64)
+	for _, entry := range r.entries(nil) {
+		counts[entry.Currency]++
+	}
+	return counts, nil
+}
+
+func (r *memoryAnalyticsRepository) GetAverageSalaryByPosition(ctx context.Context, filter *AnalyticsFilter) ([]model.SalaryByCategory, error) {
+	return groupSalaries(r.entries(filter), func(e *model.SalaryEntry) string { return e.Position }), nil
+}
+
+func (r *memoryAnalyticsRepository) GetAverageSalaryByLevel(ctx context.Context, filter *AnalyticsFilter) ([]model.SalaryByCategory, error) {
+	return groupSalaries(r.entries(filter), func(e *model.SalaryEntry) string { return e.Level }), nil
+}
+
+func (r *memoryAnalyticsRepository) GetAverageSalaryByExperience(ctx context.Context, filter *AnalyticsFilter) ([]model.SalaryByCategory, error) {
+	return groupSalaries(r.entries(filter), func(e *model.SalaryEntry) string { return e.Experience }), nil
+}
+
+func (r *memoryAnalyticsRepository) GetAverageSalaryByCompany(ctx context.Context, filter *AnalyticsFilter) ([]model.SalaryByCategory, error) {
+	return groupSalaries(r.entries(filter), func(e *model.SalaryEntry) string { return e.Company }), nil
+}
+
+func (r *memoryAnalyticsRepository) GetAverageSalaryByCity(ctx context.Context, filter *AnalyticsFilter) ([]model.SalaryByCategory, error) {
+	return groupSalaries(r.entries(filter), func(e *model.SalaryEntry) string { return e.City }), nil
+}
+
+func (r *memoryAnalyticsRepository) GetAverageSalaryByCompanySize(ctx context.Context, filter *AnalyticsFilter) ([]model.SalaryByCategory, error) {
+	return groupSalaries(r.entries(filter), func(e *model.SalaryEntry) string { return e.CompanySize }), nil
+}
+
+func (r *memoryAnalyticsRepository) GetAverageSalaryByWorkType(ctx context.Context, filter *AnalyticsFilter) ([]model.SalaryByCategory, error) {
+	return groupSalaries(r.entries(filter), func(e *model.SalaryEntry) string { return e.WorkType }), nil
+}
+
+func (r *memoryAnalyticsRepository) GetAverageSalaryByCurrency(ctx context.Context, filter *AnalyticsFilter) ([]model.SalaryByCategory, error) {
+	return groupSalaries(r.entries(filter), func(e *model.SalaryEntry) string { return e.Currency }), nil
+}
+
+func (r *memoryAnalyticsRepository) GetAverageSalaryByTech(ctx context.Context, filter *AnalyticsFilter) ([]model.SalaryByTech, error) {
+	var salaries []categorySalary
+	for _, entry := range r.entries(filter) {
+		for _, tech := range entry.TechStack {
+			salaries = append(salaries, categorySalary{category: tech, salary: float64(entry.SalaryMin)})
+		}
+	}
+	categories := groupCategorySalaries(salaries)
+
+	results := make([]model.SalaryByTech, 0, len(categories))
+	for _, category := range categories {
+		results = append(results, model.SalaryByTech{
+			Tech:    category.Category,
+			Average: category.Average,
+			Min:     category.Min,
+			Max:     category.Max,
+			Count:   category.Count,
+		})
+	}
+	return results, nil
+}
+
+func (r *memoryAnalyticsRepository) GetJobChangeData(ctx context.Context) ([]model.JobChangeData, error) {
+	var entries []*model.SalaryEntry
+	for _, entry := range r.entries(nil) {
+		if !entry.Concurrent {
+			entries = append(entries, entry)
+		}
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].UserID != entries[j].UserID {
+			return bytes.Compare(entries[i].UserID[:], entries[j].UserID[:]) < 0
+		}
+		if !entries[i].StartTime.Equal(entries[j].StartTime) {
+			return entries[i].StartTime.Before(entries[j].StartTime)
+		}
+		return entries[i].CreatedAt.Before(entries[j].CreatedAt)
+	})
+
+	results := make([]model.JobChangeData, 0, len(entries))
+	for _, entry := range entries {
+		results = append(results, newJobChangeData(entry))
+	}
+	return results, nil
+}
+
+func (r *memoryAnalyticsRepository) GetRaiseData(ctx context.Context) ([]model.RaiseData, error) {
+	var results []model.RaiseData
+	for _, entry := range r.entries(nil) {
+		if len(entry.Raises) == 0 {
+			continue
+		}
+		results = append(results, newRaiseData(entry))
+	}
+	return results, nil
+}
+
+func (r *memoryAnalyticsRepository) GetCompensationData(ctx context.Context) ([]model.CompensationData, error) {
+	var results []model.CompensationData
+	for _, entry := range r.entries(nil) {
+		results = append(results, newCompensationData(entry))
+	}
+	return results, nil
+}
+
+func (r *memoryAnalyticsRepository) GetOverallAverageSalary(ctx context.Context, filter *AnalyticsFilter) (float64, error) {
+	entries := r.entries(filter)
+	if len(entries) == 0 {
+		return 0, nil
+	}
+
+	var sum float64
+	for _, entry := range entries {
+		sum += float64(entry.SalaryMin)
+	}
+	return sum / float64(len(entries)), nil
+}
+
+func (r *memoryAnalyticsRepository) GetSalaryPercentiles(ctx context.Context, filter *AnalyticsFilter) (*model.SalaryPercentiles, error) {
+	entries := r.entries(filter)
+
+	salaries := make([]float64, len(entries))
+	for i, entry := range entries {
+		salaries[i] = float64(entry.SalaryMin)
+	}
+	sort.Float64s(salaries)
+
+	return salaryPercentiles(salaries), nil
+}
+
+func (r *memoryAnalyticsRepository) GetAverageSalaryByPositionAndLevel(ctx context.Context, filter *AnalyticsFilter) ([]model.SalaryByPositionLevel, error) {
+	// The NUL separator sorts before any other byte, so grouping by the joined
+	// key orders the results by position and then level.
+	groups := groupSalaries(r.entries(filter), func(e *model.SalaryEntry) string {
+		if e.Position == "" || e.Level == "" {
+			return ""
+		}
+		return e.Position + "\x00" + e.Level
+	})
+
+	results := make([]model.SalaryByPositionLevel, 0, len(groups))
+	for _, group := range groups {
+		position, level, _ := strings.Cut(group.Category, "\x00")
+		results = append(results, model.SalaryByPositionLevel{
+			Position: position,
+			Level:    level,
+			Average:  group.Average,
+			Min:      group.Min,
+			Max:      group.Max,
+			Count:    group.Count,
+		})
+	}
+	return results, nil
+}
+
+func (r *memoryAnalyticsRepository) GetSalaryPercentilesByPosition(ctx context.Context, filter *AnalyticsFilter) (map[string]model.SalaryPercentiles, error) {
+	salaries := make(map[string][]float64)
+	for _, entry := range r.entries(filter) {
+		if entry.Position == "" {
+			continue
+		}
+		salaries[entry.Position] = append(salaries[entry.Position], float64(entry.SalaryMin))
+	}
+	for _, values := range salaries {
+		sort.Float64s(values)
+	}
+
+	return percentilesByCategory(salaries), nil
+}
+
+func (r *memoryAnalyticsRepository) GetAvailablePositions(ctx context.Context) ([]string, error) {
+	return distinctValues(r.entries(nil), func(e *model.SalaryEntry) string { return e.Position }), nil
+}
+
+func (r *memoryAnalyticsRepository) GetAvailableLevels(ctx context.Context) ([]string, error) {
+	return distinctValues(r.entries(nil), func(e *model.SalaryEntry) string { return e.Level }), nil
+}
+
+func (r *memoryAnalyticsRepository) GetCombinedAnalytics(ctx context.Context, filter *AnalyticsFilter) (*CombinedAnalyticsResult, error) {
+	entries := r.entries(filter)
+
+	result := &CombinedAnalyticsResult{
+		ByPosition:    groupSalaries(entries, func(e *model.SalaryEntry) string { return e.Position }),
+		ByLevel:       groupSalaries(entries, func(e *model.SalaryEntry) string { return e.Level }),
+		ByExperience:  groupSalaries(entries, func(e *model.SalaryEntry) string { return e.Experience }),
+		ByCompany:     groupSalaries(entries, func(e *model.SalaryEntry) string { return e.Company }),
+		ByCity:        groupSalaries(entries, func(e *model.SalaryEntry) string { return e.City }),
+		ByCompanySize: groupSalaries(entries, func(e *model.SalaryEntry) string { return e.CompanySize }),
+		ByWorkType:    groupSalaries(entries, func(e *model.SalaryEntry) string { return e.WorkType }),
+		ByCurrency:    groupSalaries(entries, func(e *model.SalaryEntry) string { return e.Currency }),
+	}
+
+	if len(entries) > 0 {
+		average, _ := r.GetOverallAverageSalary(ctx, filter)
+		result.TotalCount = []TotalCount{{Total: int64(len(entries))}}
+		result.OverallAverage = []OverallAverage{{Average: average}}
+	}
+
+	return result, nil
+}
+
+func (r *memoryAnalyticsRepository) entries(filter *AnalyticsFilter) []*model.SalaryEntry {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var entries []*model.SalaryEntry
+	for _, entry := range r.store.salaryEntries {
+		if entry.DeletedAt != nil {
+			continue
+		}
+		if filter != nil {
+			if filter.Position != "" && entry.Position != filter.Position {
+				continue
+			}
+			if filter.Level != "" && entry.Level != filter.Level {
+				continue
+			}
+			if filter.Currency != "" && entry.Currency != filter.Currency {
+				continue
+			}
+			if filter.SalaryBasis != "" && !slices.Contains(salaryBasisValues(filter.SalaryBasis), entry.SalaryBasis) {
+				continue
+			}
+		}
+		entries = append(entries, cloneSalaryEntry(entry))
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].ID.Hex() < entries[j].ID.Hex()
+	})
+	return entries
+}
+
+type categorySalary struct {
+	category string
+	salary   float64
+}
+
+func groupSalaries(entries []*model.SalaryEntry, key func(*model.SalaryEntry) string) []model.SalaryByCategory {
+	salaries := make([]categorySalary, 0, len(entries))
+	for _, entry := range entries {
+		salaries = append(salaries, categorySalary{category: key(entry), salary: float64(entry.SalaryMin)})
+	}
+	return groupCategorySalaries(salaries)
+}
+
+func groupCategorySalaries(salaries []categorySalary) []model.SalaryByCategory {
+	groups := make(map[string]*model.SalaryByCategory)
+	sums := make(map[string]float64)
+
+	for _, s := range salaries {
+		if s.category == "" {
+			continue
+		}
+
+		group, ok := groups[s.category]
+		if !ok {
+			group = &model.SalaryByCategory{Category: s.category, Min: s.salary, Max: s.salary}
+			groups[s.category] = group
+		}
+		group.Min = math.Min(group.Min, s.salary)
+		group.Max = math.Max(group.Max, s.salary)
+		group.Count++
+		sums[s.category] += s.salary
+	}
+
+	results := make([]model.SalaryByCategory, 0, len(groups))
+	for category, group := range groups {
+		group.Average = sums[category] / float64(group.Count)
+		results = append(results, *group)
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].Category < results[j].Category
+	})
+	return results
+}
+
+func distinctValues(entries []*model.SalaryEntry, key func(*model.SalaryEntry) string) []string {
+	seen := make(map[string]bool)
+	values := []string{}
+	for _, entry := range entries {
+		value := key(entry)
+		if value != "" && !seen[value] {
+			seen[value] = true
+			values = append(values, value)
+		}
+	}
+	sort.Strings(values)
+	return values
+}
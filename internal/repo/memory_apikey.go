@@ -0,0 +1,91 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/eminsonlu/salystic/internal/model"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type memoryAPIKeyRepository struct {
+	store *MemoryStore
+}
+
+func NewMemoryAPIKeyRepository(store *MemoryStore) APIKeyRepository {
+	return &memoryAPIKeyRepository{store: store}
+}
+
+func (r *memoryAPIKeyRepository) Create(ctx context.Context, key *model.APIKey) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	for _, existing := range r.store.apiKeys {
+		if existing.KeyHash == key.KeyHash {
+			return fmt.Errorf("failed to create API key: duplicate key hash")
+		}
+	}
+
+	key.ID = primitive.NewObjectID()
+	key.CreatedAt = time.Now()
+
+	r.store.apiKeys[key.ID] = cloneAPIKey(key)
+	return nil
+}
+
+func (r *memoryAPIKeyRepository) GetByHash(ctx context.Context, keyHash string) (*model.APIKey, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	for _, key := range r.store.apiKeys {
+		if key.KeyHash == keyHash {
+			return cloneAPIKey(key), nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *memoryAPIKeyRepository) List(ctx context.Context) ([]*model.APIKey, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	keys := []*model.APIKey{}
+	for _, key := range r.store.apiKeys {
+		keys = append(keys, cloneAPIKey(key))
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].CreatedAt.After(keys[j].CreatedAt)
+	})
+	return keys, nil
+}
+
+func (r *memoryAPIKeyRepository) Revoke(ctx context.Context, id primitive.ObjectID) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	key, ok := r.store.apiKeys[id]
+	if !ok || key.RevokedAt != nil {
+		return fmt.Errorf("api key not found")
+	}
+
+	now := time.Now()
+	key.RevokedAt = &now
+	return nil
+}
+
+func (r *memoryAPIKeyRepository) RecordUsage(ctx context.Context, id primitive.ObjectID, count int64, usedAt time.Time) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if key, ok := r.store.apiKeys[id]; ok {
+		key.UsageCount += count
+		if key.LastUsedAt == nil || usedAt.After(*key.LastUsedAt) {
+			key.LastUsedAt = &usedAt
+		}
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package repo
+
+import (
+	"context"
+	"log/slog"
+)
+
+type memoryConstantsRepository struct {
+	store  *MemoryStore
+	logger *slog.Logger
+}
+
+func NewMemoryConstantsRepository(store *MemoryStore, logger *slog.Logger) ConstantsRepository {
+	return &memoryConstantsRepository{
+		store:  store,
+		logger: logger,
+	}
+}
+
+func (r *memoryConstantsRepository) SeedConstants(ctx context.Context) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	for _, constant := range defaultConstantNames() {
+		r.store.constants[constant.kind] = constant.names
+	}
+
+	r.logger.Info("Seeded in-memory constants")
+	return nil
+}
+
+func (r *memoryConstantsRepository) GetPositions(ctx context.Context) ([]string, error) {
+	return r.get("positions"), nil
+}
+
+func (r *memoryConstantsRepository) GetLevels(ctx context.Context) ([]string, error) {
+	return r.get("levels"), nil
+}
+
+func (r *memoryConstantsRepository) GetTechStacks(ctx context.Context) ([]string, error) {
+	return r.get("tech_stacks"), nil
+}
+
+func (r *memoryConstantsRepository) GetExperiences(ctx context.Context) ([]string, error) {
+	return r.get("experiences"), nil
+}
+
+func (r *memoryConstantsRepository) GetCompanies(ctx context.Context) ([]string, error) {
+	return r.get("companies"), nil
+}
+
+func (r *memoryConstantsRepository) GetCompanySizes(ctx context.Context) ([]string, error) {
+	return r.get("company_sizes"), nil
+}
+
+func (r *memoryConstantsRepository) GetWorkTypes(ctx context.Context) ([]string, error) {
+	return r.get("work_types"), nil
+}
+
+func (r *memoryConstantsRepository) GetCities(ctx context.Context) ([]string, error) {
+	return r.get("cities"), nil
+}
+
+func (r *memoryConstantsRepository) GetCurrencies(ctx context.Context) ([]string, error) {
+	return r.get("currencies"), nil
+}
+
+func (r *memoryConstantsRepository) get(name string) []string {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	values := r.store.constants[name]
+	if values == nil {
+		return nil
+	}
+	return append([]string(nil), values...)
+}
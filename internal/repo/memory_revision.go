@@ -0,0 +1,62 @@
+package repo
+
+import (
+	"context"
+	"time"
+
+	"github.com/eminsonlu/salystic/internal/model"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type memoryEntryRevisionRepository struct {
+	store *MemoryStore
+}
+
+func NewMemoryEntryRevisionRepository(store *MemoryStore) EntryRevisionRepository {
+	return &memoryEntryRevisionRepository{store: store}
+}
+
+func (r *memoryEntryRevisionRepository) Create(ctx context.Context, revision *model.EntryRevision) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	revision.ID = primitive.NewObjectID()
+	revision.Version = len(r.store.entryRevisions[revision.EntryID]) + 1
+	revision.ChangedAt = time.Now()
+
+	r.store.entryRevisions[revision.EntryID] = append(r.store.entryRevisions[revision.EntryID], cloneEntryRevision(revision))
+	return nil
+}
+
+func (r *memoryEntryRevisionRepository) ListByEntryID(ctx context.Context, entryID primitive.ObjectID) ([]*model.EntryRevision, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	revisions := []*model.EntryRevision{}
+	for _, revision := range r.store.entryRevisions[entryID] {
+		revisions = append(revisions, cloneEntryRevision(revision))
+	}
+	return revisions, nil
+}
+
+func (r *memoryEntryRevisionRepository) GetByVersion(ctx context.Context, entryID primitive.ObjectID, version int) (*model.EntryRevision, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	revisions := r.store.entryRevisions[entryID]
+	if version < 1 || version > len(revisions) {
+		return nil, nil
+	}
+	return cloneEntryRevision(revisions[version-1]), nil
+}
+
+func (r *memoryEntryRevisionRepository) DeleteByEntryIDs(ctx context.Context, entryIDs []primitive.ObjectID) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	for _, entryID := range entryIDs {
+		delete(r.store.entryRevisions, entryID)
+	}
+	return nil
+}
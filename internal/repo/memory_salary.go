@@ -0,0 +1,340 @@
+package repo
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/eminsonlu/salystic/internal/model"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type memorySalaryEntryRepository struct {
+	store *MemoryStore
+}
+
+func NewMemorySalaryEntryRepository(store *MemoryStore) SalaryEntryRepository {
+	return &memorySalaryEntryRepository{store: store}
+}
+
+func (r *memorySalaryEntryRepository) Create(ctx context.Context, entry *model.SalaryEntry) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if entry.ID.IsZero() {
+		entry.ID = primitive.NewObjectID()
+	}
+	entry.CreatedAt = time.Now()
+	entry.UpdatedAt = time.Now()
+
+	r.store.salaryEntries[entry.ID] = cloneSalaryEntry(entry)
+	return nil
+}
+
+func (r *memorySalaryEntryRepository) GetByID(ctx context.Context, id primitive.ObjectID, userID primitive.ObjectID) (*model.SalaryEntry, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	entry := r.find(id, userID)
+	if entry == nil {
+		return nil, nil
+	}
+	return cloneSalaryEntry(entry), nil
+}
+
+func (r *memorySalaryEntryRepository) GetByUserID(ctx context.Context, userID primitive.ObjectID) ([]*model.SalaryEntry, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var entries []*model.SalaryEntry
+	for _, entry := range r.store.salaryEntries {
+		if entry.UserID == userID && entry.DeletedAt == nil {
+			entries = append(entries, cloneSalaryEntry(entry))
+		}
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].CreatedAt.After(entries[j].CreatedAt)
+	})
+	return entries, nil
+}
+
+func (r *memorySalaryEntryRepository) ListByUserID(ctx context.Context, userID primitive.ObjectID, opts *SalaryEntryListOptions) ([]*model.SalaryEntry, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var entries []*model.SalaryEntry
+	for _, entry := range r.store.salaryEntries {
+		if entry.UserID != userID || entry.DeletedAt != nil {
+			continue
+		}
+		if opts.Currency != "" && entry.Currency != opts.Currency {
+			continue
+		}
+		if opts.Current != nil && *opts.Current != (entry.EndTime == nil) {
+			continue
+		}
+		if opts.After != nil && !entryAfterCursor(entry, opts) {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		c := compareEntryToCursor(entries[i], NewSalaryEntryCursor(entries[j]), opts.SortBy)
+		if opts.Ascending {
+			return c < 0
+		}
+		return c > 0
+	})
+
+	if len(entries) > opts.Limit {
+		entries = entries[:opts.Limit]
+	}
+
+	page := make([]*model.SalaryEntry, len(entries))
+	for i, entry := range entries {
+		page[i] = cloneSalaryEntry(entry)
+	}
+	return page, nil
+}
+
+func entryAfterCursor(entry *model.SalaryEntry, opts *SalaryEntryListOptions) bool {
+	c := compareEntryToCursor(entry, opts.After, opts.SortBy)
+	if opts.Ascending {
+		return c > 0
+	}
+	return c < 0
+}
+
+func compareEntryToCursor(entry *model.SalaryEntry, cursor *SalaryEntryCursor, sortBy string) int {
+	var c int
+	switch sortBy {
+	case model.EntrySortStartTime:
+		c = entry.StartTime.Compare(cursor.StartTime)
+	case model.EntrySortSalary:
+		c = cmp.Compare(entry.SalaryMin, cursor.SalaryMin)
+	default:
+		c = entry.CreatedAt.Compare(cursor.CreatedAt)
+	}
+	if c != 0 {
+		return c
+	}
+	return bytes.Compare(entry.ID[:], cursor.ID[:])
+}
+
+func (r *memorySalaryEntryRepository) Update(ctx context.Context, id primitive.ObjectID, userID primitive.ObjectID, update *model.UpdateSalaryEntryRequest) (*model.SalaryEntry, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	entry := r.find(id, userID)
+	if entry == nil {
+		return nil, nil
+	}
+
+	if update.Level != nil {
+		entry.Level = *update.Level
+	}
+	if update.Position != nil {
+		entry.Position = *update.Position
+	}
+	if update.TechStack != nil {
+		entry.TechStack = append([]string(nil), update.TechStack...)
+	}
+	if update.Experience != nil {
+		entry.Experience = *update.Experience
+	}
+	if update.Gender != nil {
+		entry.Gender = *update.Gender
+	}
+	if update.Company != nil {
+		entry.Company = *update.Company
+	}
+	if update.CompanySize != nil {
+		entry.CompanySize = *update.CompanySize
+	}
+	if update.WorkType != nil {
+		entry.WorkType = *update.WorkType
+	}
+	if update.City != nil {
+		entry.City = *update.City
+	}
+	if update.Currency != nil {
+		entry.Currency = *update.Currency
+	}
+	if update.SalaryBasis != nil {
+		entry.SalaryBasis = *update.SalaryBasis
+	}
+	if update.SalaryMin != nil {
+		entry.SalaryMin = *update.SalaryMin
+	}
+	if update.SalaryMax != nil {
+		salaryMax := *update.SalaryMax
+		entry.SalaryMax = &salaryMax
+	}
+	if update.RaisePeriod != nil {
+		entry.RaisePeriod = *update.RaisePeriod
+	}
+	if update.StartTime != nil {
+		entry.StartTime = *update.StartTime
+	}
+	if update.EndTime != nil {
+		endTime := *update.EndTime
+		entry.EndTime = &endTime
+	}
+	if update.Concurrent != nil {
+		entry.Concurrent = *update.Concurrent
+	}
+	if update.ChangeReason != nil {
+		entry.ChangeReason = *update.ChangeReason
+	}
+	if update.Compensation != nil {
+		entry.Compensation = cloneCompensation(update.Compensation)
+	}
+	if changesRaiseBasis(update) {
+		entry.RaisesVersion++
+	}
+	entry.UpdatedAt = time.Now()
+
+	return cloneSalaryEntry(entry), nil
+}
+
+func (r *memorySalaryEntryRepository) Delete(ctx context.Context, id primitive.ObjectID, userID primitive.ObjectID) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	entry := r.find(id, userID)
+	if entry == nil {
+		return fmt.Errorf("salary entry not found")
+	}
+
+	deletedAt := time.Now()
+	entry.DeletedAt = &deletedAt
+	return nil
+}
+
+func (r *memorySalaryEntryRepository) ListDeletedByUserID(ctx context.Context, userID primitive.ObjectID) ([]*model.SalaryEntry, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var entries []*model.SalaryEntry
+	for _, entry := range r.store.salaryEntries {
+		if entry.UserID == userID && entry.DeletedAt != nil {
+			entries = append(entries, cloneSalaryEntry(entry))
+		}
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		if !entries[i].DeletedAt.Equal(*entries[j].DeletedAt) {
+			return entries[i].DeletedAt.After(*entries[j].DeletedAt)
+		}
+		return bytes.Compare(entries[i].ID[:], entries[j].ID[:]) > 0
+	})
+	return entries, nil
+}
+
+func (r *memorySalaryEntryRepository) GetDeletedByID(ctx context.Context, id primitive.ObjectID, userID primitive.ObjectID) (*model.SalaryEntry, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	entry := r.findDeleted(id, userID)
+	if entry == nil {
+		return nil, nil
+	}
+	return cloneSalaryEntry(entry), nil
+}
+
+func (r *memorySalaryEntryRepository) Undelete(ctx context.Context, id primitive.ObjectID, userID primitive.ObjectID) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	entry := r.findDeleted(id, userID)
+	if entry == nil {
+		return fmt.Errorf("salary entry not found")
+	}
+
+	entry.DeletedAt = nil
+	return nil
+}
+
+func (r *memorySalaryEntryRepository) PurgeDeleted(ctx context.Context, deletedBefore time.Time) ([]primitive.ObjectID, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	var ids []primitive.ObjectID
+	for id, entry := range r.store.salaryEntries {
+		if entry.DeletedAt != nil && entry.DeletedAt.Before(deletedBefore) {
+			delete(r.store.salaryEntries, id)
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
+
+func (r *memorySalaryEntryRepository) Replace(ctx context.Context, entry *model.SalaryEntry) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	stored := r.find(entry.ID, entry.UserID)
+	if stored == nil {
+		return fmt.Errorf("salary entry not found")
+	}
+	if stored.RaisesVersion != entry.RaisesVersion {
+		return fmt.Errorf("salary entry was modified concurrently")
+	}
+
+	entry.UpdatedAt = time.Now()
+	entry.RaisesVersion++
+	r.store.salaryEntries[entry.ID] = cloneSalaryEntry(entry)
+	return nil
+}
+
+func (r *memorySalaryEntryRepository) SetRaises(ctx context.Context, entryID primitive.ObjectID, userID primitive.ObjectID, raises []model.Raise, raisesVersion int) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	entry := r.find(entryID, userID)
+	if entry == nil {
+		return fmt.Errorf("salary entry not found")
+	}
+	if entry.RaisesVersion != raisesVersion {
+		return fmt.Errorf("salary entry was modified concurrently")
+	}
+
+	entry.Raises = withRaiseIDs(raises)
+	entry.RaisesVersion++
+	entry.UpdatedAt = time.Now()
+	return nil
+}
+
+func (r *memorySalaryEntryRepository) GetRaises(ctx context.Context, entryID primitive.ObjectID, userID primitive.ObjectID) ([]model.Raise, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	entry := r.find(entryID, userID)
+	if entry == nil {
+		return nil, fmt.Errorf("salary entry not found")
+	}
+
+	return append([]model.Raise{}, entry.Raises...), nil
+}
+
+func (r *memorySalaryEntryRepository) find(id primitive.ObjectID, userID primitive.ObjectID) *model.SalaryEntry {
+	entry, ok := r.store.salaryEntries[id]
+	if !ok || entry.UserID != userID || entry.DeletedAt != nil {
+		return nil
+	}
+	return entry
+}
+
+func (r *memorySalaryEntryRepository) findDeleted(id primitive.ObjectID, userID primitive.ObjectID) *model.SalaryEntry {
+	entry, ok := r.store.salaryEntries[id]
+	if !ok || entry.UserID != userID || entry.DeletedAt == nil {
+		return nil
+	}
+	return entry
+}
@@ -0,0 +1,87 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/eminsonlu/salystic/internal/model"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type memorySessionRepository struct {
+	store *MemoryStore
+}
+
+func NewMemorySessionRepository(store *MemoryStore) SessionRepository {
+	return &memorySessionRepository{store: store}
+}
+
+func (r *memorySessionRepository) Create(ctx context.Context, session *model.Session) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if session.ID.IsZero() {
+		session.ID = primitive.NewObjectID()
+	}
+	session.CreatedAt = time.Now()
+	session.LastSeenAt = time.Now()
+
+	r.store.sessions[session.ID] = cloneSession(session)
+	return nil
+}
+
+func (r *memorySessionRepository) GetByID(ctx context.Context, id primitive.ObjectID) (*model.Session, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	session, ok := r.store.sessions[id]
+	if !ok {
+		return nil, nil
+	}
+	return cloneSession(session), nil
+}
+
+func (r *memorySessionRepository) GetActiveByUserID(ctx context.Context, userID primitive.ObjectID) ([]*model.Session, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	now := time.Now()
+	sessions := []*model.Session{}
+	for _, session := range r.store.sessions {
+		if session.UserID == userID && session.RevokedAt == nil && session.ExpiresAt.After(now) {
+			sessions = append(sessions, cloneSession(session))
+		}
+	}
+
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].LastSeenAt.After(sessions[j].LastSeenAt)
+	})
+	return sessions, nil
+}
+
+func (r *memorySessionRepository) Touch(ctx context.Context, id primitive.ObjectID) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if session, ok := r.store.sessions[id]; ok {
+		session.LastSeenAt = time.Now()
+	}
+	return nil
+}
+
+func (r *memorySessionRepository) Revoke(ctx context.Context, id primitive.ObjectID, userID primitive.ObjectID) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	session, ok := r.store.sessions[id]
+	if !ok || session.UserID != userID || session.RevokedAt != nil {
+		return fmt.Errorf("session not found")
+	}
+
+	now := time.Now()
+	session.RevokedAt = &now
+	return nil
+}
@@ -0,0 +1,176 @@
+package repo
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/eminsonlu/salystic/internal/model"
+	"github.com/eminsonlu/salystic/pkg/logging"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestMemorySalaryEntryRepository_CRUD(t *testing.T) {
+	ctx := context.Background()
+	repo := NewMemorySalaryEntryRepository(NewMemoryStore())
+	userID := primitive.NewObjectID()
+
+	entry := &model.SalaryEntry{UserID: userID, Level: "Senior", TechStack: []string{"Go"}, SalaryMin: 100000}
+	require.NoError(t, repo.Create(ctx, entry))
+	require.False(t, entry.ID.IsZero())
+
+	entry.TechStack[0] = "mutated"
+	stored, err := repo.GetByID(ctx, entry.ID, userID)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Go"}, stored.TechStack)
+
+	other, err := repo.GetByID(ctx, entry.ID, primitive.NewObjectID())
+	require.NoError(t, err)
+	assert.Nil(t, other)
+
+	level := "Middle"
+	updated, err := repo.Update(ctx, entry.ID, userID, &model.UpdateSalaryEntryRequest{Level: &level})
+	require.NoError(t, err)
+	assert.Equal(t, "Middle", updated.Level)
+	assert.Equal(t, int64(100000), updated.SalaryMin)
+
+	require.NoError(t, repo.SetRaises(ctx, entry.ID, userID, []model.Raise{{NewSalary: 120000}}, 0))
+	raises, err := repo.GetRaises(ctx, entry.ID, userID)
+	require.NoError(t, err)
+	require.Len(t, raises, 1)
+	assert.False(t, raises[0].ID.IsZero())
+
+	require.NoError(t, repo.Delete(ctx, entry.ID, userID))
+	assert.EqualError(t, repo.Delete(ctx, entry.ID, userID), "salary entry not found")
+
+	_, err = repo.GetRaises(ctx, entry.ID, userID)
+	assert.EqualError(t, err, "salary entry not found")
+}
+
+func TestMemoryUserRepository_ConcurrentUpsertCreatesOneUser(t *testing.T) {
+	ctx := context.Background()
+	repo := NewMemoryUserRepository(NewMemoryStore())
+
+	const workers = 20
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		created int
+		ids     = make(map[primitive.ObjectID]bool)
+	)
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			user, isNew, err := repo.UpsertByPseudonymizedID(ctx, "pseudo")
+			require.NoError(t, err)
+
+			mu.Lock()
+			defer mu.Unlock()
+			ids[user.ID] = true
+			if isNew {
+				created++
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, 1, created)
+	assert.Len(t, ids, 1)
+}
+
+func TestMemorySessionRepository_ActiveSessions(t *testing.T) {
+	ctx := context.Background()
+	repo := NewMemorySessionRepository(NewMemoryStore())
+	userID := primitive.NewObjectID()
+
+	active := &model.Session{UserID: userID, ExpiresAt: time.Now().Add(time.Hour)}
+	expired := &model.Session{UserID: userID, ExpiresAt: time.Now().Add(-time.Hour)}
+	revoked := &model.Session{UserID: userID, ExpiresAt: time.Now().Add(time.Hour)}
+	for _, session := range []*model.Session{active, expired, revoked} {
+		require.NoError(t, repo.Create(ctx, session))
+	}
+	require.NoError(t, repo.Revoke(ctx, revoked.ID, userID))
+	assert.EqualError(t, repo.Revoke(ctx, revoked.ID, userID), "session not found")
+
+	sessions, err := repo.GetActiveByUserID(ctx, userID)
+	require.NoError(t, err)
+	require.Len(t, sessions, 1)
+	assert.Equal(t, active.ID, sessions[0].ID)
+}
+
+func TestMemoryAnalyticsRepository_Aggregations(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore()
+	salaries := NewMemorySalaryEntryRepository(store)
+	analytics := NewMemoryAnalyticsRepository(store)
+
+	for _, entry := range []*model.SalaryEntry{
+		{Position: "Backend", Level: "Senior", Currency: "TRY", TechStack: []string{"Go", "MongoDB"}, SalaryMin: 100},
+		{Position: "Backend", Level: "Junior", Currency: "TRY", TechStack: []string{"Go"}, SalaryMin: 50},
+		{Position: "Frontend", Level: "Senior", Currency: "USD", TechStack: []string{}, SalaryMin: 80},
+		{Position: "", Level: "Senior", Currency: "TRY", SalaryMin: 10},
+	} {
+		require.NoError(t, salaries.Create(ctx, entry))
+	}
+
+	total, err := analytics.GetTotalEntries(ctx, &AnalyticsFilter{Currency: "TRY"})
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), total)
+
+	byPosition, err := analytics.GetAverageSalaryByPosition(ctx, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []model.SalaryByCategory{
+		{Category: "Backend", Average: 75, Min: 50, Max: 100, Count: 2},
+		{Category: "Frontend", Average: 80, Min: 80, Max: 80, Count: 1},
+	}, byPosition)
+
+	byTech, err := analytics.GetAverageSalaryByTech(ctx, &AnalyticsFilter{Level: "Senior"})
+	require.NoError(t, err)
+	assert.Equal(t, []model.SalaryByTech{
+		{Tech: "Go", Average: 100, Min: 100, Max: 100, Count: 1},
+		{Tech: "MongoDB", Average: 100, Min: 100, Max: 100, Count: 1},
+	}, byTech)
+
+	combined, err := analytics.GetCombinedAnalytics(ctx, &AnalyticsFilter{Position: "Backend"})
+	require.NoError(t, err)
+	assert.Equal(t, []TotalCount{{Total: 2}}, combined.TotalCount)
+	assert.Equal(t, []OverallAverage{{Average: 75}}, combined.OverallAverage)
+	assert.Len(t, combined.ByLevel, 2)
+
+	empty, err := analytics.GetCombinedAnalytics(ctx, &AnalyticsFilter{Position: "Nobody"})
+	require.NoError(t, err)
+	assert.Empty(t, empty.TotalCount)
+	assert.Empty(t, empty.OverallAverage)
+
+	byCurrency, err := analytics.CountEntriesByCurrency(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int64{"TRY": 3, "USD": 1}, byCurrency)
+
+	positions, err := analytics.GetAvailablePositions(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Backend", "Frontend"}, positions)
+}
+
+func TestMemoryConstantsRepository_Seed(t *testing.T) {
+	ctx := context.Background()
+	repo := NewMemoryConstantsRepository(NewMemoryStore(), logging.NewNop())
+
+	levels, err := repo.GetLevels(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, levels)
+
+	require.NoError(t, repo.SeedConstants(ctx))
+
+	levels, err = repo.GetLevels(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Junior", "Middle", "Senior"}, levels)
+
+	currencies, err := repo.GetCurrencies(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"TRY", "USD", "EUR", "GBP"}, currencies)
+}
@@ -0,0 +1,44 @@
+package repo
+
+import (
+	"context"
+
+	"github.com/eminsonlu/salystic/internal/model"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type memoryTransactor struct {
+	store *MemoryStore
+}
+
+// NewMemoryTransactor runs one transaction at a time and rolls back by
+// restoring the salary entries and revisions saved when it began.
+func NewMemoryTransactor(store *MemoryStore) Transactor {
+	return &memoryTransactor{store: store}
+}
+
+func (t *memoryTransactor) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	t.store.txMu.Lock()
+	defer t.store.txMu.Unlock()
+
+	t.store.mu.RLock()
+	salaryEntries := make(map[primitive.ObjectID]*model.SalaryEntry, len(t.store.salaryEntries))
+	for id, entry := range t.store.salaryEntries {
+		salaryEntries[id] = cloneSalaryEntry(entry)
+	}
+	entryRevisions := make(map[primitive.ObjectID][]*model.EntryRevision, len(t.store.entryRevisions))
+	for id, revisions := range t.store.entryRevisions {
+		entryRevisions[id] = append([]*model.EntryRevision(nil), revisions...)
+	}
+	t.store.mu.RUnlock()
+
+	if err := fn(ctx); err != nil {
+		t.store.mu.Lock()
+		t.store.salaryEntries = salaryEntries
+		t.store.entryRevisions = entryRevisions
+		t.store.mu.Unlock()
+		return err
+	}
+	return nil
+}
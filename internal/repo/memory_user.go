@@ -0,0 +1,106 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/eminsonlu/salystic/internal/model"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type memoryUserRepository struct {
+	store *MemoryStore
+}
+
+func NewMemoryUserRepository(store *MemoryStore) UserRepository {
+	return &memoryUserRepository{store: store}
+}
+
+func (r *memoryUserRepository) Create(ctx context.Context, user *model.User) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if r.findByPseudonymizedID(user.PseudonymizedID) != nil {
+		return fmt.Errorf("failed to create user: duplicate pseudonymized ID")
+	}
+
+	user.ID = primitive.NewObjectID()
+	user.CreatedAt = time.Now()
+	user.UpdatedAt = time.Now()
+	user.LastLogin = time.Now()
+
+	stored := *user
+	r.store.users[user.ID] = &stored
+	return nil
+}
+
+func (r *memoryUserRepository) GetByPseudonymizedID(ctx context.Context, pseudonymizedID string) (*model.User, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	user := r.findByPseudonymizedID(pseudonymizedID)
+	if user == nil {
+		return nil, nil
+	}
+	clone := *user
+	return &clone, nil
+}
+
+func (r *memoryUserRepository) UpsertByPseudonymizedID(ctx context.Context, pseudonymizedID string) (*model.User, bool, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	now := time.Now()
+	if user := r.findByPseudonymizedID(pseudonymizedID); user != nil {
+		user.LastLogin = now
+		user.UpdatedAt = now
+		clone := *user
+		return &clone, false, nil
+	}
+
+	user := &model.User{
+		ID:              primitive.NewObjectID(),
+		PseudonymizedID: pseudonymizedID,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+		LastLogin:       now,
+	}
+	r.store.users[user.ID] = user
+
+	clone := *user
+	return &clone, true, nil
+}
+
+func (r *memoryUserRepository) GetByID(ctx context.Context, id primitive.ObjectID) (*model.User, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	user, ok := r.store.users[id]
+	if !ok {
+		return nil, nil
+	}
+	clone := *user
+	return &clone, nil
+}
+
+func (r *memoryUserRepository) UpdateLastLogin(ctx context.Context, id primitive.ObjectID) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if user, ok := r.store.users[id]; ok {
+		user.LastLogin = time.Now()
+		user.UpdatedAt = time.Now()
+	}
+	return nil
+}
+
+func (r *memoryUserRepository) findByPseudonymizedID(pseudonymizedID string) *model.User {
+	for _, user := range r.store.users {
+		if user.PseudonymizedID == pseudonymizedID {
+			return user
+		}
+	}
+	return nil
+}
@@ -0,0 +1,41 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// IDs stay ObjectIDs in every backend so tokens, URLs and imports keep working;
+// PostgreSQL stores them as their 24 character hex form.
+
+type pgQuerier interface {
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+type pgObjectID struct {
+	id *primitive.ObjectID
+}
+
+func (s pgObjectID) Scan(src any) error {
+	hex, ok := src.(string)
+	if !ok {
+		return fmt.Errorf("cannot scan %T into ObjectID", src)
+	}
+
+	id, err := primitive.ObjectIDFromHex(hex)
+	if err != nil {
+		return err
+	}
+	*s.id = id
+	return nil
+}
+
+func scanID(id *primitive.ObjectID) pgObjectID {
+	return pgObjectID{id: id}
+}
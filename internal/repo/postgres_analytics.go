@@ -0,0 +1,416 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/eminsonlu/salystic/internal/model"
+	"github.com/eminsonlu/salystic/pkg/database"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const salaryAggregates = `AVG(e.salary_min)::float8, MIN(e.salary_min)::float8, MAX(e.salary_min)::float8, COUNT(*)`
+
+var combinedCategoryColumns = []string{
+	"position",
+	"level",
+	"experience",
+	"company",
+	"city",
+	"company_size",
+	"work_type",
+	"currency",
+}
+
+type postgresAnalyticsRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewPostgresAnalyticsRepository(db *database.Postgres) AnalyticsRepository {
+	return &postgresAnalyticsRepository{pool: db.Pool}
+}
+
+func (r *postgresAnalyticsRepository) GetTotalEntries(ctx context.Context, filter *AnalyticsFilter) (int64, error) {
+	where, args := pgAnalyticsWhere(filter)
+
+	var count int64
+	if err := r.pool.QueryRow(ctx, `SELECT COUNT(*) FROM salary_entries e`+where, args...).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count entries: %w", err)
+	}
+	return count, nil
+}
+
+func (r *postgresAnalyticsRepository) CountEntriesByCurrency(ctx context.Context) (map[string]int64, error) {
+	rows, err := r.pool.Query(ctx, `SELECT currency, COUNT(*) FROM salary_entries WHERE deleted_at IS NULL GROUP BY currency`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count entries by currency: %w", err)
+	}
+	defer rows.Close()
+
+	counts := make(map[string]int64)
+	for rows.Next() {
+		var (
+			currency string
+			count    int64
+		)
+		if err := rows.Scan(&currency, &count); err != nil {
+			return nil, fmt.Errorf("failed to decode entry counts: %w", err)
+		}
+		counts[currency] = count
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to count entries by currency: %w", err)
+	}
+	return counts, nil
+}
+
+func (r *postgresAnalyticsRepository) GetAverageSalaryByPosition(ctx context.Context, filter *AnalyticsFilter) ([]model.SalaryByCategory, error) {
+	return r.getAverageSalaryByColumn(ctx, "position", filter)
+}
+
+func (r *postgresAnalyticsRepository) GetAverageSalaryByLevel(ctx context.Context, filter *AnalyticsFilter) ([]model.SalaryByCategory, error) {
+	return r.getAverageSalaryByColumn(ctx, "level", filter)
+}
+
+func (r *postgresAnalyticsRepository) GetAverageSalaryByExperience(ctx context.Context, filter *AnalyticsFilter) ([]model.SalaryByCategory, error) {
+	return r.getAverageSalaryByColumn(ctx, "experience", filter)
+}
+
+func (r *postgresAnalyticsRepository) GetAverageSalaryByCompany(ctx context.Context, filter *AnalyticsFilter) ([]model.SalaryByCategory, error) {
+	return r.getAverageSalaryByColumn(ctx, "company", filter)
+}
+
+func (r *postgresAnalyticsRepository) GetAverageSalaryByCity(ctx context.Context, filter *AnalyticsFilter) ([]model.SalaryByCategory, error) {
+	return r.getAverageSalaryByColumn(ctx, "city", filter)
+}
+
+func (r *postgresAnalyticsRepository) GetAverageSalaryByCompanySize(ctx context.Context, filter *AnalyticsFilter) ([]model.SalaryByCategory, error) {
+	return r.getAverageSalaryByColumn(ctx, "company_size", filter)
+}
+
+func (r *postgresAnalyticsRepository) GetAverageSalaryByWorkType(ctx context.Context, filter *AnalyticsFilter) ([]model.SalaryByCategory, error) {
+	return r.getAverageSalaryByColumn(ctx, "work_type", filter)
+}
+
+func (r *postgresAnalyticsRepository) GetAverageSalaryByCurrency(ctx context.Context, filter *AnalyticsFilter) ([]model.SalaryByCategory, error) {
+	return r.getAverageSalaryByColumn(ctx, "currency", filter)
+}
+
+func (r *postgresAnalyticsRepository) GetAverageSalaryByTech(ctx context.Context, filter *AnalyticsFilter) ([]model.SalaryByTech, error) {
+	where, args := pgAnalyticsWhere(filter, "t.tech <> ''")
+
+	rows, err := r.pool.Query(ctx, `
+		SELECT t.tech, `+salaryAggregates+`
+		FROM salary_entries e
+		JOIN salary_entry_tech_stacks t ON t.entry_id = e.id`+where+`
+		GROUP BY t.tech
+		ORDER BY t.tech COLLATE "C"`,
+		args...,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to aggregate salary by tech: %w", err)
+	}
+
+	categories, err := collectSalaryByCategory(rows)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode results: %w", err)
+	}
+
+	results := make([]model.SalaryByTech, 0, len(categories))
+	for _, category := range categories {
+		results = append(results, model.SalaryByTech{
+			Tech:    category.Category,
+			Average: category.Average,
+			Min:     category.Min,
+			Max:     category.Max,
+			Count:   category.Count,
+		})
+	}
+	return results, nil
+}
+
+func (r *postgresAnalyticsRepository) getAverageSalaryByColumn(ctx context.Context, column string, filter *AnalyticsFilter) ([]model.SalaryByCategory, error) {
+	sql, args := pgCategoryQuery(column, filter)
+
+	rows, err := r.pool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to aggregate salary by field %s: %w", column, err)
+	}
+
+	results, err := collectSalaryByCategory(rows)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode results: %w", err)
+	}
+	return results, nil
+}
+
+func (r *postgresAnalyticsRepository) GetJobChangeData(ctx context.Context) ([]model.JobChangeData, error) {
+	entries, err := querySalaryEntries(ctx, r.pool, `
+		SELECT `+salaryEntryColumns+` FROM salary_entries
+		WHERE NOT concurrent AND deleted_at IS NULL
+		ORDER BY user_id COLLATE "C", start_time, created_at`,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find job change data: %w", err)
+	}
+
+	results := make([]model.JobChangeData, 0, len(entries))
+	for _, entry := range entries {
+		results = append(results, newJobChangeData(entry))
+	}
+	return results, nil
+}
+
+func (r *postgresAnalyticsRepository) GetRaiseData(ctx context.Context) ([]model.RaiseData, error) {
+	entries, err := r.entriesWithRaises(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find raise data: %w", err)
+	}
+
+	results := make([]model.RaiseData, 0, len(entries))
+	for _, entry := range entries {
+		results = append(results, newRaiseData(entry))
+	}
+	return results, nil
+}
+
+func (r *postgresAnalyticsRepository) GetCompensationData(ctx context.Context) ([]model.CompensationData, error) {
+	entries, err := querySalaryEntries(ctx, r.pool, `SELECT `+salaryEntryColumns+` FROM salary_entries WHERE deleted_at IS NULL ORDER BY id`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find compensation data: %w", err)
+	}
+
+	results := make([]model.CompensationData, 0, len(entries))
+	for _, entry := range entries {
+		results = append(results, newCompensationData(entry))
+	}
+	return results, nil
+}
+
+func (r *postgresAnalyticsRepository) entriesWithRaises(ctx context.Context) ([]*model.SalaryEntry, error) {
+	return querySalaryEntries(ctx, r.pool, `
+		SELECT `+salaryEntryColumns+` FROM salary_entries e
+		WHERE e.deleted_at IS NULL AND EXISTS (SELECT 1 FROM raises r WHERE r.entry_id = e.id)
+		ORDER BY e.id`,
+	)
+}
+
+func (r *postgresAnalyticsRepository) GetOverallAverageSalary(ctx context.Context, filter *AnalyticsFilter) (float64, error) {
+	where, args := pgAnalyticsWhere(filter)
+
+	var average float64
+	err := r.pool.QueryRow(ctx, `SELECT COALESCE(AVG(e.salary_min), 0)::float8 FROM salary_entries e`+where, args...).Scan(&average)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get overall average salary: %w", err)
+	}
+	return average, nil
+}
+
+func (r *postgresAnalyticsRepository) GetSalaryPercentiles(ctx context.Context, filter *AnalyticsFilter) (*model.SalaryPercentiles, error) {
+	where, args := pgAnalyticsWhere(filter)
+	args = append(args, salaryPercentileRanks)
+
+	var values []float64
+	err := r.pool.QueryRow(ctx, fmt.Sprintf(`
+		SELECT percentile_cont($%d::float8[]) WITHIN GROUP (ORDER BY e.salary_min)
+		FROM salary_entries e%s`, len(args), where),
+		args...,
+	).Scan(&values)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get salary percentiles: %w", err)
+	}
+	return percentilesFromValues(values), nil
+}
+
+func (r *postgresAnalyticsRepository) GetAverageSalaryByPositionAndLevel(ctx context.Context, filter *AnalyticsFilter) ([]model.SalaryByPositionLevel, error) {
+	where, args := pgAnalyticsWhere(filter, "e.position <> ''", "e.level <> ''")
+
+	rows, err := r.pool.Query(ctx, `
+		SELECT e.position, e.level, `+salaryAggregates+`
+		FROM salary_entries e`+where+`
+		GROUP BY e.position, e.level
+		ORDER BY e.position COLLATE "C", e.level COLLATE "C"`,
+		args...,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to aggregate salary by position and level: %w", err)
+	}
+
+	results, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (model.SalaryByPositionLevel, error) {
+		var result model.SalaryByPositionLevel
+		err := row.Scan(&result.Position, &result.Level, &result.Average, &result.Min, &result.Max, &result.Count)
+		return result, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode results: %w", err)
+	}
+	return results, nil
+}
+
+func (r *postgresAnalyticsRepository) GetSalaryPercentilesByPosition(ctx context.Context, filter *AnalyticsFilter) (map[string]model.SalaryPercentiles, error) {
+	where, args := pgAnalyticsWhere(filter, "e.position <> ''")
+	args = append(args, salaryPercentileRanks)
+
+	rows, err := r.pool.Query(ctx, fmt.Sprintf(`
+		SELECT e.position, percentile_cont($%d::float8[]) WITHIN GROUP (ORDER BY e.salary_min)
+		FROM salary_entries e%s
+		GROUP BY e.position`, len(args), where),
+		args...,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get salary percentiles by position: %w", err)
+	}
+	defer rows.Close()
+
+	percentiles := make(map[string]model.SalaryPercentiles)
+	for rows.Next() {
+		var position string
+		var values []float64
+		if err := rows.Scan(&position, &values); err != nil {
+			return nil, fmt.Errorf("failed to decode salary percentiles by position: %w", err)
+		}
+		percentiles[position] = *percentilesFromValues(values)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to get salary percentiles by position: %w", err)
+	}
+	return percentiles, nil
+}
+
+func (r *postgresAnalyticsRepository) GetAvailablePositions(ctx context.Context) ([]string, error) {
+	positions, err := r.distinct(ctx, "position")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get available positions: %w", err)
+	}
+	return positions, nil
+}
+
+func (r *postgresAnalyticsRepository) GetAvailableLevels(ctx context.Context) ([]string, error) {
+	levels, err := r.distinct(ctx, "level")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get available levels: %w", err)
+	}
+	return levels, nil
+}
+
+func (r *postgresAnalyticsRepository) distinct(ctx context.Context, column string) ([]string, error) {
+	rows, err := r.pool.Query(ctx, fmt.Sprintf(
+		`SELECT %[1]s FROM salary_entries WHERE %[1]s <> '' AND deleted_at IS NULL GROUP BY %[1]s ORDER BY %[1]s COLLATE "C"`,
+		column,
+	))
+	if err != nil {
+		return nil, err
+	}
+
+	values, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+// GetCombinedAnalytics sends the totals and every category breakdown in a
+// single batch so the dashboard costs one round trip, like the $facet
+// pipeline of the MongoDB implementation.
+func (r *postgresAnalyticsRepository) GetCombinedAnalytics(ctx context.Context, filter *AnalyticsFilter) (*CombinedAnalyticsResult, error) {
+	batch := &pgx.Batch{}
+
+	where, args := pgAnalyticsWhere(filter)
+	batch.Queue(`SELECT COUNT(*), COALESCE(AVG(e.salary_min), 0)::float8 FROM salary_entries e`+where, args...)
+
+	for _, column := range combinedCategoryColumns {
+		sql, args := pgCategoryQuery(column, filter)
+		batch.Queue(sql, args...)
+	}
+
+	results := r.pool.SendBatch(ctx, batch)
+	defer results.Close()
+
+	var (
+		result  CombinedAnalyticsResult
+		total   int64
+		average float64
+	)
+	if err := results.QueryRow().Scan(&total, &average); err != nil {
+		return nil, fmt.Errorf("failed to execute combined analytics query: %w", err)
+	}
+	if total > 0 {
+		result.TotalCount = []TotalCount{{Total: total}}
+		result.OverallAverage = []OverallAverage{{Average: average}}
+	}
+
+	targets := []*[]model.SalaryByCategory{
+		&result.ByPosition,
+		&result.ByLevel,
+		&result.ByExperience,
+		&result.ByCompany,
+		&result.ByCity,
+		&result.ByCompanySize,
+		&result.ByWorkType,
+		&result.ByCurrency,
+	}
+	for i, target := range targets {
+		rows, err := results.Query()
+		if err != nil {
+			return nil, fmt.Errorf("failed to execute combined analytics query: %w", err)
+		}
+
+		categories, err := collectSalaryByCategory(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode combined analytics %s: %w", combinedCategoryColumns[i], err)
+		}
+		*target = categories
+	}
+
+	return &result, nil
+}
+
+// Categories are ordered with the "C" collation so they sort byte-wise like
+// the group keys of the MongoDB implementation.
+func pgCategoryQuery(column string, filter *AnalyticsFilter) (string, []any) {
+	where, args := pgAnalyticsWhere(filter, "e."+column+" <> ''")
+	return fmt.Sprintf(`
+		SELECT e.%[1]s, %[2]s
+		FROM salary_entries e%[3]s
+		GROUP BY e.%[1]s
+		ORDER BY e.%[1]s COLLATE "C"`,
+		column, salaryAggregates, where,
+	), args
+}
+
+// pgAnalyticsWhere builds the WHERE clause of an analytics query over the
+// salary_entries alias e, always leaving out deleted entries.
+func pgAnalyticsWhere(filter *AnalyticsFilter, conditions ...string) (string, []any) {
+	var args []any
+	conditions = append([]string{"e.deleted_at IS NULL"}, conditions...)
+
+	if filter != nil {
+		add := func(column, value string) {
+			if value == "" {
+				return
+			}
+			args = append(args, value)
+			conditions = append(conditions, fmt.Sprintf("e.%s = $%d", column, len(args)))
+		}
+		add("position", filter.Position)
+		add("level", filter.Level)
+		add("currency", filter.Currency)
+		if filter.SalaryBasis != "" {
+			args = append(args, salaryBasisValues(filter.SalaryBasis))
+			conditions = append(conditions, fmt.Sprintf("e.salary_basis = ANY($%d)", len(args)))
+		}
+	}
+
+	return " WHERE " + strings.Join(conditions, " AND "), args
+}
+
+func collectSalaryByCategory(rows pgx.Rows) ([]model.SalaryByCategory, error) {
+	return pgx.CollectRows(rows, func(row pgx.CollectableRow) (model.SalaryByCategory, error) {
+		var category model.SalaryByCategory
+		err := row.Scan(&category.Category, &category.Average, &category.Min, &category.Max, &category.Count)
+		return category, err
+	})
+}
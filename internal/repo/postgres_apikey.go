@@ -0,0 +1,119 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/eminsonlu/salystic/internal/model"
+	"github.com/eminsonlu/salystic/pkg/database"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const apiKeyColumns = `id, name, prefix, key_hash, scopes, rate_limit, created_by, created_at, expires_at, revoked_at, last_used_at, usage_count`
+
+type postgresAPIKeyRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewPostgresAPIKeyRepository(db *database.Postgres) APIKeyRepository {
+	return &postgresAPIKeyRepository{pool: db.Pool}
+}
+
+func (r *postgresAPIKeyRepository) Create(ctx context.Context, key *model.APIKey) error {
+	if key.ID.IsZero() {
+		key.ID = primitive.NewObjectID()
+	}
+	key.CreatedAt = time.Now()
+
+	scopes := key.Scopes
+	if scopes == nil {
+		scopes = []string{}
+	}
+
+	_, err := r.pool.Exec(ctx,
+		`INSERT INTO api_keys (`+apiKeyColumns+`) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)`,
+		key.ID.Hex(), key.Name, key.Prefix, key.KeyHash, scopes, key.RateLimit, key.CreatedBy,
+		key.CreatedAt, key.ExpiresAt, key.RevokedAt, key.LastUsedAt, key.UsageCount,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create API key: %w", err)
+	}
+	return nil
+}
+
+func (r *postgresAPIKeyRepository) GetByHash(ctx context.Context, keyHash string) (*model.APIKey, error) {
+	key, err := scanAPIKey(r.pool.QueryRow(ctx, `SELECT `+apiKeyColumns+` FROM api_keys WHERE key_hash = $1`, keyHash))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get API key: %w", err)
+	}
+	return key, nil
+}
+
+func (r *postgresAPIKeyRepository) List(ctx context.Context) ([]*model.APIKey, error) {
+	rows, err := r.pool.Query(ctx, `SELECT `+apiKeyColumns+` FROM api_keys ORDER BY created_at DESC`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find API keys: %w", err)
+	}
+	defer rows.Close()
+
+	keys := []*model.APIKey{}
+	for rows.Next() {
+		key, err := scanAPIKey(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode API keys: %w", err)
+		}
+		keys = append(keys, key)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to find API keys: %w", err)
+	}
+
+	return keys, nil
+}
+
+func (r *postgresAPIKeyRepository) Revoke(ctx context.Context, id primitive.ObjectID) error {
+	tag, err := r.pool.Exec(ctx,
+		`UPDATE api_keys SET revoked_at = $2 WHERE id = $1 AND revoked_at IS NULL`,
+		id.Hex(), time.Now(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to revoke API key: %w", err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("api key not found")
+	}
+
+	return nil
+}
+
+func (r *postgresAPIKeyRepository) RecordUsage(ctx context.Context, id primitive.ObjectID, count int64, usedAt time.Time) error {
+	_, err := r.pool.Exec(ctx,
+		`UPDATE api_keys SET usage_count = usage_count + $2, last_used_at = GREATEST(last_used_at, $3) WHERE id = $1`,
+		id.Hex(), count, usedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to record API key usage: %w", err)
+	}
+	return nil
+}
+
+func scanAPIKey(row pgx.Row) (*model.APIKey, error) {
+	var key model.APIKey
+	err := row.Scan(
+		scanID(&key.ID), &key.Name, &key.Prefix, &key.KeyHash, &key.Scopes, &key.RateLimit, &key.CreatedBy,
+		&key.CreatedAt, &key.ExpiresAt, &key.RevokedAt, &key.LastUsedAt, &key.UsageCount,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
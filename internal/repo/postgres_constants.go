@@ -0,0 +1,92 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/eminsonlu/salystic/pkg/database"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type postgresConstantsRepository struct {
+	pool   *pgxpool.Pool
+	logger *slog.Logger
+}
+
+func NewPostgresConstantsRepository(db *database.Postgres, logger *slog.Logger) ConstantsRepository {
+	return &postgresConstantsRepository{
+		pool:   db.Pool,
+		logger: logger,
+	}
+}
+
+func (r *postgresConstantsRepository) SeedConstants(ctx context.Context) error {
+	for _, constant := range defaultConstantNames() {
+		batch := &pgx.Batch{}
+		for i, name := range constant.names {
+			batch.Queue(
+				`INSERT INTO constants (kind, name, ordinal) VALUES ($1, $2, $3) ON CONFLICT (kind, name) DO NOTHING`,
+				constant.kind, name, i,
+			)
+		}
+
+		if err := r.pool.SendBatch(ctx, batch).Close(); err != nil {
+			return fmt.Errorf("failed to seed %s: %w", constant.kind, err)
+		}
+		r.logger.Info("Seeded constants", "kind", constant.kind, "count", len(constant.names))
+	}
+
+	return nil
+}
+
+func (r *postgresConstantsRepository) GetPositions(ctx context.Context) ([]string, error) {
+	return r.get(ctx, "positions")
+}
+
+func (r *postgresConstantsRepository) GetLevels(ctx context.Context) ([]string, error) {
+	return r.get(ctx, "levels")
+}
+
+func (r *postgresConstantsRepository) GetTechStacks(ctx context.Context) ([]string, error) {
+	return r.get(ctx, "tech_stacks")
+}
+
+func (r *postgresConstantsRepository) GetExperiences(ctx context.Context) ([]string, error) {
+	return r.get(ctx, "experiences")
+}
+
+func (r *postgresConstantsRepository) GetCompanies(ctx context.Context) ([]string, error) {
+	return r.get(ctx, "companies")
+}
+
+func (r *postgresConstantsRepository) GetCompanySizes(ctx context.Context) ([]string, error) {
+	return r.get(ctx, "company_sizes")
+}
+
+func (r *postgresConstantsRepository) GetWorkTypes(ctx context.Context) ([]string, error) {
+	return r.get(ctx, "work_types")
+}
+
+func (r *postgresConstantsRepository) GetCities(ctx context.Context) ([]string, error) {
+	return r.get(ctx, "cities")
+}
+
+func (r *postgresConstantsRepository) GetCurrencies(ctx context.Context) ([]string, error) {
+	return r.get(ctx, "currencies")
+}
+
+func (r *postgresConstantsRepository) get(ctx context.Context, kind string) ([]string, error) {
+	rows, err := r.pool.Query(ctx, `SELECT name FROM constants WHERE kind = $1 ORDER BY ordinal`, kind)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find %s: %w", kind, err)
+	}
+
+	names, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode %s: %w", kind, err)
+	}
+	return names, nil
+}
@@ -0,0 +1,122 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/eminsonlu/salystic/internal/model"
+	"github.com/eminsonlu/salystic/pkg/database"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const entryRevisionColumns = `id, entry_id, user_id, version, action, restored_from, changed_by, changed_at, changes, snapshot`
+
+type postgresEntryRevisionRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewPostgresEntryRevisionRepository(db *database.Postgres) EntryRevisionRepository {
+	return &postgresEntryRevisionRepository{pool: db.Pool}
+}
+
+// Create numbers the revision in the insert itself. Locking the entry's row
+// first makes concurrent revisions of the same entry wait for each other
+// instead of picking the same version.
+func (r *postgresEntryRevisionRepository) Create(ctx context.Context, revision *model.EntryRevision) error {
+	if revision.ID.IsZero() {
+		revision.ID = primitive.NewObjectID()
+	}
+	revision.ChangedAt = time.Now()
+
+	changes := revision.Changes
+	if changes == nil {
+		changes = []model.FieldChange{}
+	}
+
+	err := pgx.BeginFunc(ctx, pgConnFor(ctx, r.pool), func(tx pgx.Tx) error {
+		if _, err := tx.Exec(ctx, `SELECT 1 FROM salary_entries WHERE id = $1 FOR UPDATE`, revision.EntryID.Hex()); err != nil {
+			return err
+		}
+
+		return tx.QueryRow(ctx,
+			`INSERT INTO entry_revisions (`+entryRevisionColumns+`)
+			SELECT $1, $2, $3, COALESCE(MAX(version), 0) + 1, $4, $5, $6, $7, $8, $9
+			FROM entry_revisions WHERE entry_id = $2
+			RETURNING version`,
+			revision.ID.Hex(), revision.EntryID.Hex(), revision.UserID.Hex(), revision.Action, revision.RestoredFrom,
+			revision.ChangedBy.Hex(), revision.ChangedAt, changes, revision.Snapshot,
+		).Scan(&revision.Version)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create entry revision: %w", err)
+	}
+	return nil
+}
+
+func (r *postgresEntryRevisionRepository) ListByEntryID(ctx context.Context, entryID primitive.ObjectID) ([]*model.EntryRevision, error) {
+	rows, err := pgConnFor(ctx, r.pool).Query(ctx,
+		`SELECT `+entryRevisionColumns+` FROM entry_revisions WHERE entry_id = $1 ORDER BY version`,
+		entryID.Hex(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find entry revisions: %w", err)
+	}
+	defer rows.Close()
+
+	revisions := []*model.EntryRevision{}
+	for rows.Next() {
+		revision, err := scanEntryRevision(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode entry revisions: %w", err)
+		}
+		revisions = append(revisions, revision)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to find entry revisions: %w", err)
+	}
+
+	return revisions, nil
+}
+
+func (r *postgresEntryRevisionRepository) GetByVersion(ctx context.Context, entryID primitive.ObjectID, version int) (*model.EntryRevision, error) {
+	revision, err := scanEntryRevision(pgConnFor(ctx, r.pool).QueryRow(ctx,
+		`SELECT `+entryRevisionColumns+` FROM entry_revisions WHERE entry_id = $1 AND version = $2`,
+		entryID.Hex(), version,
+	))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get entry revision: %w", err)
+	}
+	return revision, nil
+}
+
+func scanEntryRevision(row pgx.Row) (*model.EntryRevision, error) {
+	var revision model.EntryRevision
+	err := row.Scan(
+		scanID(&revision.ID), scanID(&revision.EntryID), scanID(&revision.UserID), &revision.Version, &revision.Action,
+		&revision.RestoredFrom, scanID(&revision.ChangedBy), &revision.ChangedAt, &revision.Changes, &revision.Snapshot,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &revision, nil
+}
+
+func (r *postgresEntryRevisionRepository) DeleteByEntryIDs(ctx context.Context, entryIDs []primitive.ObjectID) error {
+	ids := make([]string, len(entryIDs))
+	for i, id := range entryIDs {
+		ids[i] = id.Hex()
+	}
+
+	if _, err := pgConnFor(ctx, r.pool).Exec(ctx, `DELETE FROM entry_revisions WHERE entry_id = ANY($1)`, ids); err != nil {
+		return fmt.Errorf("failed to delete entry revisions: %w", err)
+	}
+	return nil
+}
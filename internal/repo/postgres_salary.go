@@ -0,0 +1,579 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/eminsonlu/salystic/internal/model"
+	"github.com/eminsonlu/salystic/pkg/database"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const salaryEntryColumns = `id, user_id, level, position, experience, gender, company, company_size, work_type, city,
+	currency, salary_basis, salary_range, salary_min, salary_max, raise_period, start_time, end_time, concurrent, change_reason, compensation, created_at, updated_at, deleted_at, raises_version`
+
+const raiseColumns = `id, entry_id, raise_date, new_salary, percentage, created_at`
+
+type postgresSalaryEntryRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewPostgresSalaryEntryRepository(db *database.Postgres) SalaryEntryRepository {
+	return &postgresSalaryEntryRepository{pool: db.Pool}
+}
+
+func (r *postgresSalaryEntryRepository) Create(ctx context.Context, entry *model.SalaryEntry) error {
+	if entry.ID.IsZero() {
+		entry.ID = primitive.NewObjectID()
+	}
+	entry.CreatedAt = time.Now()
+	entry.UpdatedAt = time.Now()
+
+	err := pgx.BeginFunc(ctx, pgConnFor(ctx, r.pool), func(tx pgx.Tx) error {
+		_, err := tx.Exec(ctx,
+			`INSERT INTO salary_entries (`+salaryEntryColumns+`)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24, $25)`,
+			entry.ID.Hex(), entry.UserID.Hex(), entry.Level, entry.Position, entry.Experience, entry.Gender,
+			entry.Company, entry.CompanySize, entry.WorkType, entry.City, entry.Currency, entry.SalaryBasis, entry.SalaryRange,
+			entry.SalaryMin, entry.SalaryMax, entry.RaisePeriod, entry.StartTime, entry.EndTime,
+			entry.Concurrent, entry.ChangeReason, entry.Compensation, entry.CreatedAt, entry.UpdatedAt, entry.DeletedAt,
+			entry.RaisesVersion,
+		)
+		if err != nil {
+			return err
+		}
+
+		if err := insertTechStack(ctx, tx, entry.ID, entry.TechStack); err != nil {
+			return err
+		}
+
+		for i := range entry.Raises {
+			if entry.Raises[i].ID.IsZero() {
+				entry.Raises[i].ID = primitive.NewObjectID()
+			}
+			if err := insertRaise(ctx, tx, entry.ID, &entry.Raises[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create salary entry: %w", err)
+	}
+	return nil
+}
+
+func (r *postgresSalaryEntryRepository) GetByID(ctx context.Context, id primitive.ObjectID, userID primitive.ObjectID) (*model.SalaryEntry, error) {
+	entries, err := querySalaryEntries(ctx, pgConnFor(ctx, r.pool),
+		`SELECT `+salaryEntryColumns+` FROM salary_entries WHERE id = $1 AND user_id = $2 AND deleted_at IS NULL`,
+		id.Hex(), userID.Hex(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get salary entry: %w", err)
+	}
+
+	if len(entries) == 0 {
+		return nil, nil
+	}
+	return entries[0], nil
+}
+
+func (r *postgresSalaryEntryRepository) GetByUserID(ctx context.Context, userID primitive.ObjectID) ([]*model.SalaryEntry, error) {
+	entries, err := querySalaryEntries(ctx, pgConnFor(ctx, r.pool),
+		`SELECT `+salaryEntryColumns+` FROM salary_entries WHERE user_id = $1 AND deleted_at IS NULL ORDER BY created_at DESC`,
+		userID.Hex(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find salary entries: %w", err)
+	}
+	return entries, nil
+}
+
+func (r *postgresSalaryEntryRepository) ListByUserID(ctx context.Context, userID primitive.ObjectID, opts *SalaryEntryListOptions) ([]*model.SalaryEntry, error) {
+	conditions := []string{"user_id = $1", "deleted_at IS NULL"}
+	args := []any{userID.Hex()}
+
+	if opts.Currency != "" {
+		args = append(args, opts.Currency)
+		conditions = append(conditions, fmt.Sprintf("currency = $%d", len(args)))
+	}
+	if opts.Current != nil {
+		if *opts.Current {
+			conditions = append(conditions, "end_time IS NULL")
+		} else {
+			conditions = append(conditions, "end_time IS NOT NULL")
+		}
+	}
+
+	column := salaryEntrySortField(opts.SortBy)
+	direction, op := "DESC", "<"
+	if opts.Ascending {
+		direction, op = "ASC", ">"
+	}
+
+	if opts.After != nil {
+		args = append(args, opts.After.value(opts.SortBy), opts.After.ID.Hex())
+		conditions = append(conditions, fmt.Sprintf(`(%s, id COLLATE "C") %s ($%d, $%d)`, column, op, len(args)-1, len(args)))
+	}
+
+	args = append(args, opts.Limit)
+	entries, err := querySalaryEntries(ctx, pgConnFor(ctx, r.pool),
+		fmt.Sprintf(`SELECT `+salaryEntryColumns+` FROM salary_entries WHERE %s ORDER BY %s %s, id COLLATE "C" %s LIMIT $%d`,
+			strings.Join(conditions, " AND "), column, direction, direction, len(args)),
+		args...,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find salary entries: %w", err)
+	}
+	return entries, nil
+}
+
+func (r *postgresSalaryEntryRepository) Update(ctx context.Context, id primitive.ObjectID, userID primitive.ObjectID, update *model.UpdateSalaryEntryRequest) (*model.SalaryEntry, error) {
+	sets := []string{"updated_at = $3"}
+	args := []any{id.Hex(), userID.Hex(), time.Now()}
+
+	set := func(column string, value any) {
+		args = append(args, value)
+		sets = append(sets, fmt.Sprintf("%s = $%d", column, len(args)))
+	}
+
+	if update.Level != nil {
+		set("level", *update.Level)
+	}
+	if update.Position != nil {
+		set("position", *update.Position)
+	}
+	if update.Experience != nil {
+		set("experience", *update.Experience)
+	}
+	if update.Gender != nil {
+		set("gender", *update.Gender)
+	}
+	if update.Company != nil {
+		set("company", *update.Company)
+	}
+	if update.CompanySize != nil {
+		set("company_size", *update.CompanySize)
+	}
+	if update.WorkType != nil {
+		set("work_type", *update.WorkType)
+	}
+	if update.City != nil {
+		set("city", *update.City)
+	}
+	if update.Currency != nil {
+		set("currency", *update.Currency)
+	}
+	if update.SalaryBasis != nil {
+		set("salary_basis", *update.SalaryBasis)
+	}
+	if update.SalaryMin != nil {
+		set("salary_min", *update.SalaryMin)
+	}
+	if update.SalaryMax != nil {
+		set("salary_max", *update.SalaryMax)
+	}
+	if update.RaisePeriod != nil {
+		set("raise_period", *update.RaisePeriod)
+	}
+	if update.StartTime != nil {
+		set("start_time", *update.StartTime)
+	}
+	if update.EndTime != nil {
+		set("end_time", *update.EndTime)
+	}
+	if update.Concurrent != nil {
+		set("concurrent", *update.Concurrent)
+	}
+	if update.ChangeReason != nil {
+		set("change_reason", *update.ChangeReason)
+	}
+	if update.Compensation != nil {
+		set("compensation", update.Compensation)
+	}
+	if changesRaiseBasis(update) {
+		sets = append(sets, "raises_version = raises_version + 1")
+	}
+
+	var entry *model.SalaryEntry
+	err := pgx.BeginFunc(ctx, pgConnFor(ctx, r.pool), func(tx pgx.Tx) error {
+		tag, err := tx.Exec(ctx,
+			`UPDATE salary_entries SET `+strings.Join(sets, ", ")+` WHERE id = $1 AND user_id = $2 AND deleted_at IS NULL`,
+			args...,
+		)
+		if err != nil {
+			return err
+		}
+		if tag.RowsAffected() == 0 {
+			return nil
+		}
+
+		if update.TechStack != nil {
+			if _, err := tx.Exec(ctx, `DELETE FROM salary_entry_tech_stacks WHERE entry_id = $1`, id.Hex()); err != nil {
+				return err
+			}
+			if err := insertTechStack(ctx, tx, id, update.TechStack); err != nil {
+				return err
+			}
+		}
+
+		entries, err := querySalaryEntries(ctx, tx, `SELECT `+salaryEntryColumns+` FROM salary_entries WHERE id = $1`, id.Hex())
+		if err != nil {
+			return err
+		}
+		entry = entries[0]
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update salary entry: %w", err)
+	}
+
+	return entry, nil
+}
+
+func (r *postgresSalaryEntryRepository) Delete(ctx context.Context, id primitive.ObjectID, userID primitive.ObjectID) error {
+	tag, err := pgConnFor(ctx, r.pool).Exec(ctx,
+		`UPDATE salary_entries SET deleted_at = $3 WHERE id = $1 AND user_id = $2 AND deleted_at IS NULL`,
+		id.Hex(), userID.Hex(), time.Now(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to delete salary entry: %w", err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("salary entry not found")
+	}
+
+	return nil
+}
+
+func (r *postgresSalaryEntryRepository) ListDeletedByUserID(ctx context.Context, userID primitive.ObjectID) ([]*model.SalaryEntry, error) {
+	entries, err := querySalaryEntries(ctx, pgConnFor(ctx, r.pool),
+		`SELECT `+salaryEntryColumns+` FROM salary_entries
+		WHERE user_id = $1 AND deleted_at IS NOT NULL
+		ORDER BY deleted_at DESC, id COLLATE "C" DESC`,
+		userID.Hex(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find deleted salary entries: %w", err)
+	}
+	return entries, nil
+}
+
+func (r *postgresSalaryEntryRepository) GetDeletedByID(ctx context.Context, id primitive.ObjectID, userID primitive.ObjectID) (*model.SalaryEntry, error) {
+	entries, err := querySalaryEntries(ctx, pgConnFor(ctx, r.pool),
+		`SELECT `+salaryEntryColumns+` FROM salary_entries WHERE id = $1 AND user_id = $2 AND deleted_at IS NOT NULL`,
+		id.Hex(), userID.Hex(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get deleted salary entry: %w", err)
+	}
+
+	if len(entries) == 0 {
+		return nil, nil
+	}
+	return entries[0], nil
+}
+
+func (r *postgresSalaryEntryRepository) Undelete(ctx context.Context, id primitive.ObjectID, userID primitive.ObjectID) error {
+	tag, err := pgConnFor(ctx, r.pool).Exec(ctx,
+		`UPDATE salary_entries SET deleted_at = NULL WHERE id = $1 AND user_id = $2 AND deleted_at IS NOT NULL`,
+		id.Hex(), userID.Hex(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to undelete salary entry: %w", err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("salary entry not found")
+	}
+
+	return nil
+}
+
+// PurgeDeleted relies on the tech stack and raises of an entry being removed
+// with it by their foreign keys.
+func (r *postgresSalaryEntryRepository) PurgeDeleted(ctx context.Context, deletedBefore time.Time) ([]primitive.ObjectID, error) {
+	rows, err := pgConnFor(ctx, r.pool).Query(ctx, `DELETE FROM salary_entries WHERE deleted_at < $1 RETURNING id`, deletedBefore)
+	if err != nil {
+		return nil, fmt.Errorf("failed to purge salary entries: %w", err)
+	}
+	defer rows.Close()
+
+	var ids []primitive.ObjectID
+	for rows.Next() {
+		var id primitive.ObjectID
+		if err := rows.Scan(scanID(&id)); err != nil {
+			return nil, fmt.Errorf("failed to purge salary entries: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to purge salary entries: %w", err)
+	}
+	return ids, nil
+}
+
+func (r *postgresSalaryEntryRepository) Replace(ctx context.Context, entry *model.SalaryEntry) error {
+	updatedAt := time.Now()
+
+	var found bool
+	err := pgx.BeginFunc(ctx, pgConnFor(ctx, r.pool), func(tx pgx.Tx) error {
+		tag, err := tx.Exec(ctx,
+			`UPDATE salary_entries SET
+				level = $3, position = $4, experience = $5, gender = $6, company = $7, company_size = $8,
+				work_type = $9, city = $10, currency = $11, salary_basis = $12, salary_range = $13,
+				salary_min = $14, salary_max = $15, raise_period = $16, start_time = $17, end_time = $18,
+				concurrent = $19, change_reason = $20, compensation = $21, created_at = $22, updated_at = $23,
+				raises_version = raises_version + 1
+			WHERE id = $1 AND user_id = $2 AND deleted_at IS NULL AND raises_version = $24`,
+			entry.ID.Hex(), entry.UserID.Hex(), entry.Level, entry.Position, entry.Experience, entry.Gender,
+			entry.Company, entry.CompanySize, entry.WorkType, entry.City, entry.Currency, entry.SalaryBasis,
+			entry.SalaryRange, entry.SalaryMin, entry.SalaryMax, entry.RaisePeriod, entry.StartTime, entry.EndTime,
+			entry.Concurrent, entry.ChangeReason, entry.Compensation, entry.CreatedAt, updatedAt, entry.RaisesVersion,
+		)
+		if err != nil {
+			return err
+		}
+		if found = tag.RowsAffected() > 0; !found {
+			return nil
+		}
+
+		if _, err := tx.Exec(ctx, `DELETE FROM salary_entry_tech_stacks WHERE entry_id = $1`, entry.ID.Hex()); err != nil {
+			return err
+		}
+		if err := insertTechStack(ctx, tx, entry.ID, entry.TechStack); err != nil {
+			return err
+		}
+
+		if _, err := tx.Exec(ctx, `DELETE FROM raises WHERE entry_id = $1`, entry.ID.Hex()); err != nil {
+			return err
+		}
+		for i := range entry.Raises {
+			if err := insertRaise(ctx, tx, entry.ID, &entry.Raises[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to replace salary entry: %w", err)
+	}
+
+	if !found {
+		return r.missingOrModified(ctx, entry.ID, entry.UserID)
+	}
+
+	entry.UpdatedAt = updatedAt
+	entry.RaisesVersion++
+	return nil
+}
+
+func (r *postgresSalaryEntryRepository) SetRaises(ctx context.Context, entryID primitive.ObjectID, userID primitive.ObjectID, raises []model.Raise, raisesVersion int) error {
+	raises = withRaiseIDs(raises)
+
+	var found bool
+	err := pgx.BeginFunc(ctx, pgConnFor(ctx, r.pool), func(tx pgx.Tx) error {
+		tag, err := tx.Exec(ctx,
+			`UPDATE salary_entries SET updated_at = $3, raises_version = raises_version + 1
+			WHERE id = $1 AND user_id = $2 AND deleted_at IS NULL AND raises_version = $4`,
+			entryID.Hex(), userID.Hex(), time.Now(), raisesVersion,
+		)
+		if err != nil {
+			return err
+		}
+		if found = tag.RowsAffected() > 0; !found {
+			return nil
+		}
+		if _, err := tx.Exec(ctx, `DELETE FROM raises WHERE entry_id = $1`, entryID.Hex()); err != nil {
+			return err
+		}
+		for i := range raises {
+			if err := insertRaise(ctx, tx, entryID, &raises[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to set raises: %w", err)
+	}
+
+	if !found {
+		return r.missingOrModified(ctx, entryID, userID)
+	}
+
+	return nil
+}
+
+// missingOrModified explains why a write guarded by the raises version matched
+// no row: the entry is gone, or its raises moved past that version.
+func (r *postgresSalaryEntryRepository) missingOrModified(ctx context.Context, id primitive.ObjectID, userID primitive.ObjectID) error {
+	var exists bool
+	err := pgConnFor(ctx, r.pool).QueryRow(ctx,
+		`SELECT EXISTS (SELECT 1 FROM salary_entries WHERE id = $1 AND user_id = $2 AND deleted_at IS NULL)`,
+		id.Hex(), userID.Hex(),
+	).Scan(&exists)
+	if err != nil {
+		return fmt.Errorf("failed to get salary entry: %w", err)
+	}
+	if !exists {
+		return fmt.Errorf("salary entry not found")
+	}
+	return fmt.Errorf("salary entry was modified concurrently")
+}
+
+func (r *postgresSalaryEntryRepository) GetRaises(ctx context.Context, entryID primitive.ObjectID, userID primitive.ObjectID) ([]model.Raise, error) {
+	var exists bool
+	err := pgConnFor(ctx, r.pool).QueryRow(ctx,
+		`SELECT EXISTS (SELECT 1 FROM salary_entries WHERE id = $1 AND user_id = $2 AND deleted_at IS NULL)`,
+		entryID.Hex(), userID.Hex(),
+	).Scan(&exists)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get raises: %w", err)
+	}
+
+	if !exists {
+		return nil, fmt.Errorf("salary entry not found")
+	}
+
+	raises, err := queryRaises(ctx, pgConnFor(ctx, r.pool), []string{entryID.Hex()})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get raises: %w", err)
+	}
+
+	if raises[entryID] == nil {
+		return []model.Raise{}, nil
+	}
+	return raises[entryID], nil
+}
+
+func insertTechStack(ctx context.Context, q pgQuerier, entryID primitive.ObjectID, techStack []string) error {
+	for i, tech := range techStack {
+		_, err := q.Exec(ctx,
+			`INSERT INTO salary_entry_tech_stacks (entry_id, ordinal, tech) VALUES ($1, $2, $3)`,
+			entryID.Hex(), i, tech,
+		)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func insertRaise(ctx context.Context, q pgQuerier, entryID primitive.ObjectID, raise *model.Raise) error {
+	_, err := q.Exec(ctx,
+		`INSERT INTO raises (`+raiseColumns+`) VALUES ($1, $2, $3, $4, $5, $6)`,
+		raise.ID.Hex(), entryID.Hex(), raise.RaiseDate, raise.NewSalary, raise.Percentage, raise.CreatedAt,
+	)
+	return err
+}
+
+// querySalaryEntries runs a query selecting salaryEntryColumns and attaches the
+// tech stack and raises of every returned entry, keeping the query's order.
+func querySalaryEntries(ctx context.Context, q pgQuerier, sql string, args ...any) ([]*model.SalaryEntry, error) {
+	rows, err := q.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []*model.SalaryEntry
+	for rows.Next() {
+		var entry model.SalaryEntry
+		err := rows.Scan(
+			scanID(&entry.ID), scanID(&entry.UserID), &entry.Level, &entry.Position, &entry.Experience,
+			&entry.Gender, &entry.Company, &entry.CompanySize, &entry.WorkType, &entry.City,
+			&entry.Currency, &entry.SalaryBasis, &entry.SalaryRange, &entry.SalaryMin, &entry.SalaryMax, &entry.RaisePeriod,
+			&entry.StartTime, &entry.EndTime, &entry.Concurrent, &entry.ChangeReason, &entry.Compensation, &entry.CreatedAt, &entry.UpdatedAt,
+			&entry.DeletedAt, &entry.RaisesVersion,
+		)
+		if err != nil {
+			rows.Close()
+			return nil, err
+		}
+		entries = append(entries, &entry)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(entries) == 0 {
+		return entries, nil
+	}
+
+	ids := make([]string, len(entries))
+	for i, entry := range entries {
+		ids[i] = entry.ID.Hex()
+	}
+
+	techStacks, err := queryTechStacks(ctx, q, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	raises, err := queryRaises(ctx, q, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, entry := range entries {
+		entry.TechStack = techStacks[entry.ID]
+		entry.Raises = raises[entry.ID]
+	}
+	return entries, nil
+}
+
+func queryTechStacks(ctx context.Context, q pgQuerier, entryIDs []string) (map[primitive.ObjectID][]string, error) {
+	rows, err := q.Query(ctx,
+		`SELECT entry_id, tech FROM salary_entry_tech_stacks WHERE entry_id = ANY($1) ORDER BY entry_id, ordinal`,
+		entryIDs,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	techStacks := make(map[primitive.ObjectID][]string)
+	for rows.Next() {
+		var (
+			entryID primitive.ObjectID
+			tech    string
+		)
+		if err := rows.Scan(scanID(&entryID), &tech); err != nil {
+			return nil, err
+		}
+		techStacks[entryID] = append(techStacks[entryID], tech)
+	}
+	return techStacks, rows.Err()
+}
+
+func queryRaises(ctx context.Context, q pgQuerier, entryIDs []string) (map[primitive.ObjectID][]model.Raise, error) {
+	rows, err := q.Query(ctx,
+		`SELECT `+raiseColumns+` FROM raises WHERE entry_id = ANY($1) ORDER BY entry_id, raise_date, created_at, id`,
+		entryIDs,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	raises := make(map[primitive.ObjectID][]model.Raise)
+	for rows.Next() {
+		var (
+			entryID primitive.ObjectID
+			raise   model.Raise
+		)
+		err := rows.Scan(scanID(&raise.ID), scanID(&entryID), &raise.RaiseDate, &raise.NewSalary, &raise.Percentage, &raise.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		raises[entryID] = append(raises[entryID], raise)
+	}
+	return raises, rows.Err()
+}
@@ -0,0 +1,116 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/eminsonlu/salystic/internal/model"
+	"github.com/eminsonlu/salystic/pkg/database"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const sessionColumns = `id, user_id, user_agent, ip_hash, created_at, last_seen_at, expires_at, revoked_at`
+
+type postgresSessionRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewPostgresSessionRepository(db *database.Postgres) SessionRepository {
+	return &postgresSessionRepository{pool: db.Pool}
+}
+
+func (r *postgresSessionRepository) Create(ctx context.Context, session *model.Session) error {
+	if session.ID.IsZero() {
+		session.ID = primitive.NewObjectID()
+	}
+	session.CreatedAt = time.Now()
+	session.LastSeenAt = time.Now()
+
+	_, err := r.pool.Exec(ctx,
+		`INSERT INTO sessions (`+sessionColumns+`) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`,
+		session.ID.Hex(), session.UserID.Hex(), session.UserAgent, session.IPHash,
+		session.CreatedAt, session.LastSeenAt, session.ExpiresAt, session.RevokedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create session: %w", err)
+	}
+	return nil
+}
+
+func (r *postgresSessionRepository) GetByID(ctx context.Context, id primitive.ObjectID) (*model.Session, error) {
+	session, err := scanSession(r.pool.QueryRow(ctx, `SELECT `+sessionColumns+` FROM sessions WHERE id = $1`, id.Hex()))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get session: %w", err)
+	}
+	return session, nil
+}
+
+func (r *postgresSessionRepository) GetActiveByUserID(ctx context.Context, userID primitive.ObjectID) ([]*model.Session, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT `+sessionColumns+` FROM sessions
+		WHERE user_id = $1 AND revoked_at IS NULL AND expires_at > $2
+		ORDER BY last_seen_at DESC`,
+		userID.Hex(), time.Now(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find sessions: %w", err)
+	}
+	defer rows.Close()
+
+	sessions := []*model.Session{}
+	for rows.Next() {
+		session, err := scanSession(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode sessions: %w", err)
+		}
+		sessions = append(sessions, session)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to find sessions: %w", err)
+	}
+
+	return sessions, nil
+}
+
+func (r *postgresSessionRepository) Touch(ctx context.Context, id primitive.ObjectID) error {
+	if _, err := r.pool.Exec(ctx, `UPDATE sessions SET last_seen_at = $2 WHERE id = $1`, id.Hex(), time.Now()); err != nil {
+		return fmt.Errorf("failed to touch session: %w", err)
+	}
+	return nil
+}
+
+func (r *postgresSessionRepository) Revoke(ctx context.Context, id primitive.ObjectID, userID primitive.ObjectID) error {
+	tag, err := r.pool.Exec(ctx,
+		`UPDATE sessions SET revoked_at = $3 WHERE id = $1 AND user_id = $2 AND revoked_at IS NULL`,
+		id.Hex(), userID.Hex(), time.Now(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to revoke session: %w", err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("session not found")
+	}
+
+	return nil
+}
+
+func scanSession(row pgx.Row) (*model.Session, error) {
+	var session model.Session
+	err := row.Scan(
+		scanID(&session.ID), scanID(&session.UserID), &session.UserAgent, &session.IPHash,
+		&session.CreatedAt, &session.LastSeenAt, &session.ExpiresAt, &session.RevokedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
@@ -0,0 +1,41 @@
+package repo
+
+import (
+	"context"
+
+	"github.com/eminsonlu/salystic/pkg/database"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type pgTxKey struct{}
+
+// pgConn is a connection pool or a transaction.
+type pgConn interface {
+	pgQuerier
+	Begin(ctx context.Context) (pgx.Tx, error)
+}
+
+type postgresTransactor struct {
+	pool *pgxpool.Pool
+}
+
+func NewPostgresTransactor(db *database.Postgres) Transactor {
+	return &postgresTransactor{pool: db.Pool}
+}
+
+func (t *postgresTransactor) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return pgx.BeginFunc(ctx, t.pool, func(tx pgx.Tx) error {
+		return fn(context.WithValue(ctx, pgTxKey{}, tx))
+	})
+}
+
+// pgConnFor returns the transaction ctx runs in, or pool outside one.
+// Transactions begun on it become savepoints of the outer transaction.
+func pgConnFor(ctx context.Context, pool *pgxpool.Pool) pgConn {
+	if tx, ok := ctx.Value(pgTxKey{}).(pgx.Tx); ok {
+		return tx
+	}
+	return pool
+}
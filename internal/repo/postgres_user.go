@@ -0,0 +1,106 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/eminsonlu/salystic/internal/model"
+	"github.com/eminsonlu/salystic/pkg/database"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const userColumns = `id, pseudonymized_id, created_at, updated_at, last_login`
+
+type postgresUserRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewPostgresUserRepository(db *database.Postgres) UserRepository {
+	return &postgresUserRepository{pool: db.Pool}
+}
+
+func (r *postgresUserRepository) Create(ctx context.Context, user *model.User) error {
+	if user.ID.IsZero() {
+		user.ID = primitive.NewObjectID()
+	}
+	user.CreatedAt = time.Now()
+	user.UpdatedAt = time.Now()
+	user.LastLogin = time.Now()
+
+	_, err := r.pool.Exec(ctx,
+		`INSERT INTO users (`+userColumns+`) VALUES ($1, $2, $3, $4, $5)`,
+		user.ID.Hex(), user.PseudonymizedID, user.CreatedAt, user.UpdatedAt, user.LastLogin,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create user: %w", err)
+	}
+	return nil
+}
+
+func (r *postgresUserRepository) GetByPseudonymizedID(ctx context.Context, pseudonymizedID string) (*model.User, error) {
+	user, err := scanUser(r.pool.QueryRow(ctx, `SELECT `+userColumns+` FROM users WHERE pseudonymized_id = $1`, pseudonymizedID))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user by pseudonymized ID: %w", err)
+	}
+	return user, nil
+}
+
+func (r *postgresUserRepository) UpsertByPseudonymizedID(ctx context.Context, pseudonymizedID string) (*model.User, bool, error) {
+	now := time.Now()
+
+	var (
+		user    model.User
+		created bool
+	)
+	err := r.pool.QueryRow(ctx, `
+		INSERT INTO users (`+userColumns+`)
+		VALUES ($1, $2, $3, $3, $3)
+		ON CONFLICT (pseudonymized_id) DO UPDATE
+		SET last_login = EXCLUDED.last_login, updated_at = EXCLUDED.updated_at
+		RETURNING `+userColumns+`, xmax = 0`,
+		primitive.NewObjectID().Hex(), pseudonymizedID, now,
+	).Scan(scanID(&user.ID), &user.PseudonymizedID, &user.CreatedAt, &user.UpdatedAt, &user.LastLogin, &created)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to upsert user: %w", err)
+	}
+	return &user, created, nil
+}
+
+func (r *postgresUserRepository) GetByID(ctx context.Context, id primitive.ObjectID) (*model.User, error) {
+	user, err := scanUser(r.pool.QueryRow(ctx, `SELECT `+userColumns+` FROM users WHERE id = $1`, id.Hex()))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user by ID: %w", err)
+	}
+	return user, nil
+}
+
+func (r *postgresUserRepository) UpdateLastLogin(ctx context.Context, id primitive.ObjectID) error {
+	_, err := r.pool.Exec(ctx,
+		`UPDATE users SET last_login = $2, updated_at = $2 WHERE id = $1`,
+		id.Hex(), time.Now(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update last login: %w", err)
+	}
+	return nil
+}
+
+func scanUser(row pgx.Row) (*model.User, error) {
+	var user model.User
+	err := row.Scan(scanID(&user.ID), &user.PseudonymizedID, &user.CreatedAt, &user.UpdatedAt, &user.LastLogin)
+	if err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
@@ -0,0 +1,58 @@
+package repo
+
+import (
+	"log/slog"
+
+	"github.com/eminsonlu/salystic/pkg/database"
+)
+
+type Repositories struct {
+	Users          UserRepository
+	Sessions       SessionRepository
+	SalaryEntries  SalaryEntryRepository
+	Constants      ConstantsRepository
+	Analytics      AnalyticsRepository
+	APIKeys        APIKeyRepository
+	EntryRevisions EntryRevisionRepository
+	Transactor     Transactor
+}
+
+func NewMongoRepositories(db *database.MongoDB, logger *slog.Logger) *Repositories {
+	return &Repositories{
+		Users:          NewUserRepository(db),
+		Sessions:       NewSessionRepository(db),
+		SalaryEntries:  NewSalaryEntryRepository(db),
+		Constants:      NewConstantsRepository(db, logger),
+		Analytics:      NewAnalyticsRepo(db.Database),
+		APIKeys:        NewAPIKeyRepository(db),
+		EntryRevisions: NewEntryRevisionRepository(db),
+		Transactor:     NewMongoTransactor(db),
+	}
+}
+
+func NewMemoryRepositories(logger *slog.Logger) *Repositories {
+	store := NewMemoryStore()
+	return &Repositories{
+		Users:          NewMemoryUserRepository(store),
+		Sessions:       NewMemorySessionRepository(store),
+		SalaryEntries:  NewMemorySalaryEntryRepository(store),
+		Constants:      NewMemoryConstantsRepository(store, logger),
+		Analytics:      NewMemoryAnalyticsRepository(store),
+		APIKeys:        NewMemoryAPIKeyRepository(store),
+		EntryRevisions: NewMemoryEntryRevisionRepository(store),
+		Transactor:     NewMemoryTransactor(store),
+	}
+}
+
+func NewPostgresRepositories(db *database.Postgres, logger *slog.Logger) *Repositories {
+	return &Repositories{
+		Users:          NewPostgresUserRepository(db),
+		Sessions:       NewPostgresSessionRepository(db),
+		SalaryEntries:  NewPostgresSalaryEntryRepository(db),
+		Constants:      NewPostgresConstantsRepository(db, logger),
+		Analytics:      NewPostgresAnalyticsRepository(db),
+		APIKeys:        NewPostgresAPIKeyRepository(db),
+		EntryRevisions: NewPostgresEntryRevisionRepository(db),
+		Transactor:     NewPostgresTransactor(db),
+	}
+}
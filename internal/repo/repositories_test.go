@@ -0,0 +1,1040 @@
+package repo_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/eminsonlu/salystic/internal/migrations"
+	"github.com/eminsonlu/salystic/internal/model"
+	"github.com/eminsonlu/salystic/internal/repo"
+	"github.com/eminsonlu/salystic/pkg/database"
+	"github.com/eminsonlu/salystic/pkg/logging"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// The same suite runs against every storage backend. MongoDB and PostgreSQL
+// are only exercised when SALYSTIC_TEST_MONGO_URI or SALYSTIC_TEST_POSTGRES_URL
+// points at a server; each run works in a throwaway database or schema.
+
+func TestRepositories_Memory(t *testing.T) {
+	runRepositorySuite(t, func(t *testing.T) *repo.Repositories {
+		repos := repo.NewMemoryRepositories(logging.NewNop())
+		require.NoError(t, repos.Constants.SeedConstants(context.Background()))
+		return repos
+	})
+}
+
+func TestRepositories_Mongo(t *testing.T) {
+	uri := os.Getenv("SALYSTIC_TEST_MONGO_URI")
+	if uri == "" {
+		t.Skip("SALYSTIC_TEST_MONGO_URI is not set")
+	}
+
+	runRepositorySuite(t, func(t *testing.T) *repo.Repositories {
+		db, err := database.NewMongoDB(uri, fmt.Sprintf("salystic_test_%d", time.Now().UnixNano()), "", "")
+		require.NoError(t, err)
+		t.Cleanup(func() {
+			db.Database.Drop(context.Background())
+			db.Close()
+		})
+
+		migrator, err := migrations.NewMigrator(db, logging.NewNop(), time.Minute)
+		require.NoError(t, err)
+		_, err = migrator.Up(context.Background())
+		require.NoError(t, err)
+
+		return repo.NewMongoRepositories(db, logging.NewNop())
+	})
+}
+
+func TestRepositories_Postgres(t *testing.T) {
+	rawURL := os.Getenv("SALYSTIC_TEST_POSTGRES_URL")
+	if rawURL == "" {
+		t.Skip("SALYSTIC_TEST_POSTGRES_URL is not set")
+	}
+
+	runRepositorySuite(t, func(t *testing.T) *repo.Repositories {
+		ctx := context.Background()
+		schema := fmt.Sprintf("salystic_test_%d", time.Now().UnixNano())
+
+		admin, err := database.NewPostgres(rawURL)
+		require.NoError(t, err)
+		_, err = admin.Pool.Exec(ctx, "CREATE SCHEMA "+schema)
+		require.NoError(t, err)
+
+		schemaURL, err := url.Parse(rawURL)
+		require.NoError(t, err)
+		query := schemaURL.Query()
+		query.Set("search_path", schema)
+		schemaURL.RawQuery = query.Encode()
+
+		pg, err := database.NewPostgres(schemaURL.String())
+		require.NoError(t, err)
+		t.Cleanup(func() {
+			pg.Close()
+			admin.Pool.Exec(context.Background(), "DROP SCHEMA "+schema+" CASCADE")
+			admin.Close()
+		})
+
+		migrator, err := migrations.NewPostgresMigrator(pg, logging.NewNop(), time.Minute)
+		require.NoError(t, err)
+		_, err = migrator.Up(ctx)
+		require.NoError(t, err)
+
+		return repo.NewPostgresRepositories(pg, logging.NewNop())
+	})
+}
+
+func runRepositorySuite(t *testing.T, newRepos func(t *testing.T) *repo.Repositories) {
+	t.Run("Users", func(t *testing.T) { testUsers(t, newRepos(t)) })
+	t.Run("UsersConcurrentUpsert", func(t *testing.T) { testUsersConcurrentUpsert(t, newRepos(t)) })
+	t.Run("Sessions", func(t *testing.T) { testSessions(t, newRepos(t)) })
+	t.Run("APIKeys", func(t *testing.T) { testAPIKeys(t, newRepos(t)) })
+	t.Run("SalaryEntries", func(t *testing.T) { testSalaryEntries(t, newRepos(t)) })
+	t.Run("ListEntries", func(t *testing.T) { testListEntries(t, newRepos(t)) })
+	t.Run("ReplaceEntry", func(t *testing.T) { testReplaceEntry(t, newRepos(t)) })
+	t.Run("SoftDelete", func(t *testing.T) { testSoftDelete(t, newRepos(t)) })
+	t.Run("Raises", func(t *testing.T) { testRaises(t, newRepos(t)) })
+	t.Run("RaisesConcurrentSet", func(t *testing.T) { testRaisesConcurrentSet(t, newRepos(t)) })
+	t.Run("EntryRevisions", func(t *testing.T) { testEntryRevisions(t, newRepos(t)) })
+	t.Run("EntryRevisionsConcurrentCreate", func(t *testing.T) { testEntryRevisionsConcurrentCreate(t, newRepos(t)) })
+	t.Run("Transactions", func(t *testing.T) { testTransactions(t, newRepos(t)) })
+	t.Run("Constants", func(t *testing.T) { testConstants(t, newRepos(t)) })
+	t.Run("Analytics", func(t *testing.T) { testAnalytics(t, newRepos(t)) })
+	t.Run("AnalyticsSalaryBasis", func(t *testing.T) { testAnalyticsSalaryBasis(t, newRepos(t)) })
+	t.Run("JobChangeData", func(t *testing.T) { testJobChangeData(t, newRepos(t)) })
+}
+
+func testUsers(t *testing.T, repos *repo.Repositories) {
+	ctx := context.Background()
+
+	missing, err := repos.Users.GetByPseudonymizedID(ctx, "nobody")
+	require.NoError(t, err)
+	assert.Nil(t, missing)
+
+	created, isNew, err := repos.Users.UpsertByPseudonymizedID(ctx, "pseudo")
+	require.NoError(t, err)
+	assert.True(t, isNew)
+	assert.False(t, created.ID.IsZero())
+
+	again, isNew, err := repos.Users.UpsertByPseudonymizedID(ctx, "pseudo")
+	require.NoError(t, err)
+	assert.False(t, isNew)
+	assert.Equal(t, created.ID, again.ID)
+	assert.WithinDuration(t, created.CreatedAt, again.CreatedAt, time.Millisecond)
+
+	byID, err := repos.Users.GetByID(ctx, created.ID)
+	require.NoError(t, err)
+	require.NotNil(t, byID)
+	assert.Equal(t, "pseudo", byID.PseudonymizedID)
+
+	require.NoError(t, repos.Users.UpdateLastLogin(ctx, created.ID))
+
+	unknown, err := repos.Users.GetByID(ctx, primitive.NewObjectID())
+	require.NoError(t, err)
+	assert.Nil(t, unknown)
+}
+
+func testUsersConcurrentUpsert(t *testing.T, repos *repo.Repositories) {
+	ctx := context.Background()
+
+	const workers = 20
+	var (
+		wg      sync.WaitGroup
+		created atomic.Int32
+		ids     = make([]primitive.ObjectID, workers)
+		errs    = make([]error, workers)
+	)
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			user, isNew, err := repos.Users.UpsertByPseudonymizedID(ctx, "concurrent_pseudo_id")
+			errs[i] = err
+			if err == nil {
+				ids[i] = user.ID
+				if isNew {
+					created.Add(1)
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	for i := 0; i < workers; i++ {
+		require.NoError(t, errs[i])
+		assert.Equal(t, ids[0], ids[i])
+	}
+	assert.Equal(t, int32(1), created.Load())
+
+	stored, err := repos.Users.GetByPseudonymizedID(ctx, "concurrent_pseudo_id")
+	require.NoError(t, err)
+	assert.Equal(t, ids[0], stored.ID)
+}
+
+func testSessions(t *testing.T, repos *repo.Repositories) {
+	ctx := context.Background()
+
+	user, _, err := repos.Users.UpsertByPseudonymizedID(ctx, "session_user")
+	require.NoError(t, err)
+
+	active := &model.Session{UserID: user.ID, UserAgent: "test", ExpiresAt: time.Now().Add(time.Hour)}
+	expired := &model.Session{UserID: user.ID, ExpiresAt: time.Now().Add(-time.Hour)}
+	revoked := &model.Session{UserID: user.ID, ExpiresAt: time.Now().Add(time.Hour)}
+	for _, session := range []*model.Session{active, expired, revoked} {
+		require.NoError(t, repos.Sessions.Create(ctx, session))
+	}
+
+	require.NoError(t, repos.Sessions.Revoke(ctx, revoked.ID, user.ID))
+	assert.EqualError(t, repos.Sessions.Revoke(ctx, revoked.ID, user.ID), "session not found")
+	assert.EqualError(t, repos.Sessions.Revoke(ctx, active.ID, primitive.NewObjectID()), "session not found")
+
+	sessions, err := repos.Sessions.GetActiveByUserID(ctx, user.ID)
+	require.NoError(t, err)
+	require.Len(t, sessions, 1)
+	assert.Equal(t, active.ID, sessions[0].ID)
+	assert.Equal(t, "test", sessions[0].UserAgent)
+
+	require.NoError(t, repos.Sessions.Touch(ctx, active.ID))
+
+	stored, err := repos.Sessions.GetByID(ctx, revoked.ID)
+	require.NoError(t, err)
+	require.NotNil(t, stored)
+	assert.NotNil(t, stored.RevokedAt)
+
+	missing, err := repos.Sessions.GetByID(ctx, primitive.NewObjectID())
+	require.NoError(t, err)
+	assert.Nil(t, missing)
+}
+
+func testAPIKeys(t *testing.T, repos *repo.Repositories) {
+	ctx := context.Background()
+
+	key := &model.APIKey{
+		Name:      "partner",
+		Prefix:    "sk_abc",
+		KeyHash:   "hash",
+		Scopes:    []string{model.ScopeReadAnalytics, model.ScopeExport},
+		RateLimit: 100,
+		CreatedBy: "admin",
+	}
+	require.NoError(t, repos.APIKeys.Create(ctx, key))
+	assert.False(t, key.ID.IsZero())
+
+	usedAt := time.Now().Truncate(time.Millisecond)
+	require.NoError(t, repos.APIKeys.RecordUsage(ctx, key.ID, 3, usedAt))
+	require.NoError(t, repos.APIKeys.RecordUsage(ctx, key.ID, 2, usedAt.Add(-time.Minute)))
+
+	stored, err := repos.APIKeys.GetByHash(ctx, "hash")
+	require.NoError(t, err)
+	require.NotNil(t, stored)
+	assert.Equal(t, key.ID, stored.ID)
+	assert.Equal(t, []string{model.ScopeReadAnalytics, model.ScopeExport}, stored.Scopes)
+	assert.Equal(t, int64(5), stored.UsageCount)
+	require.NotNil(t, stored.LastUsedAt)
+	assert.True(t, usedAt.Equal(*stored.LastUsedAt))
+
+	missing, err := repos.APIKeys.GetByHash(ctx, "unknown")
+	require.NoError(t, err)
+	assert.Nil(t, missing)
+
+	keys, err := repos.APIKeys.List(ctx)
+	require.NoError(t, err)
+	assert.Len(t, keys, 1)
+
+	require.NoError(t, repos.APIKeys.Revoke(ctx, key.ID))
+	assert.EqualError(t, repos.APIKeys.Revoke(ctx, key.ID), "api key not found")
+}
+
+func testSalaryEntries(t *testing.T, repos *repo.Repositories) {
+	ctx := context.Background()
+	userID := primitive.NewObjectID()
+	salaryMax := int64(150000)
+
+	first := &model.SalaryEntry{
+		UserID:      userID,
+		Level:       "Senior",
+		Position:    "Backend Developer",
+		TechStack:   []string{"Go", "PostgreSQL", "Docker"},
+		Currency:    "TRY",
+		SalaryBasis: model.SalaryBasisGrossMonthly,
+		SalaryMin:   100000,
+		SalaryMax:   &salaryMax,
+		RaisePeriod: 2,
+		StartTime:   time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC),
+		Concurrent:  true,
+		Compensation: &model.Compensation{
+			Bonus:         &model.Bonus{Months: 2},
+			Equity:        &model.EquityGrant{Type: model.EquityTypeRSU, GrantValue: 480000, VestingMonths: 48, CliffMonths: 12},
+			MealCard:      5000,
+			OtherBenefits: []model.Benefit{{Name: "Gym", MonthlyValue: 1000}},
+		},
+	}
+	require.NoError(t, repos.SalaryEntries.Create(ctx, first))
+	require.False(t, first.ID.IsZero())
+
+	time.Sleep(5 * time.Millisecond)
+	second := &model.SalaryEntry{UserID: userID, Level: "Middle", Currency: "USD", SalaryMin: 5000, StartTime: time.Now()}
+	require.NoError(t, repos.SalaryEntries.Create(ctx, second))
+
+	stored, err := repos.SalaryEntries.GetByID(ctx, first.ID, userID)
+	require.NoError(t, err)
+	require.NotNil(t, stored)
+	assert.Equal(t, []string{"Go", "PostgreSQL", "Docker"}, stored.TechStack)
+	assert.Equal(t, int64(100000), stored.SalaryMin)
+	assert.Equal(t, model.SalaryBasisGrossMonthly, stored.SalaryBasis)
+	require.NotNil(t, stored.SalaryMax)
+	assert.Equal(t, salaryMax, *stored.SalaryMax)
+	assert.True(t, stored.StartTime.Equal(first.StartTime))
+	assert.Nil(t, stored.EndTime)
+	assert.True(t, stored.Concurrent)
+	assert.Equal(t, first.Compensation, stored.Compensation)
+
+	otherUser, err := repos.SalaryEntries.GetByID(ctx, first.ID, primitive.NewObjectID())
+	require.NoError(t, err)
+	assert.Nil(t, otherUser)
+
+	entries, err := repos.SalaryEntries.GetByUserID(ctx, userID)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, second.ID, entries[0].ID)
+	assert.Equal(t, first.ID, entries[1].ID)
+
+	level := "Staff"
+	endTime := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	concurrent := false
+	reason := model.ChangeReasonCareerGrowth
+	salaryBasis := model.SalaryBasisNetAnnual
+	compensation := &model.Compensation{
+		Bonus:           &model.Bonus{Amount: 60000},
+		HealthInsurance: &model.HealthInsurance{CoversFamily: true, MonthlyValue: 2000},
+	}
+	updated, err := repos.SalaryEntries.Update(ctx, first.ID, userID, &model.UpdateSalaryEntryRequest{
+		Level:        &level,
+		TechStack:    []string{"Rust"},
+		EndTime:      &endTime,
+		Concurrent:   &concurrent,
+		ChangeReason: &reason,
+		SalaryBasis:  &salaryBasis,
+		Compensation: compensation,
+	})
+	require.NoError(t, err)
+	require.NotNil(t, updated)
+	assert.Equal(t, "Staff", updated.Level)
+	assert.Equal(t, "Backend Developer", updated.Position)
+	assert.Equal(t, []string{"Rust"}, updated.TechStack)
+	require.NotNil(t, updated.EndTime)
+	assert.True(t, updated.EndTime.Equal(endTime))
+	assert.False(t, updated.Concurrent)
+	assert.Equal(t, model.ChangeReasonCareerGrowth, updated.ChangeReason)
+	assert.Equal(t, model.SalaryBasisNetAnnual, updated.SalaryBasis)
+	assert.Equal(t, compensation, updated.Compensation)
+
+	notFound, err := repos.SalaryEntries.Update(ctx, first.ID, primitive.NewObjectID(), &model.UpdateSalaryEntryRequest{Level: &level})
+	require.NoError(t, err)
+	assert.Nil(t, notFound)
+
+	assert.EqualError(t, repos.SalaryEntries.Delete(ctx, first.ID, primitive.NewObjectID()), "salary entry not found")
+	require.NoError(t, repos.SalaryEntries.Delete(ctx, first.ID, userID))
+	assert.EqualError(t, repos.SalaryEntries.Delete(ctx, first.ID, userID), "salary entry not found")
+
+	entries, err = repos.SalaryEntries.GetByUserID(ctx, userID)
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+}
+
+func testSoftDelete(t *testing.T, repos *repo.Repositories) {
+	ctx := context.Background()
+	userID := primitive.NewObjectID()
+
+	kept := &model.SalaryEntry{UserID: userID, Position: "Backend Developer", Currency: "TRY", SalaryMin: 1000, StartTime: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)}
+	deleted := &model.SalaryEntry{UserID: userID, Position: "Frontend Developer", Currency: "USD", SalaryMin: 3000, StartTime: time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)}
+	require.NoError(t, repos.SalaryEntries.Create(ctx, kept))
+	require.NoError(t, repos.SalaryEntries.Create(ctx, deleted))
+	require.NoError(t, repos.SalaryEntries.SetRaises(ctx, deleted.ID, userID, []model.Raise{{NewSalary: 3300, RaiseDate: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)}}, 0))
+
+	notDeleted, err := repos.SalaryEntries.GetDeletedByID(ctx, deleted.ID, userID)
+	require.NoError(t, err)
+	assert.Nil(t, notDeleted)
+	assert.EqualError(t, repos.SalaryEntries.Undelete(ctx, deleted.ID, userID), "salary entry not found")
+
+	require.NoError(t, repos.SalaryEntries.Delete(ctx, deleted.ID, userID))
+
+	entry, err := repos.SalaryEntries.GetByID(ctx, deleted.ID, userID)
+	require.NoError(t, err)
+	assert.Nil(t, entry)
+
+	entries, err := repos.SalaryEntries.GetByUserID(ctx, userID)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, kept.ID, entries[0].ID)
+
+	entries, err = repos.SalaryEntries.ListByUserID(ctx, userID, &repo.SalaryEntryListOptions{Limit: 10})
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+
+	level := "Senior"
+	updated, err := repos.SalaryEntries.Update(ctx, deleted.ID, userID, &model.UpdateSalaryEntryRequest{Level: &level})
+	require.NoError(t, err)
+	assert.Nil(t, updated)
+	_, err = repos.SalaryEntries.GetRaises(ctx, deleted.ID, userID)
+	assert.EqualError(t, err, "salary entry not found")
+
+	total, err := repos.Analytics.GetTotalEntries(ctx, nil)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	counts, err := repos.Analytics.CountEntriesByCurrency(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int64{"TRY": 1}, counts)
+	positions, err := repos.Analytics.GetAvailablePositions(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Backend Developer"}, positions)
+	raiseData, err := repos.Analytics.GetRaiseData(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, raiseData)
+
+	deletedEntries, err := repos.SalaryEntries.ListDeletedByUserID(ctx, userID)
+	require.NoError(t, err)
+	require.Len(t, deletedEntries, 1)
+	assert.Equal(t, deleted.ID, deletedEntries[0].ID)
+	require.NotNil(t, deletedEntries[0].DeletedAt)
+	require.Len(t, deletedEntries[0].Raises, 1)
+
+	found, err := repos.SalaryEntries.GetDeletedByID(ctx, deleted.ID, userID)
+	require.NoError(t, err)
+	require.NotNil(t, found)
+	assert.Equal(t, "Frontend Developer", found.Position)
+	missing, err := repos.SalaryEntries.GetDeletedByID(ctx, deleted.ID, primitive.NewObjectID())
+	require.NoError(t, err)
+	assert.Nil(t, missing)
+
+	require.NoError(t, repos.SalaryEntries.Undelete(ctx, deleted.ID, userID))
+	entry, err = repos.SalaryEntries.GetByID(ctx, deleted.ID, userID)
+	require.NoError(t, err)
+	require.NotNil(t, entry)
+	assert.Nil(t, entry.DeletedAt)
+	require.Len(t, entry.Raises, 1)
+
+	require.NoError(t, repos.SalaryEntries.Delete(ctx, deleted.ID, userID))
+
+	purged, err := repos.SalaryEntries.PurgeDeleted(ctx, time.Now().Add(-time.Hour))
+	require.NoError(t, err)
+	assert.Empty(t, purged)
+
+	purged, err = repos.SalaryEntries.PurgeDeleted(ctx, time.Now().Add(time.Second))
+	require.NoError(t, err)
+	assert.Equal(t, []primitive.ObjectID{deleted.ID}, purged)
+
+	found, err = repos.SalaryEntries.GetDeletedByID(ctx, deleted.ID, userID)
+	require.NoError(t, err)
+	assert.Nil(t, found)
+
+	entry, err = repos.SalaryEntries.GetByID(ctx, kept.ID, userID)
+	require.NoError(t, err)
+	assert.NotNil(t, entry)
+}
+
+func testReplaceEntry(t *testing.T, repos *repo.Repositories) {
+	ctx := context.Background()
+	userID := primitive.NewObjectID()
+
+	salaryMax := int64(2000)
+	endTime := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	entry := &model.SalaryEntry{
+		UserID: userID, Level: "Senior", TechStack: []string{"Go"}, Currency: "TRY", SalaryMin: 1000, SalaryMax: &salaryMax,
+		StartTime: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), EndTime: &endTime,
+		Compensation: &model.Compensation{MealCard: 3000},
+	}
+	require.NoError(t, repos.SalaryEntries.Create(ctx, entry))
+	require.NoError(t, repos.SalaryEntries.SetRaises(ctx, entry.ID, userID, []model.Raise{{NewSalary: 1200, RaiseDate: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)}}, 0))
+
+	replacement := &model.SalaryEntry{
+		ID: entry.ID, UserID: userID, Level: "Staff", TechStack: []string{"Rust", "Go"}, Currency: "USD", SalaryMin: 50,
+		StartTime: entry.StartTime, CreatedAt: entry.CreatedAt,
+		Raises: []model.Raise{{ID: primitive.NewObjectID(), NewSalary: 60, Percentage: 20, RaiseDate: time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC), CreatedAt: time.Now()}},
+	}
+	assert.EqualError(t, repos.SalaryEntries.Replace(ctx, replacement), "salary entry was modified concurrently")
+
+	replacement.RaisesVersion = 1
+	require.NoError(t, repos.SalaryEntries.Replace(ctx, replacement))
+	assert.Equal(t, 2, replacement.RaisesVersion)
+
+	stored, err := repos.SalaryEntries.GetByID(ctx, entry.ID, userID)
+	require.NoError(t, err)
+	require.NotNil(t, stored)
+	assert.Equal(t, "Staff", stored.Level)
+	assert.Equal(t, []string{"Rust", "Go"}, stored.TechStack)
+	assert.Equal(t, "USD", stored.Currency)
+	assert.Nil(t, stored.SalaryMax)
+	assert.Nil(t, stored.EndTime)
+	assert.Nil(t, stored.Compensation)
+	require.Len(t, stored.Raises, 1)
+	assert.Equal(t, replacement.Raises[0].ID, stored.Raises[0].ID)
+	assert.True(t, stored.CreatedAt.Equal(entry.CreatedAt))
+
+	replacement.UserID = primitive.NewObjectID()
+	assert.EqualError(t, repos.SalaryEntries.Replace(ctx, replacement), "salary entry not found")
+}
+
+func testListEntries(t *testing.T, repos *repo.Repositories) {
+	ctx := context.Background()
+	userID := primitive.NewObjectID()
+	ended := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	var created []*model.SalaryEntry
+	for i, salary := range []int64{300, 100, 200, 200, 400} {
+		entry := &model.SalaryEntry{
+			UserID:    userID,
+			Currency:  "TRY",
+			SalaryMin: salary,
+			StartTime: time.Date(2020+i, 1, 1, 0, 0, 0, 0, time.UTC),
+		}
+		if i < 2 {
+			entry.EndTime = &ended
+		}
+		if i == 4 {
+			entry.Currency = "USD"
+		}
+		require.NoError(t, repos.SalaryEntries.Create(ctx, entry))
+		created = append(created, entry)
+		time.Sleep(2 * time.Millisecond)
+	}
+	require.NoError(t, repos.SalaryEntries.Create(ctx, &model.SalaryEntry{UserID: primitive.NewObjectID(), Currency: "TRY", SalaryMin: 1}))
+
+	collect := func(opts repo.SalaryEntryListOptions) []primitive.ObjectID {
+		var ids []primitive.ObjectID
+		for {
+			page, err := repos.SalaryEntries.ListByUserID(ctx, userID, &opts)
+			require.NoError(t, err)
+			for _, entry := range page {
+				ids = append(ids, entry.ID)
+			}
+			if len(page) < opts.Limit {
+				return ids
+			}
+			opts.After = repo.NewSalaryEntryCursor(page[len(page)-1])
+		}
+	}
+
+	assert.Equal(t,
+		[]primitive.ObjectID{created[4].ID, created[3].ID, created[2].ID, created[1].ID, created[0].ID},
+		collect(repo.SalaryEntryListOptions{SortBy: model.EntrySortCreatedAt, Limit: 2}))
+
+	assert.Equal(t,
+		[]primitive.ObjectID{created[0].ID, created[1].ID, created[2].ID, created[3].ID, created[4].ID},
+		collect(repo.SalaryEntryListOptions{SortBy: model.EntrySortStartTime, Ascending: true, Limit: 3}))
+
+	tied := []primitive.ObjectID{created[2].ID, created[3].ID}
+	if created[3].ID.Hex() < created[2].ID.Hex() {
+		tied = []primitive.ObjectID{created[3].ID, created[2].ID}
+	}
+	assert.Equal(t,
+		[]primitive.ObjectID{created[1].ID, tied[0], tied[1], created[0].ID, created[4].ID},
+		collect(repo.SalaryEntryListOptions{SortBy: model.EntrySortSalary, Ascending: true, Limit: 2}))
+
+	current := true
+	assert.Equal(t,
+		[]primitive.ObjectID{created[2].ID, created[3].ID},
+		collect(repo.SalaryEntryListOptions{SortBy: model.EntrySortStartTime, Ascending: true, Currency: "TRY", Current: &current, Limit: 10}))
+
+	past := false
+	assert.Equal(t,
+		[]primitive.ObjectID{created[1].ID, created[0].ID},
+		collect(repo.SalaryEntryListOptions{SortBy: model.EntrySortStartTime, Current: &past, Limit: 1}))
+}
+
+func testRaises(t *testing.T, repos *repo.Repositories) {
+	ctx := context.Background()
+	userID := primitive.NewObjectID()
+
+	entry := &model.SalaryEntry{UserID: userID, Currency: "TRY", SalaryMin: 100000, StartTime: time.Now().AddDate(-2, 0, 0)}
+	require.NoError(t, repos.SalaryEntries.Create(ctx, entry))
+
+	raises, err := repos.SalaryEntries.GetRaises(ctx, entry.ID, userID)
+	require.NoError(t, err)
+	assert.Empty(t, raises)
+
+	require.NoError(t, repos.SalaryEntries.SetRaises(ctx, entry.ID, userID, []model.Raise{
+		{RaiseDate: time.Now().AddDate(-1, 0, 0), NewSalary: 120000, Percentage: 20},
+		{RaiseDate: time.Now(), NewSalary: 132000, Percentage: 10},
+	}, 0))
+
+	raises, err = repos.SalaryEntries.GetRaises(ctx, entry.ID, userID)
+	require.NoError(t, err)
+	require.Len(t, raises, 2)
+	assert.Equal(t, int64(120000), raises[0].NewSalary)
+	assert.Equal(t, int64(132000), raises[1].NewSalary)
+	assert.Equal(t, 10.0, raises[1].Percentage)
+	assert.False(t, raises[0].ID.IsZero())
+	assert.False(t, raises[0].CreatedAt.IsZero())
+
+	stored, err := repos.SalaryEntries.GetByID(ctx, entry.ID, userID)
+	require.NoError(t, err)
+	assert.Len(t, stored.Raises, 2)
+	assert.Equal(t, 1, stored.RaisesVersion)
+
+	// Replacing keeps the IDs of existing raises and drops the missing ones.
+	kept := raises[1]
+	kept.NewSalary = 135000
+	assert.EqualError(t, repos.SalaryEntries.SetRaises(ctx, entry.ID, userID, []model.Raise{kept}, 0), "salary entry was modified concurrently")
+	require.NoError(t, repos.SalaryEntries.SetRaises(ctx, entry.ID, userID, []model.Raise{kept}, 1))
+
+	raises, err = repos.SalaryEntries.GetRaises(ctx, entry.ID, userID)
+	require.NoError(t, err)
+	require.Len(t, raises, 1)
+	assert.Equal(t, kept.ID, raises[0].ID)
+	assert.Equal(t, int64(135000), raises[0].NewSalary)
+
+	assert.EqualError(t, repos.SalaryEntries.SetRaises(ctx, entry.ID, primitive.NewObjectID(), []model.Raise{{NewSalary: 1}}, 2), "salary entry not found")
+
+	salaryMin := int64(110000)
+	updated, err := repos.SalaryEntries.Update(ctx, entry.ID, userID, &model.UpdateSalaryEntryRequest{SalaryMin: &salaryMin})
+	require.NoError(t, err)
+	assert.Equal(t, 3, updated.RaisesVersion)
+
+	_, err = repos.SalaryEntries.GetRaises(ctx, primitive.NewObjectID(), userID)
+	assert.EqualError(t, err, "salary entry not found")
+}
+
+func testRaisesConcurrentSet(t *testing.T, repos *repo.Repositories) {
+	ctx := context.Background()
+	userID := primitive.NewObjectID()
+
+	entry := &model.SalaryEntry{UserID: userID, Currency: "TRY", SalaryMin: 1000, StartTime: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)}
+	require.NoError(t, repos.SalaryEntries.Create(ctx, entry))
+
+	// Every writer read the entry at version 0, so only one may replace its
+	// raises.
+	const workers = 8
+	var (
+		wg   sync.WaitGroup
+		errs = make([]error, workers)
+	)
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs[i] = repos.SalaryEntries.SetRaises(ctx, entry.ID, userID, []model.Raise{
+				{NewSalary: int64(1100 + i), RaiseDate: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)},
+			}, 0)
+		}(i)
+	}
+	wg.Wait()
+
+	applied := -1
+	for i, err := range errs {
+		if err == nil {
+			assert.Equal(t, -1, applied, "more than one concurrent write applied")
+			applied = i
+			continue
+		}
+		assert.EqualError(t, err, "salary entry was modified concurrently")
+	}
+	require.NotEqual(t, -1, applied)
+
+	stored, err := repos.SalaryEntries.GetByID(ctx, entry.ID, userID)
+	require.NoError(t, err)
+	require.Len(t, stored.Raises, 1)
+	assert.Equal(t, int64(1100+applied), stored.Raises[0].NewSalary)
+	assert.Equal(t, 1, stored.RaisesVersion)
+}
+
+func testConstants(t *testing.T, repos *repo.Repositories) {
+	ctx := context.Background()
+
+	require.NoError(t, repos.Constants.SeedConstants(ctx))
+
+	levels, err := repos.Constants.GetLevels(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Junior", "Middle", "Senior"}, levels)
+
+	currencies, err := repos.Constants.GetCurrencies(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"TRY", "USD", "EUR", "GBP"}, currencies)
+
+	positions, err := repos.Constants.GetPositions(ctx)
+	require.NoError(t, err)
+	assert.NotEmpty(t, positions)
+}
+
+func testAnalytics(t *testing.T, repos *repo.Repositories) {
+	ctx := context.Background()
+	userID := primitive.NewObjectID()
+
+	for _, entry := range []*model.SalaryEntry{
+		{Position: "Backend", Level: "Senior", City: "Istanbul", Currency: "TRY", TechStack: []string{"Go", "PostgreSQL"}, SalaryMin: 100},
+		{Position: "Backend", Level: "Junior", City: "Ankara", Currency: "TRY", TechStack: []string{"Go"}, SalaryMin: 50},
+		{Position: "Frontend", Level: "Senior", City: "Istanbul", Currency: "TRY", TechStack: []string{"React"}, SalaryMin: 80},
+		{Position: "Frontend", Level: "Middle", Currency: "USD", SalaryMin: 20},
+		{Position: "", Level: "Senior", Currency: "TRY", SalaryMin: 10},
+	} {
+		entry.UserID = userID
+		entry.StartTime = time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+		require.NoError(t, repos.SalaryEntries.Create(ctx, entry))
+	}
+
+	total, err := repos.Analytics.GetTotalEntries(ctx, &repo.AnalyticsFilter{Currency: "TRY"})
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), total)
+
+	byCurrency, err := repos.Analytics.CountEntriesByCurrency(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int64{"TRY": 4, "USD": 1}, byCurrency)
+
+	byPosition, err := repos.Analytics.GetAverageSalaryByPosition(ctx, &repo.AnalyticsFilter{Currency: "TRY"})
+	require.NoError(t, err)
+	assert.Equal(t, []model.SalaryByCategory{
+		{Category: "Backend", Average: 75, Min: 50, Max: 100, Count: 2},
+		{Category: "Frontend", Average: 80, Min: 80, Max: 80, Count: 1},
+	}, byPosition)
+
+	byCity, err := repos.Analytics.GetAverageSalaryByCity(ctx, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []model.SalaryByCategory{
+		{Category: "Ankara", Average: 50, Min: 50, Max: 50, Count: 1},
+		{Category: "Istanbul", Average: 90, Min: 80, Max: 100, Count: 2},
+	}, byCity)
+
+	byTech, err := repos.Analytics.GetAverageSalaryByTech(ctx, &repo.AnalyticsFilter{Position: "Backend"})
+	require.NoError(t, err)
+	assert.Equal(t, []model.SalaryByTech{
+		{Tech: "Go", Average: 75, Min: 50, Max: 100, Count: 2},
+		{Tech: "PostgreSQL", Average: 100, Min: 100, Max: 100, Count: 1},
+	}, byTech)
+
+	average, err := repos.Analytics.GetOverallAverageSalary(ctx, &repo.AnalyticsFilter{Level: "Senior"})
+	require.NoError(t, err)
+	assert.Equal(t, float64(190)/3, average)
+
+	percentiles, err := repos.Analytics.GetSalaryPercentiles(ctx, &repo.AnalyticsFilter{Currency: "TRY"})
+	require.NoError(t, err)
+	assert.InDelta(t, 40, percentiles.P25, 1e-9)
+	assert.InDelta(t, 65, percentiles.P50, 1e-9)
+	assert.InDelta(t, 85, percentiles.P75, 1e-9)
+	assert.InDelta(t, 94, percentiles.P90, 1e-9)
+
+	emptyPercentiles, err := repos.Analytics.GetSalaryPercentiles(ctx, &repo.AnalyticsFilter{Currency: "EUR"})
+	require.NoError(t, err)
+	assert.Equal(t, model.SalaryPercentiles{}, *emptyPercentiles)
+
+	byPositionAndLevel, err := repos.Analytics.GetAverageSalaryByPositionAndLevel(ctx, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []model.SalaryByPositionLevel{
+		{Position: "Backend", Level: "Junior", Average: 50, Min: 50, Max: 50, Count: 1},
+		{Position: "Backend", Level: "Senior", Average: 100, Min: 100, Max: 100, Count: 1},
+		{Position: "Frontend", Level: "Middle", Average: 20, Min: 20, Max: 20, Count: 1},
+		{Position: "Frontend", Level: "Senior", Average: 80, Min: 80, Max: 80, Count: 1},
+	}, byPositionAndLevel)
+
+	percentilesByPosition, err := repos.Analytics.GetSalaryPercentilesByPosition(ctx, &repo.AnalyticsFilter{Currency: "TRY"})
+	require.NoError(t, err)
+	require.Len(t, percentilesByPosition, 2)
+	assert.InDelta(t, 75, percentilesByPosition["Backend"].P50, 1e-9)
+	assert.InDelta(t, 95, percentilesByPosition["Backend"].P90, 1e-9)
+	assert.Equal(t, model.SalaryPercentiles{P25: 80, P50: 80, P75: 80, P90: 80}, percentilesByPosition["Frontend"])
+
+	combined, err := repos.Analytics.GetCombinedAnalytics(ctx, &repo.AnalyticsFilter{Currency: "TRY"})
+	require.NoError(t, err)
+	assert.Equal(t, []repo.TotalCount{{Total: 4}}, combined.TotalCount)
+	assert.Equal(t, []repo.OverallAverage{{Average: 60}}, combined.OverallAverage)
+	assert.Equal(t, byPosition, combined.ByPosition)
+	assert.Len(t, combined.ByLevel, 2)
+	assert.Len(t, combined.ByCurrency, 1)
+
+	empty, err := repos.Analytics.GetCombinedAnalytics(ctx, &repo.AnalyticsFilter{Currency: "EUR"})
+	require.NoError(t, err)
+	assert.Empty(t, empty.TotalCount)
+	assert.Empty(t, empty.OverallAverage)
+	assert.Empty(t, empty.ByPosition)
+
+	positions, err := repos.Analytics.GetAvailablePositions(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Backend", "Frontend"}, positions)
+
+	levels, err := repos.Analytics.GetAvailableLevels(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Junior", "Middle", "Senior"}, levels)
+
+	raiseData, err := repos.Analytics.GetRaiseData(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, raiseData)
+
+	endTime := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	withRaise := &model.SalaryEntry{
+		UserID: userID, Level: "Senior", Company: "Fintech", CompanySize: "51 - 100 Kişi", Currency: "TRY",
+		SalaryBasis: model.SalaryBasisGrossAnnual, SalaryMin: 1000,
+		StartTime: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), EndTime: &endTime,
+		Compensation: &model.Compensation{MealCard: 3000},
+	}
+	require.NoError(t, repos.SalaryEntries.Create(ctx, withRaise))
+	require.NoError(t, repos.SalaryEntries.SetRaises(ctx, withRaise.ID, userID, []model.Raise{{NewSalary: 1200, Percentage: 20, RaiseDate: time.Now()}}, 0))
+
+	raiseData, err = repos.Analytics.GetRaiseData(ctx)
+	require.NoError(t, err)
+	require.Len(t, raiseData, 1)
+	assert.Equal(t, withRaise.ID.Hex(), raiseData[0].EntryID)
+	assert.True(t, raiseData[0].StartTime.Equal(withRaise.StartTime))
+	require.NotNil(t, raiseData[0].EndTime)
+	assert.True(t, raiseData[0].EndTime.Equal(endTime))
+	assert.Equal(t, "Senior", raiseData[0].Level)
+	assert.Equal(t, "Fintech", raiseData[0].Company)
+	assert.Equal(t, "51 - 100 Kişi", raiseData[0].CompanySize)
+	assert.Equal(t, int64(1000), raiseData[0].SalaryMin)
+	require.Len(t, raiseData[0].Raises, 1)
+
+	compensationData, err := repos.Analytics.GetCompensationData(ctx)
+	require.NoError(t, err)
+	var withCompensation []model.CompensationData
+	for _, data := range compensationData {
+		if data.Compensation != nil {
+			withCompensation = append(withCompensation, data)
+		}
+	}
+	require.Len(t, withCompensation, 1)
+	assert.Equal(t, "Senior", withCompensation[0].Level)
+	assert.Equal(t, int64(1000), withCompensation[0].SalaryMin)
+	assert.Equal(t, model.SalaryBasisGrossAnnual, withCompensation[0].SalaryBasis)
+	assert.True(t, withCompensation[0].StartTime.Equal(withRaise.StartTime))
+	assert.Equal(t, int64(3000), withCompensation[0].Compensation.MealCard)
+	require.Len(t, withCompensation[0].Raises, 1)
+}
+
+func testAnalyticsSalaryBasis(t *testing.T, repos *repo.Repositories) {
+	ctx := context.Background()
+	userID := primitive.NewObjectID()
+
+	for _, entry := range []*model.SalaryEntry{
+		{Position: "Backend", SalaryBasis: model.SalaryBasisNetMonthly, TechStack: []string{"Go"}, SalaryMin: 100},
+		{Position: "Backend", TechStack: []string{"Go"}, SalaryMin: 50},
+		{Position: "Backend", SalaryBasis: model.SalaryBasisGrossAnnual, TechStack: []string{"Go"}, SalaryMin: 3000},
+	} {
+		entry.UserID = userID
+		entry.Currency = "TRY"
+		entry.StartTime = time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+		require.NoError(t, repos.SalaryEntries.Create(ctx, entry))
+	}
+
+	// Entries without a basis count as net monthly.
+	netMonthly := &repo.AnalyticsFilter{SalaryBasis: model.SalaryBasisNetMonthly}
+
+	total, err := repos.Analytics.GetTotalEntries(ctx, netMonthly)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), total)
+
+	average, err := repos.Analytics.GetOverallAverageSalary(ctx, netMonthly)
+	require.NoError(t, err)
+	assert.Equal(t, float64(75), average)
+
+	byTech, err := repos.Analytics.GetAverageSalaryByTech(ctx, netMonthly)
+	require.NoError(t, err)
+	assert.Equal(t, []model.SalaryByTech{{Tech: "Go", Average: 75, Min: 50, Max: 100, Count: 2}}, byTech)
+
+	percentiles, err := repos.Analytics.GetSalaryPercentiles(ctx, netMonthly)
+	require.NoError(t, err)
+	assert.InDelta(t, 95, percentiles.P90, 1e-9)
+
+	combined, err := repos.Analytics.GetCombinedAnalytics(ctx, &repo.AnalyticsFilter{SalaryBasis: model.SalaryBasisGrossAnnual})
+	require.NoError(t, err)
+	require.Len(t, combined.TotalCount, 1)
+	assert.Equal(t, int64(1), combined.TotalCount[0].Total)
+	require.Len(t, combined.OverallAverage, 1)
+	assert.Equal(t, float64(3000), combined.OverallAverage[0].Average)
+}
+
+func testJobChangeData(t *testing.T, repos *repo.Repositories) {
+	ctx := context.Background()
+	alice, bob := primitive.NewObjectID(), primitive.NewObjectID()
+
+	for _, entry := range []*model.SalaryEntry{
+		{UserID: alice, Position: "Backend", Level: "Senior", Currency: "TRY", SalaryMin: 300, StartTime: time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC), ChangeReason: model.ChangeReasonSalary},
+		{UserID: bob, Currency: "USD", SalaryMin: 50, StartTime: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{UserID: alice, Position: "Backend", Level: "Junior", Currency: "TRY", SalaryMin: 100, StartTime: time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{UserID: alice, Currency: "USD", SalaryMin: 10, StartTime: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), Concurrent: true},
+	} {
+		require.NoError(t, repos.SalaryEntries.Create(ctx, entry))
+	}
+
+	data, err := repos.Analytics.GetJobChangeData(ctx)
+	require.NoError(t, err)
+	require.Len(t, data, 3)
+
+	var aliceJobs []model.JobChangeData
+	for _, item := range data {
+		if item.UserID == alice {
+			aliceJobs = append(aliceJobs, item)
+		}
+	}
+	require.Len(t, aliceJobs, 2)
+	assert.Equal(t, int64(100), aliceJobs[0].SalaryMin)
+	assert.Equal(t, "Junior", aliceJobs[0].Level)
+	assert.Equal(t, int64(300), aliceJobs[1].SalaryMin)
+	assert.Equal(t, model.ChangeReasonSalary, aliceJobs[1].ChangeReason)
+	assert.True(t, aliceJobs[1].StartTime.Equal(time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)))
+
+	if data[0].UserID == alice {
+		assert.Equal(t, alice, data[1].UserID, "jobs of a user are consecutive")
+	} else {
+		assert.Equal(t, bob, data[0].UserID)
+	}
+}
+
+func testEntryRevisionsConcurrentCreate(t *testing.T, repos *repo.Repositories) {
+	ctx := context.Background()
+	userID := primitive.NewObjectID()
+
+	entry := &model.SalaryEntry{UserID: userID, Currency: "TRY", SalaryMin: 1000, StartTime: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)}
+	require.NoError(t, repos.SalaryEntries.Create(ctx, entry))
+
+	const workers = 8
+	var (
+		wg   sync.WaitGroup
+		errs = make([]error, workers)
+	)
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs[i] = repos.EntryRevisions.Create(ctx, &model.EntryRevision{
+				EntryID: entry.ID, UserID: userID, Action: model.RevisionActionUpdate, ChangedBy: userID, Snapshot: entry,
+			})
+		}(i)
+	}
+	wg.Wait()
+
+	for i := 0; i < workers; i++ {
+		require.NoError(t, errs[i])
+	}
+
+	revisions, err := repos.EntryRevisions.ListByEntryID(ctx, entry.ID)
+	require.NoError(t, err)
+	require.Len(t, revisions, workers)
+	for i, revision := range revisions {
+		assert.Equal(t, i+1, revision.Version)
+	}
+}
+
+func testTransactions(t *testing.T, repos *repo.Repositories) {
+	ctx := context.Background()
+	userID := primitive.NewObjectID()
+
+	writeEntry := func(ctx context.Context, entry *model.SalaryEntry) error {
+		if err := repos.SalaryEntries.Create(ctx, entry); err != nil {
+			return err
+		}
+		if err := repos.SalaryEntries.SetRaises(ctx, entry.ID, userID, []model.Raise{{NewSalary: 1200, RaiseDate: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)}}, 0); err != nil {
+			return err
+		}
+		return repos.EntryRevisions.Create(ctx, &model.EntryRevision{
+			EntryID: entry.ID, UserID: userID, Action: model.RevisionActionCreate, ChangedBy: userID, Snapshot: entry,
+		})
+	}
+
+	rolledBack := &model.SalaryEntry{UserID: userID, Currency: "TRY", SalaryMin: 1000, StartTime: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)}
+	err := repos.Transactor.WithTransaction(ctx, func(ctx context.Context) error {
+		if err := writeEntry(ctx, rolledBack); err != nil {
+			return err
+		}
+		return fmt.Errorf("revision rejected")
+	})
+	assert.EqualError(t, err, "revision rejected")
+
+	found, err := repos.SalaryEntries.GetByID(ctx, rolledBack.ID, userID)
+	require.NoError(t, err)
+	assert.Nil(t, found)
+	revisions, err := repos.EntryRevisions.ListByEntryID(ctx, rolledBack.ID)
+	require.NoError(t, err)
+	assert.Empty(t, revisions)
+
+	committed := &model.SalaryEntry{UserID: userID, Currency: "TRY", SalaryMin: 1000, StartTime: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)}
+	require.NoError(t, repos.Transactor.WithTransaction(ctx, func(ctx context.Context) error {
+		return writeEntry(ctx, committed)
+	}))
+
+	found, err = repos.SalaryEntries.GetByID(ctx, committed.ID, userID)
+	require.NoError(t, err)
+	require.NotNil(t, found)
+	assert.Len(t, found.Raises, 1)
+	revisions, err = repos.EntryRevisions.ListByEntryID(ctx, committed.ID)
+	require.NoError(t, err)
+	assert.Len(t, revisions, 1)
+}
+
+func testEntryRevisions(t *testing.T, repos *repo.Repositories) {
+	ctx := context.Background()
+	userID, entryID := primitive.NewObjectID(), primitive.NewObjectID()
+
+	revisions, err := repos.EntryRevisions.ListByEntryID(ctx, entryID)
+	require.NoError(t, err)
+	assert.Empty(t, revisions)
+
+	endTime := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	snapshot := &model.SalaryEntry{
+		ID: entryID, UserID: userID, Level: "Senior", TechStack: []string{"Go"}, Currency: "TRY", SalaryMin: 1000,
+		StartTime: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), EndTime: &endTime,
+		Raises: []model.Raise{{ID: primitive.NewObjectID(), NewSalary: 1200, Percentage: 20, RaiseDate: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)}},
+	}
+	created := &model.EntryRevision{
+		EntryID: entryID, UserID: userID, Action: model.RevisionActionCreate, ChangedBy: userID,
+		Changes:  []model.FieldChange{{Field: "level", New: json.RawMessage(`"Senior"`)}},
+		Snapshot: snapshot,
+	}
+	require.NoError(t, repos.EntryRevisions.Create(ctx, created))
+	assert.Equal(t, 1, created.Version)
+	assert.False(t, created.ID.IsZero())
+
+	restored := &model.EntryRevision{
+		EntryID: entryID, UserID: userID, Action: model.RevisionActionRestore, RestoredFrom: 1, ChangedBy: userID,
+		Changes:  []model.FieldChange{{Field: "level", Old: json.RawMessage(`"Staff"`), New: json.RawMessage(`"Senior"`)}},
+		Snapshot: snapshot,
+	}
+	require.NoError(t, repos.EntryRevisions.Create(ctx, restored))
+	assert.Equal(t, 2, restored.Version)
+
+	other := &model.EntryRevision{EntryID: primitive.NewObjectID(), UserID: userID, Action: model.RevisionActionCreate, ChangedBy: userID, Snapshot: snapshot}
+	require.NoError(t, repos.EntryRevisions.Create(ctx, other))
+	assert.Equal(t, 1, other.Version)
+
+	revisions, err = repos.EntryRevisions.ListByEntryID(ctx, entryID)
+	require.NoError(t, err)
+	require.Len(t, revisions, 2)
+	assert.Equal(t, model.RevisionActionCreate, revisions[0].Action)
+	assert.Equal(t, model.RevisionActionRestore, revisions[1].Action)
+	assert.Equal(t, 1, revisions[1].RestoredFrom)
+	assert.Equal(t, userID, revisions[1].ChangedBy)
+	assert.JSONEq(t, `"Staff"`, string(revisions[1].Changes[0].Old))
+
+	revision, err := repos.EntryRevisions.GetByVersion(ctx, entryID, 1)
+	require.NoError(t, err)
+	require.NotNil(t, revision)
+	assert.Equal(t, "level", revision.Changes[0].Field)
+	assert.Empty(t, revision.Changes[0].Old)
+	assert.Equal(t, "Senior", revision.Snapshot.Level)
+	assert.Equal(t, []string{"Go"}, revision.Snapshot.TechStack)
+	require.NotNil(t, revision.Snapshot.EndTime)
+	assert.True(t, revision.Snapshot.EndTime.Equal(endTime))
+	require.Len(t, revision.Snapshot.Raises, 1)
+	assert.Equal(t, snapshot.Raises[0].ID, revision.Snapshot.Raises[0].ID)
+
+	missing, err := repos.EntryRevisions.GetByVersion(ctx, entryID, 3)
+	require.NoError(t, err)
+	assert.Nil(t, missing)
+
+	require.NoError(t, repos.EntryRevisions.DeleteByEntryIDs(ctx, []primitive.ObjectID{entryID}))
+	revisions, err = repos.EntryRevisions.ListByEntryID(ctx, entryID)
+	require.NoError(t, err)
+	assert.Empty(t, revisions)
+
+	revisions, err = repos.EntryRevisions.ListByEntryID(ctx, other.EntryID)
+	require.NoError(t, err)
+	assert.Len(t, revisions, 1)
+}
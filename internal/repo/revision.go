@@ -0,0 +1,109 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/eminsonlu/salystic/internal/model"
+	"github.com/eminsonlu/salystic/pkg/database"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// EntryRevisionRepository stores the revisions of salary entries. Revisions
+// are never updated, and are only deleted when their entry is purged.
+type EntryRevisionRepository interface {
+	// Create stores revision as the next version of its entry.
+	Create(ctx context.Context, revision *model.EntryRevision) error
+	ListByEntryID(ctx context.Context, entryID primitive.ObjectID) ([]*model.EntryRevision, error)
+	GetByVersion(ctx context.Context, entryID primitive.ObjectID, version int) (*model.EntryRevision, error)
+	DeleteByEntryIDs(ctx context.Context, entryIDs []primitive.ObjectID) error
+}
+
+type entryRevisionRepository struct {
+	collection *mongo.Collection
+}
+
+func NewEntryRevisionRepository(db *database.MongoDB) EntryRevisionRepository {
+	return &entryRevisionRepository{
+		collection: db.Database.Collection("entry_revisions"),
+	}
+}
+
+// revisionCreateAttempts bounds how often Create picks a new version after a
+// concurrent revision of the same entry took the one it tried.
+const revisionCreateAttempts = 10
+
+// Create numbers revisions by counting the entry's existing ones. The unique
+// entry_revision_version_idx index rejects a concurrent revision taking the
+// same version, in which case the version is recounted.
+func (r *entryRevisionRepository) Create(ctx context.Context, revision *model.EntryRevision) error {
+	revision.ChangedAt = time.Now()
+
+	for attempt := 1; ; attempt++ {
+		err := r.insertNextVersion(ctx, revision)
+		if err == nil {
+			return nil
+		}
+		if !mongo.IsDuplicateKeyError(err) || attempt == revisionCreateAttempts {
+			return fmt.Errorf("failed to create entry revision: %w", err)
+		}
+	}
+}
+
+func (r *entryRevisionRepository) insertNextVersion(ctx context.Context, revision *model.EntryRevision) error {
+	count, err := r.collection.CountDocuments(ctx, bson.M{"entry_id": revision.EntryID})
+	if err != nil {
+		return err
+	}
+
+	revision.Version = int(count) + 1
+
+	result, err := r.collection.InsertOne(ctx, revision)
+	if err != nil {
+		return err
+	}
+
+	revision.ID = result.InsertedID.(primitive.ObjectID)
+	return nil
+}
+
+func (r *entryRevisionRepository) ListByEntryID(ctx context.Context, entryID primitive.ObjectID) ([]*model.EntryRevision, error) {
+	opts := options.Find().SetSort(bson.M{"version": 1})
+
+	cursor, err := r.collection.Find(ctx, bson.M{"entry_id": entryID}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find entry revisions: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	revisions := []*model.EntryRevision{}
+	if err = cursor.All(ctx, &revisions); err != nil {
+		return nil, fmt.Errorf("failed to decode entry revisions: %w", err)
+	}
+
+	return revisions, nil
+}
+
+func (r *entryRevisionRepository) GetByVersion(ctx context.Context, entryID primitive.ObjectID, version int) (*model.EntryRevision, error) {
+	var revision model.EntryRevision
+	err := r.collection.FindOne(ctx, bson.M{"entry_id": entryID, "version": version}).Decode(&revision)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get entry revision: %w", err)
+	}
+	return &revision, nil
+}
+
+func (r *entryRevisionRepository) DeleteByEntryIDs(ctx context.Context, entryIDs []primitive.ObjectID) error {
+	if _, err := r.collection.DeleteMany(ctx, bson.M{"entry_id": bson.M{"$in": entryIDs}}); err != nil {
+		return fmt.Errorf("failed to delete entry revisions: %w", err)
+	}
+	return nil
+}
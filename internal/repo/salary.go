@@ -17,12 +17,84 @@ type SalaryEntryRepository interface {
 	Create(ctx context.Context, entry *model.SalaryEntry) error
 	GetByID(ctx context.Context, id primitive.ObjectID, userID primitive.ObjectID) (*model.SalaryEntry, error)
 	GetByUserID(ctx context.Context, userID primitive.ObjectID) ([]*model.SalaryEntry, error)
+	ListByUserID(ctx context.Context, userID primitive.ObjectID, opts *SalaryEntryListOptions) ([]*model.SalaryEntry, error)
+	// Update moves the raises to the next version when it changes the salary
+	// or period they are computed and validated against.
 	Update(ctx context.Context, id primitive.ObjectID, userID primitive.ObjectID, update *model.UpdateSalaryEntryRequest) (*model.SalaryEntry, error)
+	// Delete marks the entry as deleted. Deleted entries are left out of the
+	// other methods until they are undeleted, and of analytics.
 	Delete(ctx context.Context, id primitive.ObjectID, userID primitive.ObjectID) error
-	AddRaise(ctx context.Context, entryID primitive.ObjectID, userID primitive.ObjectID, raise *model.Raise) error
+	// ListDeletedByUserID returns the user's deleted entries, most recently
+	// deleted first.
+	ListDeletedByUserID(ctx context.Context, userID primitive.ObjectID) ([]*model.SalaryEntry, error)
+	GetDeletedByID(ctx context.Context, id primitive.ObjectID, userID primitive.ObjectID) (*model.SalaryEntry, error)
+	Undelete(ctx context.Context, id primitive.ObjectID, userID primitive.ObjectID) error
+	// PurgeDeleted permanently removes the entries deleted before
+	// deletedBefore and returns their IDs.
+	PurgeDeleted(ctx context.Context, deletedBefore time.Time) ([]primitive.ObjectID, error)
+	// Replace overwrites the stored entry with the same ID and owner with
+	// entry, raises included, keeping entry's creation time. Like SetRaises,
+	// it only applies while the stored raises are at entry.RaisesVersion.
+	Replace(ctx context.Context, entry *model.SalaryEntry) error
+	// SetRaises replaces the raises of an entry with raises in chronological
+	// order, assigning an ID and creation time to new ones. It only applies
+	// while the stored raises are still at raisesVersion and moves them to the
+	// next version; otherwise it fails with "salary entry was modified
+	// concurrently".
+	SetRaises(ctx context.Context, entryID primitive.ObjectID, userID primitive.ObjectID, raises []model.Raise, raisesVersion int) error
 	GetRaises(ctx context.Context, entryID primitive.ObjectID, userID primitive.ObjectID) ([]model.Raise, error)
 }
 
+// SalaryEntryListOptions selects one page of a user's entries. Entries are
+// ordered by SortBy and then by ID, and After holds the sort key of the last
+// entry on the previous page.
+type SalaryEntryListOptions struct {
+	SortBy    string
+	Ascending bool
+	Currency  string
+	Current   *bool
+	After     *SalaryEntryCursor
+	Limit     int
+}
+
+type SalaryEntryCursor struct {
+	StartTime time.Time
+	SalaryMin int64
+	CreatedAt time.Time
+	ID        primitive.ObjectID
+}
+
+func NewSalaryEntryCursor(entry *model.SalaryEntry) *SalaryEntryCursor {
+	return &SalaryEntryCursor{
+		StartTime: entry.StartTime,
+		SalaryMin: entry.SalaryMin,
+		CreatedAt: entry.CreatedAt,
+		ID:        entry.ID,
+	}
+}
+
+func (c *SalaryEntryCursor) value(sortBy string) interface{} {
+	switch sortBy {
+	case model.EntrySortStartTime:
+		return c.StartTime
+	case model.EntrySortSalary:
+		return c.SalaryMin
+	default:
+		return c.CreatedAt
+	}
+}
+
+func salaryEntrySortField(sortBy string) string {
+	switch sortBy {
+	case model.EntrySortStartTime:
+		return "start_time"
+	case model.EntrySortSalary:
+		return "salary_min"
+	default:
+		return "created_at"
+	}
+}
+
 type salaryEntryRepository struct {
 	collection *mongo.Collection
 }
@@ -48,7 +120,7 @@ func (r *salaryEntryRepository) Create(ctx context.Context, entry *model.SalaryE
 
 func (r *salaryEntryRepository) GetByID(ctx context.Context, id primitive.ObjectID, userID primitive.ObjectID) (*model.SalaryEntry, error) {
 	var entry model.SalaryEntry
-	filter := bson.M{"_id": id, "user_id": userID}
+	filter := bson.M{"_id": id, "user_id": userID, "deleted_at": nil}
 	
 	err := r.collection.FindOne(ctx, filter).Decode(&entry)
 	if err != nil {
@@ -61,7 +133,7 @@ func (r *salaryEntryRepository) GetByID(ctx context.Context, id primitive.Object
 }
 
 func (r *salaryEntryRepository) GetByUserID(ctx context.Context, userID primitive.ObjectID) ([]*model.SalaryEntry, error) {
-	filter := bson.M{"user_id": userID}
+	filter := bson.M{"user_id": userID, "deleted_at": nil}
 	opts := options.Find().SetSort(bson.M{"created_at": -1})
 	
 	cursor, err := r.collection.Find(ctx, filter, opts)
@@ -78,8 +150,53 @@ func (r *salaryEntryRepository) GetByUserID(ctx context.Context, userID primitiv
 	return entries, nil
 }
 
+func (r *salaryEntryRepository) ListByUserID(ctx context.Context, userID primitive.ObjectID, opts *SalaryEntryListOptions) ([]*model.SalaryEntry, error) {
+	filter := bson.M{"user_id": userID, "deleted_at": nil}
+	if opts.Currency != "" {
+		filter["currency"] = opts.Currency
+	}
+	if opts.Current != nil {
+		if *opts.Current {
+			filter["end_time"] = nil
+		} else {
+			filter["end_time"] = bson.M{"$ne": nil}
+		}
+	}
+
+	field := salaryEntrySortField(opts.SortBy)
+	direction, op := -1, "$lt"
+	if opts.Ascending {
+		direction, op = 1, "$gt"
+	}
+
+	if opts.After != nil {
+		value := opts.After.value(opts.SortBy)
+		filter["$or"] = bson.A{
+			bson.M{field: bson.M{op: value}},
+			bson.M{field: value, "_id": bson.M{op: opts.After.ID}},
+		}
+	}
+
+	findOpts := options.Find().
+		SetSort(bson.D{{Key: field, Value: direction}, {Key: "_id", Value: direction}}).
+		SetLimit(int64(opts.Limit))
+
+	cursor, err := r.collection.Find(ctx, filter, findOpts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find salary entries: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var entries []*model.SalaryEntry
+	if err = cursor.All(ctx, &entries); err != nil {
+		return nil, fmt.Errorf("failed to decode salary entries: %w", err)
+	}
+
+	return entries, nil
+}
+
 func (r *salaryEntryRepository) Update(ctx context.Context, id primitive.ObjectID, userID primitive.ObjectID, update *model.UpdateSalaryEntryRequest) (*model.SalaryEntry, error) {
-	filter := bson.M{"_id": id, "user_id": userID}
+	filter := bson.M{"_id": id, "user_id": userID, "deleted_at": nil}
 	
 	updateDoc := bson.M{"$set": bson.M{"updated_at": time.Now()}}
 	setDoc := updateDoc["$set"].(bson.M)
@@ -114,6 +231,9 @@ func (r *salaryEntryRepository) Update(ctx context.Context, id primitive.ObjectI
 	if update.Currency != nil {
 		setDoc["currency"] = *update.Currency
 	}
+	if update.SalaryBasis != nil {
+		setDoc["salary_basis"] = *update.SalaryBasis
+	}
 	if update.SalaryMin != nil {
 		setDoc["salary_min"] = *update.SalaryMin
 	}
@@ -129,6 +249,18 @@ func (r *salaryEntryRepository) Update(ctx context.Context, id primitive.ObjectI
 	if update.EndTime != nil {
 		setDoc["end_time"] = *update.EndTime
 	}
+	if update.Concurrent != nil {
+		setDoc["concurrent"] = *update.Concurrent
+	}
+	if update.ChangeReason != nil {
+		setDoc["change_reason"] = *update.ChangeReason
+	}
+	if update.Compensation != nil {
+		setDoc["compensation"] = update.Compensation
+	}
+	if changesRaiseBasis(update) {
+		updateDoc["$inc"] = bson.M{"raises_version": 1}
+	}
 
 	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
 	var entry model.SalaryEntry
@@ -144,45 +276,160 @@ func (r *salaryEntryRepository) Update(ctx context.Context, id primitive.ObjectI
 }
 
 func (r *salaryEntryRepository) Delete(ctx context.Context, id primitive.ObjectID, userID primitive.ObjectID) error {
-	filter := bson.M{"_id": id, "user_id": userID}
-	
-	result, err := r.collection.DeleteOne(ctx, filter)
+	filter := bson.M{"_id": id, "user_id": userID, "deleted_at": nil}
+
+	result, err := r.collection.UpdateOne(ctx, filter, bson.M{"$set": bson.M{"deleted_at": time.Now()}})
 	if err != nil {
 		return fmt.Errorf("failed to delete salary entry: %w", err)
 	}
 
-	if result.DeletedCount == 0 {
+	if result.MatchedCount == 0 {
 		return fmt.Errorf("salary entry not found")
 	}
 
 	return nil
 }
 
-func (r *salaryEntryRepository) AddRaise(ctx context.Context, entryID primitive.ObjectID, userID primitive.ObjectID, raise *model.Raise) error {
-	filter := bson.M{"_id": entryID, "user_id": userID}
-	
-	raise.ID = primitive.NewObjectID()
-	raise.CreatedAt = time.Now()
+func (r *salaryEntryRepository) ListDeletedByUserID(ctx context.Context, userID primitive.ObjectID) ([]*model.SalaryEntry, error) {
+	filter := bson.M{"user_id": userID, "deleted_at": bson.M{"$ne": nil}}
+	opts := options.Find().SetSort(bson.D{{Key: "deleted_at", Value: -1}, {Key: "_id", Value: -1}})
 
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find deleted salary entries: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var entries []*model.SalaryEntry
+	if err = cursor.All(ctx, &entries); err != nil {
+		return nil, fmt.Errorf("failed to decode deleted salary entries: %w", err)
+	}
+
+	return entries, nil
+}
+
+func (r *salaryEntryRepository) GetDeletedByID(ctx context.Context, id primitive.ObjectID, userID primitive.ObjectID) (*model.SalaryEntry, error) {
+	var entry model.SalaryEntry
+	filter := bson.M{"_id": id, "user_id": userID, "deleted_at": bson.M{"$ne": nil}}
+
+	err := r.collection.FindOne(ctx, filter).Decode(&entry)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get deleted salary entry: %w", err)
+	}
+	return &entry, nil
+}
+
+func (r *salaryEntryRepository) Undelete(ctx context.Context, id primitive.ObjectID, userID primitive.ObjectID) error {
+	filter := bson.M{"_id": id, "user_id": userID, "deleted_at": bson.M{"$ne": nil}}
+
+	result, err := r.collection.UpdateOne(ctx, filter, bson.M{"$unset": bson.M{"deleted_at": ""}})
+	if err != nil {
+		return fmt.Errorf("failed to undelete salary entry: %w", err)
+	}
+
+	if result.MatchedCount == 0 {
+		return fmt.Errorf("salary entry not found")
+	}
+
+	return nil
+}
+
+func (r *salaryEntryRepository) PurgeDeleted(ctx context.Context, deletedBefore time.Time) ([]primitive.ObjectID, error) {
+	filter := bson.M{"deleted_at": bson.M{"$lt": deletedBefore}}
+
+	cursor, err := r.collection.Find(ctx, filter, options.Find().SetProjection(bson.M{"_id": 1}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to find expired salary entries: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var results []struct {
+		ID primitive.ObjectID `bson:"_id"`
+	}
+	if err = cursor.All(ctx, &results); err != nil {
+		return nil, fmt.Errorf("failed to decode expired salary entries: %w", err)
+	}
+
+	if len(results) == 0 {
+		return nil, nil
+	}
+
+	ids := make([]primitive.ObjectID, len(results))
+	for i, result := range results {
+		ids[i] = result.ID
+	}
+
+	if _, err := r.collection.DeleteMany(ctx, bson.M{"_id": bson.M{"$in": ids}, "deleted_at": bson.M{"$lt": deletedBefore}}); err != nil {
+		return nil, fmt.Errorf("failed to purge salary entries: %w", err)
+	}
+
+	return ids, nil
+}
+
+func (r *salaryEntryRepository) Replace(ctx context.Context, entry *model.SalaryEntry) error {
+	filter := bson.M{"_id": entry.ID, "user_id": entry.UserID, "deleted_at": nil, "raises_version": entry.RaisesVersion}
+	replacement := *entry
+	replacement.UpdatedAt = time.Now()
+	replacement.RaisesVersion++
+
+	result, err := r.collection.ReplaceOne(ctx, filter, &replacement)
+	if err != nil {
+		return fmt.Errorf("failed to replace salary entry: %w", err)
+	}
+
+	if result.MatchedCount == 0 {
+		return r.missingOrModified(ctx, entry.ID, entry.UserID)
+	}
+
+	*entry = replacement
+	return nil
+}
+
+func (r *salaryEntryRepository) SetRaises(ctx context.Context, entryID primitive.ObjectID, userID primitive.ObjectID, raises []model.Raise, raisesVersion int) error {
+	filter := bson.M{"_id": entryID, "user_id": userID, "deleted_at": nil, "raises_version": raisesVersion}
+
+	raises = withRaiseIDs(raises)
 	update := bson.M{
-		"$push": bson.M{"raises": raise},
-		"$set":  bson.M{"updated_at": time.Now()},
+		"$set": bson.M{"raises": raises, "updated_at": time.Now()},
+		"$inc": bson.M{"raises_version": 1},
 	}
 
 	result, err := r.collection.UpdateOne(ctx, filter, update)
 	if err != nil {
-		return fmt.Errorf("failed to add raise: %w", err)
+		return fmt.Errorf("failed to set raises: %w", err)
 	}
 
 	if result.MatchedCount == 0 {
-		return fmt.Errorf("salary entry not found")
+		return r.missingOrModified(ctx, entryID, userID)
 	}
 
 	return nil
 }
 
+// missingOrModified explains why a write guarded by the raises version matched
+// nothing: the entry is gone, or its raises moved past that version.
+func (r *salaryEntryRepository) missingOrModified(ctx context.Context, id primitive.ObjectID, userID primitive.ObjectID) error {
+	count, err := r.collection.CountDocuments(ctx, bson.M{"_id": id, "user_id": userID, "deleted_at": nil})
+	if err != nil {
+		return fmt.Errorf("failed to get salary entry: %w", err)
+	}
+	if count == 0 {
+		return fmt.Errorf("salary entry not found")
+	}
+	return fmt.Errorf("salary entry was modified concurrently")
+}
+
+// changesRaiseBasis reports whether update changes what raises are computed
+// or validated against.
+func changesRaiseBasis(update *model.UpdateSalaryEntryRequest) bool {
+	return update.SalaryMin != nil || update.StartTime != nil || update.EndTime != nil
+}
+
 func (r *salaryEntryRepository) GetRaises(ctx context.Context, entryID primitive.ObjectID, userID primitive.ObjectID) ([]model.Raise, error) {
-	filter := bson.M{"_id": entryID, "user_id": userID}
+	filter := bson.M{"_id": entryID, "user_id": userID, "deleted_at": nil}
 	projection := bson.M{"raises": 1}
 	
 	var result struct {
@@ -203,3 +450,17 @@ func (r *salaryEntryRepository) GetRaises(ctx context.Context, entryID primitive
 
 	return result.Raises, nil
 }
+
+// withRaiseIDs copies raises, assigning an ID and creation time to the ones
+// that have not been stored yet.
+func withRaiseIDs(raises []model.Raise) []model.Raise {
+	result := make([]model.Raise, len(raises))
+	for i, raise := range raises {
+		if raise.ID.IsZero() {
+			raise.ID = primitive.NewObjectID()
+			raise.CreatedAt = time.Now()
+		}
+		result[i] = raise
+	}
+	return result
+}
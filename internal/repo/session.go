@@ -0,0 +1,111 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/eminsonlu/salystic/internal/model"
+	"github.com/eminsonlu/salystic/pkg/database"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type SessionRepository interface {
+	Create(ctx context.Context, session *model.Session) error
+	GetByID(ctx context.Context, id primitive.ObjectID) (*model.Session, error)
+	GetActiveByUserID(ctx context.Context, userID primitive.ObjectID) ([]*model.Session, error)
+	Touch(ctx context.Context, id primitive.ObjectID) error
+	Revoke(ctx context.Context, id primitive.ObjectID, userID primitive.ObjectID) error
+}
+
+type sessionRepository struct {
+	collection *mongo.Collection
+}
+
+func NewSessionRepository(db *database.MongoDB) SessionRepository {
+	return &sessionRepository{
+		collection: db.Database.Collection("sessions"),
+	}
+}
+
+func (r *sessionRepository) Create(ctx context.Context, session *model.Session) error {
+	if session.ID.IsZero() {
+		session.ID = primitive.NewObjectID()
+	}
+	session.CreatedAt = time.Now()
+	session.LastSeenAt = time.Now()
+
+	if _, err := r.collection.InsertOne(ctx, session); err != nil {
+		return fmt.Errorf("failed to create session: %w", err)
+	}
+	return nil
+}
+
+func (r *sessionRepository) GetByID(ctx context.Context, id primitive.ObjectID) (*model.Session, error) {
+	var session model.Session
+	err := r.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&session)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get session: %w", err)
+	}
+	return &session, nil
+}
+
+func (r *sessionRepository) GetActiveByUserID(ctx context.Context, userID primitive.ObjectID) ([]*model.Session, error) {
+	filter := bson.M{
+		"user_id":    userID,
+		"revoked_at": bson.M{"$exists": false},
+		"expires_at": bson.M{"$gt": time.Now()},
+	}
+	opts := options.Find().SetSort(bson.M{"last_seen_at": -1})
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find sessions: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	sessions := []*model.Session{}
+	if err = cursor.All(ctx, &sessions); err != nil {
+		return nil, fmt.Errorf("failed to decode sessions: %w", err)
+	}
+
+	return sessions, nil
+}
+
+func (r *sessionRepository) Touch(ctx context.Context, id primitive.ObjectID) error {
+	_, err := r.collection.UpdateOne(
+		ctx,
+		bson.M{"_id": id},
+		bson.M{"$set": bson.M{"last_seen_at": time.Now()}},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to touch session: %w", err)
+	}
+	return nil
+}
+
+func (r *sessionRepository) Revoke(ctx context.Context, id primitive.ObjectID, userID primitive.ObjectID) error {
+	filter := bson.M{
+		"_id":        id,
+		"user_id":    userID,
+		"revoked_at": bson.M{"$exists": false},
+	}
+
+	result, err := r.collection.UpdateOne(ctx, filter, bson.M{"$set": bson.M{"revoked_at": time.Now()}})
+	if err != nil {
+		return fmt.Errorf("failed to revoke session: %w", err)
+	}
+
+	if result.MatchedCount == 0 {
+		return fmt.Errorf("session not found")
+	}
+
+	return nil
+}
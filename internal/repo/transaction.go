@@ -0,0 +1,42 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/eminsonlu/salystic/pkg/database"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// Transactor runs writes across repositories atomically. Repositories join
+// the transaction through the context passed to fn; if fn returns an error,
+// none of its writes are kept and the error is returned as is.
+type Transactor interface {
+	WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+type mongoTransactor struct {
+	client *mongo.Client
+}
+
+// NewMongoTransactor runs transactions in client sessions, which requires a
+// replica set or sharded cluster.
+func NewMongoTransactor(db *database.MongoDB) Transactor {
+	return &mongoTransactor{client: db.Client}
+}
+
+// WithTransaction may run fn more than once when the transaction conflicts
+// with a concurrent one.
+func (t *mongoTransactor) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	session, err := t.client.StartSession()
+	if err != nil {
+		return fmt.Errorf("failed to start session: %w", err)
+	}
+	defer session.EndSession(ctx)
+
+	_, err = session.WithTransaction(ctx, func(sessCtx mongo.SessionContext) (any, error) {
+		return nil, fn(sessCtx)
+	})
+	return err
+}
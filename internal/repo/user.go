@@ -10,11 +10,13 @@ import (
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 type UserRepository interface {
 	Create(ctx context.Context, user *model.User) error
 	GetByPseudonymizedID(ctx context.Context, pseudonymizedID string) (*model.User, error)
+	UpsertByPseudonymizedID(ctx context.Context, pseudonymizedID string) (*model.User, bool, error)
 	GetByID(ctx context.Context, id primitive.ObjectID) (*model.User, error)
 	UpdateLastLogin(ctx context.Context, id primitive.ObjectID) error
 }
@@ -55,6 +57,61 @@ func (r *userRepository) GetByPseudonymizedID(ctx context.Context, pseudonymized
 	return &user, nil
 }
 
+func (r *userRepository) UpsertByPseudonymizedID(ctx context.Context, pseudonymizedID string) (*model.User, bool, error) {
+	user, created, err := r.upsert(ctx, pseudonymizedID)
+	if mongo.IsDuplicateKeyError(err) {
+		// A concurrent upsert inserted the same user first; retrying matches it.
+		user, created, err = r.upsert(ctx, pseudonymizedID)
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to upsert user: %w", err)
+	}
+	return user, created, nil
+}
+
+func (r *userRepository) upsert(ctx context.Context, pseudonymizedID string) (*model.User, bool, error) {
+	now := time.Now()
+	id := primitive.NewObjectID()
+
+	opts := options.FindOneAndUpdate().
+		SetUpsert(true).
+		SetReturnDocument(options.Before)
+
+	var user model.User
+	err := r.collection.FindOneAndUpdate(
+		ctx,
+		bson.M{"pseudonymized_id": pseudonymizedID},
+		bson.M{
+			"$set": bson.M{
+				"last_login": now,
+				"updated_at": now,
+			},
+			"$setOnInsert": bson.M{
+				"_id":        id,
+				"created_at": now,
+			},
+		},
+		opts,
+	).Decode(&user)
+
+	if err == mongo.ErrNoDocuments {
+		return &model.User{
+			ID:              id,
+			PseudonymizedID: pseudonymizedID,
+			CreatedAt:       now,
+			UpdatedAt:       now,
+			LastLogin:       now,
+		}, true, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+
+	user.LastLogin = now
+	user.UpdatedAt = now
+	return &user, false, nil
+}
+
 func (r *userRepository) GetByID(ctx context.Context, id primitive.ObjectID) (*model.User, error) {
 	var user model.User
 	err := r.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&user)
@@ -1,12 +1,21 @@
 package repo
 
 import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/eminsonlu/salystic/internal/model"
+	"github.com/eminsonlu/salystic/pkg/database"
+	"github.com/eminsonlu/salystic/pkg/logging"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
@@ -42,6 +51,64 @@ func TestUserRepository_Interface(t *testing.T) {
 	
 	assert.NotNil(t, repo.Create)
 	assert.NotNil(t, repo.GetByPseudonymizedID)
+	assert.NotNil(t, repo.UpsertByPseudonymizedID)
 	assert.NotNil(t, repo.GetByID)
 	assert.NotNil(t, repo.UpdateLastLogin)
 }
+
+func TestUserRepository_UpsertByPseudonymizedID_Concurrent(t *testing.T) {
+	uri := os.Getenv("SALYSTIC_TEST_MONGO_URI")
+	if uri == "" {
+		t.Skip("SALYSTIC_TEST_MONGO_URI is not set")
+	}
+
+	dbName := fmt.Sprintf("salystic_test_%d", time.Now().UnixNano())
+	db, err := database.NewMongoDB(uri, dbName, "", "")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		db.Database.Drop(context.Background())
+		db.Close()
+	})
+
+	ctx := context.Background()
+	require.NoError(t, NewIndexRepo(db.Database, logging.NewNop()).CreateAllIndexes(ctx))
+
+	repo := NewUserRepository(db)
+
+	const workers = 20
+	var (
+		wg      sync.WaitGroup
+		created atomic.Int32
+		ids     = make([]primitive.ObjectID, workers)
+		errs    = make([]error, workers)
+	)
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			user, isNew, err := repo.UpsertByPseudonymizedID(ctx, "concurrent_pseudo_id")
+			errs[i] = err
+			if err == nil {
+				ids[i] = user.ID
+				if isNew {
+					created.Add(1)
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	for i := 0; i < workers; i++ {
+		require.NoError(t, errs[i])
+		assert.Equal(t, ids[0], ids[i])
+	}
+	assert.Equal(t, int32(1), created.Load())
+
+	count, err := db.Database.Collection("users").CountDocuments(ctx, bson.M{"pseudonymized_id": "concurrent_pseudo_id"})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+
+	stored, err := repo.GetByPseudonymizedID(ctx, "concurrent_pseudo_id")
+	require.NoError(t, err)
+	assert.Equal(t, ids[0], stored.ID)
+}
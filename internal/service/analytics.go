@@ -4,27 +4,36 @@ import (
 	"context"
 	"crypto/md5"
 	"fmt"
+	"log/slog"
 	"math"
 	"sort"
 	"time"
 
+	"github.com/eminsonlu/salystic/internal/metrics"
 	"github.com/eminsonlu/salystic/internal/model"
 	"github.com/eminsonlu/salystic/internal/repo"
+	"github.com/eminsonlu/salystic/pkg/currency"
+	"github.com/eminsonlu/salystic/pkg/tracing"
 	"github.com/jellydator/ttlcache/v3"
+	"go.opentelemetry.io/otel/attribute"
 	"golang.org/x/sync/errgroup"
 )
 
 type AnalyticsService struct {
-	analyticsRepo *repo.AnalyticsRepo
+	analyticsRepo repo.AnalyticsRepository
 	cache         *ttlcache.Cache[string, *model.Analytics]
 	cacheCareer   *ttlcache.Cache[string, *model.CareerAnalytics]
+	cacheComp     *ttlcache.Cache[string, *model.CompensationAnalytics]
+	cacheDetailed *ttlcache.Cache[string, *model.DetailedAnalytics]
+	exchangeRates currency.Rates
+	logger        *slog.Logger
 }
 
-func NewAnalyticsService(analyticsRepo *repo.AnalyticsRepo) *AnalyticsService {
-	return NewAnalyticsServiceWithTTL(analyticsRepo, 10*time.Minute)
+func NewAnalyticsService(analyticsRepo repo.AnalyticsRepository, exchangeRates currency.Rates, logger *slog.Logger) *AnalyticsService {
+	return NewAnalyticsServiceWithTTL(analyticsRepo, exchangeRates, 10*time.Minute, logger)
 }
 
-func NewAnalyticsServiceWithTTL(analyticsRepo *repo.AnalyticsRepo, cacheTTL time.Duration) *AnalyticsService {
+func NewAnalyticsServiceWithTTL(analyticsRepo repo.AnalyticsRepository, exchangeRates currency.Rates, cacheTTL time.Duration, logger *slog.Logger) *AnalyticsService {
 	cache := ttlcache.New(
 		ttlcache.WithTTL[string, *model.Analytics](cacheTTL),
 		ttlcache.WithCapacity[string, *model.Analytics](100),
@@ -37,30 +46,119 @@ func NewAnalyticsServiceWithTTL(analyticsRepo *repo.AnalyticsRepo, cacheTTL time
 	)
 	go cacheCareer.Start()
 
+	cacheComp := ttlcache.New(
+		ttlcache.WithTTL[string, *model.CompensationAnalytics](cacheTTL),
+		ttlcache.WithCapacity[string, *model.CompensationAnalytics](100),
+	)
+	go cacheComp.Start()
+
+	cacheDetailed := ttlcache.New(
+		ttlcache.WithTTL[string, *model.DetailedAnalytics](cacheTTL),
+		ttlcache.WithCapacity[string, *model.DetailedAnalytics](100),
+	)
+	go cacheDetailed.Start()
+
 	return &AnalyticsService{
 		analyticsRepo: analyticsRepo,
 		cache:         cache,
 		cacheCareer:   cacheCareer,
+		cacheComp:     cacheComp,
+		cacheDetailed: cacheDetailed,
+		exchangeRates: exchangeRates,
+		logger:        logger,
+	}
+}
+
+func (s *AnalyticsService) Close() {
+	s.cache.Stop()
+	s.cacheCareer.Stop()
+	s.cacheComp.Stop()
+	s.cacheDetailed.Stop()
+}
+
+func (s *AnalyticsService) WarmUp(ctx context.Context) error {
+	if _, err := s.GetGeneralAnalytics(ctx, "", "", "", model.SalaryBasisNetMonthly); err != nil {
+		return fmt.Errorf("failed to warm general analytics cache: %w", err)
+	}
+	if _, err := s.GetCareerAnalytics(ctx); err != nil {
+		return fmt.Errorf("failed to warm career analytics cache: %w", err)
+	}
+	if _, err := s.GetCompensationAnalytics(ctx, "TRY", model.SalaryBasisGrossAnnual); err != nil {
+		return fmt.Errorf("failed to warm compensation analytics cache: %w", err)
 	}
+	return nil
 }
 
-func (s *AnalyticsService) generateCacheKey(level, position, currency string) string {
-	key := fmt.Sprintf("analytics:%s:%s:%s", level, position, currency)
+func (s *AnalyticsService) GeneralCacheStats() metrics.CacheStats {
+	stats := s.cache.Metrics()
+	return metrics.CacheStats{Hits: stats.Hits, Misses: stats.Misses}
+}
+
+func (s *AnalyticsService) CareerCacheStats() metrics.CacheStats {
+	stats := s.cacheCareer.Metrics()
+	return metrics.CacheStats{Hits: stats.Hits, Misses: stats.Misses}
+}
+
+func (s *AnalyticsService) CompensationCacheStats() metrics.CacheStats {
+	stats := s.cacheComp.Metrics()
+	return metrics.CacheStats{Hits: stats.Hits, Misses: stats.Misses}
+}
+
+func (s *AnalyticsService) DetailedCacheStats() metrics.CacheStats {
+	stats := s.cacheDetailed.Metrics()
+	return metrics.CacheStats{Hits: stats.Hits, Misses: stats.Misses}
+}
+
+func (s *AnalyticsService) EntryStats(ctx context.Context) (*metrics.EntryStats, error) {
+	byCurrency, err := s.analyticsRepo.CountEntriesByCurrency(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := &metrics.EntryStats{ByCurrency: byCurrency}
+	for _, count := range byCurrency {
+		stats.Total += count
+	}
+	return stats, nil
+}
+
+func (s *AnalyticsService) generateCacheKey(level, position, currency, basis string) string {
+	key := fmt.Sprintf("analytics:%s:%s:%s:%s", level, position, currency, basis)
 	hash := md5.Sum([]byte(key))
 	return fmt.Sprintf("%x", hash)
 }
 
-func (s *AnalyticsService) GetGeneralAnalytics(ctx context.Context, level, position, currency string) (*model.Analytics, error) {
-	cacheKey := s.generateCacheKey(level, position, currency)
+// GetGeneralAnalytics aggregates the salaries recorded on basis, which
+// defaults to net monthly; amounts on different bases are never averaged
+// together.
+func (s *AnalyticsService) GetGeneralAnalytics(ctx context.Context, level, position, currency, basis string) (*model.Analytics, error) {
+	if basis == "" {
+		basis = model.SalaryBasisNetMonthly
+	}
+
+	ctx, span := tracing.Start(ctx, "AnalyticsService.GetGeneralAnalytics",
+		attribute.String("analytics.level", level),
+		attribute.String("analytics.position", position),
+		attribute.String("analytics.currency", currency),
+		attribute.String("analytics.basis", basis),
+	)
+	defer span.End()
+
+	cacheKey := s.generateCacheKey(level, position, currency, basis)
 
 	if cached := s.cache.Get(cacheKey); cached != nil {
+		span.SetAttributes(attribute.Bool("analytics.cache_hit", true))
+		s.logger.DebugContext(ctx, "Analytics cache hit", "cache", "general", "level", level, "position", position, "currency", currency, "basis", basis)
 		return cached.Value(), nil
 	}
+	span.SetAttributes(attribute.Bool("analytics.cache_hit", false))
+	s.logger.DebugContext(ctx, "Analytics cache miss", "cache", "general", "level", level, "position", position, "currency", currency, "basis", basis)
 
 	filter := &repo.AnalyticsFilter{
-		Level:    level,
-		Position: position,
-		Currency: currency,
+		Level:       level,
+		Position:    position,
+		Currency:    currency,
+		SalaryBasis: basis,
 	}
 
 	g, ctx := errgroup.WithContext(ctx)
@@ -68,17 +166,30 @@ func (s *AnalyticsService) GetGeneralAnalytics(ctx context.Context, level, posit
 	var (
 		combinedResult *repo.CombinedAnalyticsResult
 		salaryByTech   []model.SalaryByTech
+		percentiles    *model.SalaryPercentiles
 	)
 
 	g.Go(func() error {
+		ctx, span := tracing.Start(ctx, "AnalyticsRepo.GetCombinedAnalytics")
 		var err error
 		combinedResult, err = s.analyticsRepo.GetCombinedAnalytics(ctx, filter)
+		tracing.End(span, err)
 		return err
 	})
 
 	g.Go(func() error {
+		ctx, span := tracing.Start(ctx, "AnalyticsRepo.GetAverageSalaryByTech")
 		var err error
 		salaryByTech, err = s.analyticsRepo.GetAverageSalaryByTech(ctx, filter)
+		tracing.End(span, err)
+		return err
+	})
+
+	g.Go(func() error {
+		ctx, span := tracing.Start(ctx, "AnalyticsRepo.GetSalaryPercentiles")
+		var err error
+		percentiles, err = s.analyticsRepo.GetSalaryPercentiles(ctx, filter)
+		tracing.End(span, err)
 		return err
 	})
 
@@ -191,6 +302,7 @@ func (s *AnalyticsService) GetGeneralAnalytics(ctx context.Context, level, posit
 	salaryRanges := s.buildSalaryRanges(salaryByPosition, currency)
 
 	analytics := &model.Analytics{
+		SalaryBasis:                basis,
 		TotalEntries:               totalEntries,
 		AverageSalary:              averageSalary,
 		AverageSalaryByPosition:    averageByPositionMap,
@@ -223,6 +335,7 @@ func (s *AnalyticsService) GetGeneralAnalytics(ctx context.Context, level, posit
 		TopPayingPositions:         topPayingPositions,
 		TopPayingTechs:             topPayingTechs,
 		SalaryRanges:               salaryRanges,
+		SalaryPercentiles:          *percentiles,
 		LastUpdated:                time.Now(),
 	}
 
@@ -230,12 +343,93 @@ func (s *AnalyticsService) GetGeneralAnalytics(ctx context.Context, level, posit
 	return analytics, nil
 }
 
+// GetDetailedAnalytics returns the general analytics together with the extra
+// breakdowns served to API keys, all computed on a single salary basis.
+func (s *AnalyticsService) GetDetailedAnalytics(ctx context.Context, level, position, currency, basis string) (*model.DetailedAnalytics, error) {
+	if basis == "" {
+		basis = model.SalaryBasisNetMonthly
+	}
+
+	ctx, span := tracing.Start(ctx, "AnalyticsService.GetDetailedAnalytics",
+		attribute.String("analytics.level", level),
+		attribute.String("analytics.position", position),
+		attribute.String("analytics.currency", currency),
+		attribute.String("analytics.basis", basis),
+	)
+	defer span.End()
+
+	cacheKey := s.generateCacheKey(level, position, currency, basis)
+
+	if cached := s.cacheDetailed.Get(cacheKey); cached != nil {
+		span.SetAttributes(attribute.Bool("analytics.cache_hit", true))
+		s.logger.DebugContext(ctx, "Analytics cache hit", "cache", "detailed", "level", level, "position", position, "currency", currency, "basis", basis)
+		return cached.Value(), nil
+	}
+	span.SetAttributes(attribute.Bool("analytics.cache_hit", false))
+	s.logger.DebugContext(ctx, "Analytics cache miss", "cache", "detailed", "level", level, "position", position, "currency", currency, "basis", basis)
+
+	filter := &repo.AnalyticsFilter{
+		Level:       level,
+		Position:    position,
+		Currency:    currency,
+		SalaryBasis: basis,
+	}
+
+	g, gctx := errgroup.WithContext(ctx)
+
+	var (
+		analytics             *model.Analytics
+		byPositionAndLevel    []model.SalaryByPositionLevel
+		percentilesByPosition map[string]model.SalaryPercentiles
+	)
+
+	g.Go(func() error {
+		var err error
+		analytics, err = s.GetGeneralAnalytics(gctx, level, position, currency, basis)
+		return err
+	})
+
+	g.Go(func() error {
+		ctx, span := tracing.Start(gctx, "AnalyticsRepo.GetAverageSalaryByPositionAndLevel")
+		var err error
+		byPositionAndLevel, err = s.analyticsRepo.GetAverageSalaryByPositionAndLevel(ctx, filter)
+		tracing.End(span, err)
+		return err
+	})
+
+	g.Go(func() error {
+		ctx, span := tracing.Start(gctx, "AnalyticsRepo.GetSalaryPercentilesByPosition")
+		var err error
+		percentilesByPosition, err = s.analyticsRepo.GetSalaryPercentilesByPosition(ctx, filter)
+		tracing.End(span, err)
+		return err
+	})
+
+	if err := g.Wait(); err != nil {
+		return nil, fmt.Errorf("failed to fetch detailed analytics data: %w", err)
+	}
+
+	detailed := &model.DetailedAnalytics{
+		Analytics:                analytics,
+		SalaryByPositionAndLevel: byPositionAndLevel,
+		PercentilesByPosition:    percentilesByPosition,
+	}
+
+	s.cacheDetailed.Set(cacheKey, detailed, ttlcache.DefaultTTL)
+	return detailed, nil
+}
+
 func (s *AnalyticsService) GetCareerAnalytics(ctx context.Context) (*model.CareerAnalytics, error) {
+	ctx, span := tracing.Start(ctx, "AnalyticsService.GetCareerAnalytics")
+	defer span.End()
+
 	cacheKey := "career_analytics"
 
 	if cached := s.cacheCareer.Get(cacheKey); cached != nil {
+		s.logger.DebugContext(ctx, "Analytics cache hit", "cache", "career")
 		return cached.Value(), nil
 	}
+	s.logger.DebugContext(ctx, "Analytics cache miss", "cache", "career")
 
 	jobChangeData, err := s.analyticsRepo.GetJobChangeData(ctx)
 	if err != nil {
@@ -261,55 +455,348 @@ func (s *AnalyticsService) GetCareerAnalytics(ctx context.Context) (*model.Caree
 	}, nil
 }
 
-func (s *AnalyticsService) calculateJobChangeAnalytics(data []model.JobChangeData) model.JobChangeAnalytics {
-	if len(data) == 0 {
-		return model.JobChangeAnalytics{}
+// GetCompensationAnalytics reports base salary against total compensation in
+// the given currency, either as net monthly or gross annual amounts. Entries in
+// a currency without an exchange rate are left out.
+func (s *AnalyticsService) GetCompensationAnalytics(ctx context.Context, targetCurrency, basis string) (*model.CompensationAnalytics, error) {
+	ctx, span := tracing.Start(ctx, "AnalyticsService.GetCompensationAnalytics",
+		attribute.String("analytics.currency", targetCurrency),
+		attribute.String("analytics.basis", basis),
+	)
+	defer span.End()
+
+	cacheKey := targetCurrency + ":" + basis
+	if cached := s.cacheComp.Get(cacheKey); cached != nil {
+		s.logger.DebugContext(ctx, "Analytics cache hit", "cache", "compensation", "currency", targetCurrency, "basis", basis)
+		return cached.Value(), nil
 	}
+	s.logger.DebugContext(ctx, "Analytics cache miss", "cache", "compensation", "currency", targetCurrency, "basis", basis)
 
-	var totalIncrease float64
-	var withIncrease int
+	data, err := s.analyticsRepo.GetCompensationData(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get compensation data: %w", err)
+	}
+
+	analytics := s.calculateCompensationAnalytics(data, targetCurrency, basis)
+	s.cacheComp.Set(cacheKey, analytics, ttlcache.DefaultTTL)
 
-	for _, item := range data {
-		if len(item.Raises) > 0 {
-			salaryMax := item.SalaryMax
-			if salaryMax == nil {
-				salaryMax = &item.SalaryMin
+	return analytics, nil
+}
+
+// compensationSample is one entry's base salary and total compensation in the
+// target currency and basis.
+type compensationSample struct {
+	position   string
+	level      string
+	experience string
+	base       float64
+	total      float64
+}
+
+// calculateCompensationAnalytics normalizes each entry's latest salary to
+// the monthly form of basis before adding the compensation components, which
+// are taken as recorded.
+func (s *AnalyticsService) calculateCompensationAnalytics(data []model.CompensationData, targetCurrency, basis string) *model.CompensationAnalytics {
+	var samples []compensationSample
+	var withBonus, withEquity, withMealCard, withHealthInsurance int
+
+	monthlyBasis := model.SalaryBasisNetMonthly
+	if isGrossBasis(basis) {
+		monthlyBasis = model.SalaryBasisGrossMonthly
+	}
+
+	for _, entry := range data {
+		monthlySalary, ok := s.normalizeSalary(float64(latestSalary(entry.SalaryMin, entry.Raises)),
+			entry.Currency, entry.SalaryBasis, salaryYear(entry.StartTime, entry.Raises), monthlyBasis)
+		if !ok {
+			continue
+		}
+
+		rate, ok := s.exchangeRates.Convert(1, entry.Currency, targetCurrency)
+		if !ok {
+			continue
+		}
+
+		base, total := annualCompensation(monthlySalary, entry.Compensation)
+		if !isAnnualBasis(basis) {
+			base, total = base/12, total/12
+		}
+
+		samples = append(samples, compensationSample{
+			position:   entry.Position,
+			level:      entry.Level,
+			experience: entry.Experience,
+			base:       base * rate,
+			total:      total * rate,
+		})
+
+		if compensation := entry.Compensation; compensation != nil {
+			if compensation.Bonus != nil {
+				withBonus++
+			}
+			if compensation.Equity != nil {
+				withEquity++
 			}
-			initialSalary := (item.SalaryMin + *salaryMax) / 2
-			latestRaise := item.Raises[len(item.Raises)-1]
-			if latestRaise.NewSalary > initialSalary {
-				withIncrease++
-				increase := float64(latestRaise.NewSalary-initialSalary) / float64(initialSalary) * 100
-				totalIncrease += increase
+			if compensation.MealCard > 0 {
+				withMealCard++
+			}
+			if compensation.HealthInsurance != nil {
+				withHealthInsurance++
+			}
+		}
+	}
+
+	analytics := &model.CompensationAnalytics{
+		Currency:     targetCurrency,
+		Basis:        basis,
+		TotalEntries: len(samples),
+		ByPosition:   compensationCohorts(samples, func(s compensationSample) string { return s.position }),
+		ByLevel:      compensationCohorts(samples, func(s compensationSample) string { return s.level }),
+		ByExperience: compensationCohorts(samples, func(s compensationSample) string { return s.experience }),
+	}
+	if len(samples) == 0 {
+		return analytics
+	}
+
+	overall := summarizeCompensation("", samples)
+	share := func(count int) float64 {
+		return roundToTwoDecimals(float64(count) / float64(len(samples)) * 100)
+	}
+
+	analytics.AverageBase = overall.AverageBase
+	analytics.AverageTotal = overall.AverageTotal
+	analytics.WithBonusPercentage = share(withBonus)
+	analytics.WithEquityPercentage = share(withEquity)
+	analytics.WithMealCardPercentage = share(withMealCard)
+	analytics.WithHealthInsurancePercentage = share(withHealthInsurance)
+
+	return analytics
+}
+
+// annualCompensation returns the annual base salary and total compensation of
+// a job paid monthly. Equity counts at its grant value spread evenly over the
+// vesting period.
+func annualCompensation(monthlySalary float64, compensation *model.Compensation) (float64, float64) {
+	base := monthlySalary * 12
+	if compensation == nil {
+		return base, base
+	}
+
+	total := base
+	if bonus := compensation.Bonus; bonus != nil {
+		total += float64(bonus.Amount) + bonus.Months*monthlySalary
+	}
+	if equity := compensation.Equity; equity != nil && equity.VestingMonths > 0 {
+		total += float64(equity.GrantValue) * 12 / float64(equity.VestingMonths)
+	}
+
+	monthlyBenefits := compensation.MealCard
+	if compensation.HealthInsurance != nil {
+		monthlyBenefits += compensation.HealthInsurance.MonthlyValue
+	}
+	for _, benefit := range compensation.OtherBenefits {
+		monthlyBenefits += benefit.MonthlyValue
+	}
+	total += float64(monthlyBenefits) * 12
+
+	return base, total
+}
+
+func compensationCohorts(samples []compensationSample, key func(compensationSample) string) []model.CompensationCohort {
+	groups := make(map[string][]compensationSample)
+	for _, sample := range samples {
+		if category := key(sample); category != "" {
+			groups[category] = append(groups[category], sample)
+		}
+	}
+
+	cohorts := make([]model.CompensationCohort, 0, len(groups))
+	for category, group := range groups {
+		cohorts = append(cohorts, summarizeCompensation(category, group))
+	}
+
+	sort.Slice(cohorts, func(i, j int) bool {
+		return cohorts[i].Category < cohorts[j].Category
+	})
+	return cohorts
+}
+
+func summarizeCompensation(category string, samples []compensationSample) model.CompensationCohort {
+	bases := make([]float64, len(samples))
+	totals := make([]float64, len(samples))
+	var baseSum, totalSum float64
+	for i, sample := range samples {
+		bases[i] = sample.base
+		totals[i] = sample.total
+		baseSum += sample.base
+		totalSum += sample.total
+	}
+
+	return model.CompensationCohort{
+		Category:     category,
+		Count:        len(samples),
+		AverageBase:  roundToTwoDecimals(baseSum / float64(len(samples))),
+		AverageTotal: roundToTwoDecimals(totalSum / float64(len(samples))),
+		MedianBase:   roundToTwoDecimals(calculateMedianFloat(bases)),
+		MedianTotal:  roundToTwoDecimals(calculateMedianFloat(totals)),
+	}
+}
+
+// jobChange is the salary change between consecutive jobs of one user, as a
+// percentage of the previous job's final salary.
+type jobChange struct {
+	position   string
+	level      string
+	reason     string
+	percentage float64
+}
+
+func (s *AnalyticsService) calculateJobChangeAnalytics(data []model.JobChangeData) model.JobChangeAnalytics {
+	changes := s.collectJobChanges(data)
+	if len(changes) == 0 {
+		return model.JobChangeAnalytics{
+			ByPosition: []model.JobChangeBreakdown{},
+			ByLevel:    []model.JobChangeBreakdown{},
+		}
+	}
+
+	percentages := make([]float64, len(changes))
+	var withReason, salaryMotivated int
+	for i, change := range changes {
+		percentages[i] = change.percentage
+		if change.reason != "" {
+			withReason++
+			if change.reason == model.ChangeReasonSalary {
+				salaryMotivated++
 			}
 		}
 	}
 
-	averageIncrease := float64(0)
-	if withIncrease > 0 {
-		averageIncrease = totalIncrease / float64(withIncrease)
+	summary := summarizeJobChanges("", changes)
+	analytics := model.JobChangeAnalytics{
+		TotalChanges:           summary.Count,
+		AverageSalaryIncrease:  summary.AverageSalaryIncrease,
+		MedianSalaryIncrease:   roundToTwoDecimals(calculateMedianFloat(percentages)),
+		PercentageWithIncrease: summary.PercentageWithIncrease,
+		ByPosition:             jobChangeBreakdown(changes, func(c jobChange) string { return c.position }),
+		ByLevel:                jobChangeBreakdown(changes, func(c jobChange) string { return c.level }),
+	}
+	if withReason > 0 {
+		analytics.SalaryMotivatedPercentage = roundToTwoDecimals(float64(salaryMotivated) / float64(withReason) * 100)
 	}
+	return analytics
+}
 
-	percentageWithIncrease := float64(withIncrease) / float64(len(data)) * 100
+// collectJobChanges pairs consecutive jobs of each user. Both salaries are
+// normalized to net monthly and the new job's starting salary is converted to
+// the previous job's currency; changes between currencies without an exchange
+// rate are skipped.
+func (s *AnalyticsService) collectJobChanges(data []model.JobChangeData) []jobChange {
+	var changes []jobChange
+	for i := 1; i < len(data); i++ {
+		previous, next := data[i-1], data[i]
+		if previous.UserID != next.UserID {
+			continue
+		}
 
-	return model.JobChangeAnalytics{
-		AverageSalaryIncrease:  roundToTwoDecimals(averageIncrease),
-		PercentageWithIncrease: roundToTwoDecimals(percentageWithIncrease),
+		finalSalary, ok := s.normalizeSalary(float64(latestSalary(previous.SalaryMin, previous.Raises)),
+			previous.Currency, previous.SalaryBasis, salaryYear(previous.StartTime, previous.Raises), model.SalaryBasisNetMonthly)
+		if !ok || finalSalary <= 0 {
+			continue
+		}
+
+		startingSalary, ok := s.normalizeSalary(float64(next.SalaryMin),
+			next.Currency, next.SalaryBasis, next.StartTime.Year(), model.SalaryBasisNetMonthly)
+		if !ok {
+			continue
+		}
+
+		startingSalary, ok = s.exchangeRates.Convert(startingSalary, next.Currency, previous.Currency)
+		if !ok {
+			continue
+		}
+
+		changes = append(changes, jobChange{
+			position:   next.Position,
+			level:      next.Level,
+			reason:     next.ChangeReason,
+			percentage: (startingSalary - finalSalary) / finalSalary * 100,
+		})
 	}
+	return changes
 }
 
-func (s *AnalyticsService) calculateRaiseAnalytics(data []model.RaiseData) model.RaiseAnalytics {
-	if len(data) == 0 {
-		return model.RaiseAnalytics{}
+func jobChangeBreakdown(changes []jobChange, key func(jobChange) string) []model.JobChangeBreakdown {
+	groups := make(map[string][]jobChange)
+	for _, change := range changes {
+		if category := key(change); category != "" {
+			groups[category] = append(groups[category], change)
+		}
 	}
 
-	var totalRaises int
-	var totalPercentage float64
-	var timeBetweenRaises []int
+	breakdown := make([]model.JobChangeBreakdown, 0, len(groups))
+	for category, group := range groups {
+		breakdown = append(breakdown, summarizeJobChanges(category, group))
+	}
+	sort.Slice(breakdown, func(i, j int) bool {
+		return breakdown[i].Category < breakdown[j].Category
+	})
+	return breakdown
+}
+
+func summarizeJobChanges(category string, changes []jobChange) model.JobChangeBreakdown {
+	var total float64
+	var withIncrease int
+	for _, change := range changes {
+		total += change.percentage
+		if change.percentage > 0 {
+			withIncrease++
+		}
+	}
+
+	return model.JobChangeBreakdown{
+		Category:               category,
+		Count:                  len(changes),
+		AverageSalaryIncrease:  roundToTwoDecimals(total / float64(len(changes))),
+		PercentageWithIncrease: roundToTwoDecimals(float64(withIncrease) / float64(len(changes)) * 100),
+	}
+}
+
+// raiseEvent is one raise with the months since the previous raise, or since
+// the job started for the first one.
+type raiseEvent struct {
+	level       string
+	sector      string
+	companySize string
+	percentage  float64
+	months      int
+}
+
+// raiseIntervalBuckets are the upper bounds, in months, of the interval
+// distribution.
+var raiseIntervalBuckets = []struct {
+	label     string
+	maxMonths int
+}{
+	{"0-6", 6},
+	{"7-12", 12},
+	{"13-18", 18},
+	{"19-24", 24},
+	{"25+", math.MaxInt},
+}
+
+var raisePercentileRanks = []float64{0.1, 0.25, 0.5, 0.75, 0.9}
+
+// calculateRaiseAnalytics works on raise dates. Percentages are recomputed
+// from each job's starting salary so raises recorded before they were derived
+// on the server are counted the same way.
+func (s *AnalyticsService) calculateRaiseAnalytics(data []model.RaiseData) model.RaiseAnalytics {
+	var events []raiseEvent
 	var totalYears float64
 
 	for _, entry := range data {
-		if len(entry.Raises) == 0 {
+		raises := withRaisePercentages(entry.SalaryMin, entry.Raises)
+		if len(raises) == 0 {
 			continue
 		}
 
@@ -317,42 +804,128 @@ func (s *AnalyticsService) calculateRaiseAnalytics(data []model.RaiseData) model
 		if entry.EndTime != nil {
 			endTime = *entry.EndTime
 		}
-		years := endTime.Sub(entry.StartTime).Hours() / (24 * 365)
-		totalYears += years
+		totalYears += endTime.Sub(entry.StartTime).Hours() / (24 * 365)
+
+		previous := entry.StartTime
+		for _, raise := range raises {
+			events = append(events, raiseEvent{
+				level:       entry.Level,
+				sector:      entry.Company,
+				companySize: entry.CompanySize,
+				percentage:  raise.Percentage,
+				months:      monthsBetween(previous, raise.RaiseDate),
+			})
+			previous = raise.RaiseDate
+		}
+	}
 
-		totalRaises += len(entry.Raises)
+	analytics := model.RaiseAnalytics{
+		IntervalDistribution: raiseIntervalDistribution(events),
+		ByCompanySize:        raiseBreakdown(events, func(e raiseEvent) string { return e.companySize }),
+		BySector:             raiseBreakdown(events, func(e raiseEvent) string { return e.sector }),
+		ByLevel:              raiseBreakdown(events, func(e raiseEvent) string { return e.level }),
+	}
+	if len(events) == 0 {
+		return analytics
+	}
+
+	percentages := make([]float64, len(events))
+	months := make([]int, len(events))
+	var totalPercentage float64
+	for i, event := range events {
+		percentages[i] = event.percentage
+		months[i] = event.months
+		totalPercentage += event.percentage
+	}
+	sort.Float64s(percentages)
+
+	p := make([]float64, len(raisePercentileRanks))
+	for i, rank := range raisePercentileRanks {
+		p[i] = roundToTwoDecimals(percentile(percentages, rank))
+	}
 
-		for i, raise := range entry.Raises {
-			totalPercentage += raise.Percentage
+	analytics.TotalRaises = len(events)
+	if totalYears > 0 {
+		analytics.AveragePerYear = roundToTwoDecimals(float64(len(events)) / totalYears)
+	}
+	analytics.AveragePercentage = roundToTwoDecimals(totalPercentage / float64(len(events)))
+	analytics.PercentagePercentiles = model.RaisePercentiles{P10: p[0], P25: p[1], P50: p[2], P75: p[3], P90: p[4]}
+	analytics.MedianTimeBetweenRaises = calculateMedian(months)
+
+	return analytics
+}
+
+func raiseIntervalDistribution(events []raiseEvent) []model.RaiseIntervalBucket {
+	buckets := make([]model.RaiseIntervalBucket, len(raiseIntervalBuckets))
+	for i, bucket := range raiseIntervalBuckets {
+		buckets[i].Range = bucket.label
+	}
 
-			if i > 0 {
-				prevRaise := entry.Raises[i-1]
-				months := int(raise.CreatedAt.Sub(prevRaise.CreatedAt).Hours() / (24 * 30))
-				timeBetweenRaises = append(timeBetweenRaises, months)
-			} else {
-				months := int(raise.CreatedAt.Sub(entry.StartTime).Hours() / (24 * 30))
-				timeBetweenRaises = append(timeBetweenRaises, months)
+	for _, event := range events {
+		for i, bucket := range raiseIntervalBuckets {
+			if event.months <= bucket.maxMonths {
+				buckets[i].Count++
+				break
 			}
 		}
 	}
 
-	averagePerYear := float64(0)
-	if totalYears > 0 {
-		averagePerYear = float64(totalRaises) / totalYears
+	if len(events) > 0 {
+		for i := range buckets {
+			buckets[i].Percentage = roundToTwoDecimals(float64(buckets[i].Count) / float64(len(events)) * 100)
+		}
 	}
+	return buckets
+}
 
-	averagePercentage := float64(0)
-	if totalRaises > 0 {
-		averagePercentage = totalPercentage / float64(totalRaises)
+func raiseBreakdown(events []raiseEvent, key func(raiseEvent) string) []model.RaiseBreakdown {
+	groups := make(map[string][]raiseEvent)
+	for _, event := range events {
+		if category := key(event); category != "" {
+			groups[category] = append(groups[category], event)
+		}
+	}
+
+	breakdown := make([]model.RaiseBreakdown, 0, len(groups))
+	for category, group := range groups {
+		var totalPercentage float64
+		months := make([]int, len(group))
+		for i, event := range group {
+			totalPercentage += event.percentage
+			months[i] = event.months
+		}
+		breakdown = append(breakdown, model.RaiseBreakdown{
+			Category:                category,
+			Count:                   len(group),
+			AveragePercentage:       roundToTwoDecimals(totalPercentage / float64(len(group))),
+			MedianTimeBetweenRaises: calculateMedian(months),
+		})
 	}
 
-	medianTime := calculateMedian(timeBetweenRaises)
+	sort.Slice(breakdown, func(i, j int) bool {
+		return breakdown[i].Category < breakdown[j].Category
+	})
+	return breakdown
+}
+
+// monthsBetween counts whole 30-day months.
+func monthsBetween(from, to time.Time) int {
+	if !to.After(from) {
+		return 0
+	}
+	return int(to.Sub(from).Hours() / (24 * 30))
+}
 
-	return model.RaiseAnalytics{
-		AveragePerYear:          roundToTwoDecimals(averagePerYear),
-		AveragePercentage:       roundToTwoDecimals(averagePercentage),
-		MedianTimeBetweenRaises: medianTime,
+// percentile expects values in ascending order and interpolates between
+// neighbours.
+func percentile(sorted []float64, rank float64) float64 {
+	if len(sorted) == 0 {
+		return 0
 	}
+	position := rank * float64(len(sorted)-1)
+	lower := int(math.Floor(position))
+	upper := int(math.Ceil(position))
+	return sorted[lower] + (sorted[upper]-sorted[lower])*(position-float64(lower))
 }
 
 func calculateMedian(values []int) int {
@@ -370,16 +943,38 @@ func calculateMedian(values []int) int {
 	return (values[n/2-1] + values[n/2]) / 2
 }
 
+func calculateMedianFloat(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	n := len(sorted)
+
+	if n%2 == 1 {
+		return sorted[n/2]
+	}
+
+	return (sorted[n/2-1] + sorted[n/2]) / 2
+}
+
 func (s *AnalyticsService) GetAvailablePositions(ctx context.Context) ([]string, error) {
+	ctx, span := tracing.Start(ctx, "AnalyticsService.GetAvailablePositions")
+	defer span.End()
+
 	return s.analyticsRepo.GetAvailablePositions(ctx)
 }
 
 func (s *AnalyticsService) GetAvailableLevels(ctx context.Context) ([]string, error) {
+	ctx, span := tracing.Start(ctx, "AnalyticsService.GetAvailableLevels")
+	defer span.End()
+
 	return s.analyticsRepo.GetAvailableLevels(ctx)
 }
 
 func roundToTwoDecimals(value float64) float64 {
-	return float64(int(value*100+0.5)) / 100
+	return math.Round(value*100) / 100
 }
 
 func (s *AnalyticsService) buildTopPayingChart(data []model.SalaryByCategory, limit int) []model.ChartDataPoint {
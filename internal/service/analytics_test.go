@@ -0,0 +1,355 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/eminsonlu/salystic/internal/model"
+	"github.com/eminsonlu/salystic/internal/repo"
+	"github.com/eminsonlu/salystic/pkg/currency"
+	"github.com/eminsonlu/salystic/pkg/logging"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func newMemoryAnalyticsService(t *testing.T) (*AnalyticsService, repo.SalaryEntryRepository) {
+	store := repo.NewMemoryStore()
+	analyticsService := NewAnalyticsService(repo.NewMemoryAnalyticsRepository(store), currency.Rates{"TRY": 1, "USD": 40}, logging.NewNop())
+	t.Cleanup(analyticsService.Close)
+	return analyticsService, repo.NewMemorySalaryEntryRepository(store)
+}
+
+func TestAnalyticsService_GetGeneralAnalytics(t *testing.T) {
+	ctx := context.Background()
+	analyticsService, salaries := newMemoryAnalyticsService(t)
+
+	userID := primitive.NewObjectID()
+	for _, entry := range []*model.SalaryEntry{
+		{UserID: userID, Position: "Backend", Level: "Senior", Currency: "TRY", TechStack: []string{"Go"}, SalaryMin: 120000},
+		{UserID: userID, Position: "Backend", Level: "Junior", Currency: "TRY", TechStack: []string{"Go", "Redis"}, SalaryMin: 60000},
+		{UserID: userID, Position: "Frontend", Level: "Senior", Currency: "USD", TechStack: []string{"React"}, SalaryMin: 5000},
+	} {
+		require.NoError(t, salaries.Create(ctx, entry))
+	}
+
+	analytics, err := analyticsService.GetGeneralAnalytics(ctx, "", "", "TRY", "")
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(2), analytics.TotalEntries)
+	assert.Equal(t, float64(90000), analytics.AverageSalary)
+	assert.Equal(t, map[string]float64{"Backend": 90000}, analytics.AverageSalaryByPosition)
+	assert.Equal(t, map[string]float64{"Go": 90000, "Redis": 60000}, analytics.AverageSalaryByTech)
+	assert.Equal(t, float64(60000), analytics.MinSalaryByPosition["Backend"])
+	assert.Equal(t, float64(120000), analytics.MaxSalaryByPosition["Backend"])
+}
+
+func TestAnalyticsService_GetGeneralAnalyticsKeepsSalaryBasesApart(t *testing.T) {
+	ctx := context.Background()
+	analyticsService, salaries := newMemoryAnalyticsService(t)
+
+	userID := primitive.NewObjectID()
+	for _, entry := range []*model.SalaryEntry{
+		{UserID: userID, Position: "Backend", Level: "Senior", Currency: "TRY", SalaryBasis: model.SalaryBasisNetMonthly, TechStack: []string{"Go"}, SalaryMin: 100000},
+		{UserID: userID, Position: "Backend", Level: "Senior", Currency: "TRY", SalaryBasis: model.SalaryBasisNetMonthly, TechStack: []string{"Go"}, SalaryMin: 60000},
+		{UserID: userID, Position: "Backend", Level: "Senior", Currency: "TRY", SalaryBasis: model.SalaryBasisGrossAnnual, TechStack: []string{"Go"}, SalaryMin: 2400000},
+	} {
+		require.NoError(t, salaries.Create(ctx, entry))
+	}
+
+	netMonthly, err := analyticsService.GetGeneralAnalytics(ctx, "", "", "TRY", "")
+	require.NoError(t, err)
+	assert.Equal(t, model.SalaryBasisNetMonthly, netMonthly.SalaryBasis)
+	assert.Equal(t, int64(2), netMonthly.TotalEntries)
+	assert.Equal(t, float64(80000), netMonthly.AverageSalary)
+	assert.Equal(t, map[string]float64{"Go": 80000}, netMonthly.AverageSalaryByTech)
+	assert.Equal(t, float64(100000), netMonthly.MaxSalaryByPosition["Backend"])
+	assert.InDelta(t, 96000, netMonthly.SalaryPercentiles.P90, 1e-9)
+
+	grossAnnual, err := analyticsService.GetGeneralAnalytics(ctx, "", "", "TRY", model.SalaryBasisGrossAnnual)
+	require.NoError(t, err)
+	assert.Equal(t, model.SalaryBasisGrossAnnual, grossAnnual.SalaryBasis)
+	assert.Equal(t, int64(1), grossAnnual.TotalEntries)
+	assert.Equal(t, float64(2400000), grossAnnual.AverageSalary)
+	assert.Equal(t, map[string]float64{"Go": 2400000}, grossAnnual.AverageSalaryByTech)
+
+	detailed, err := analyticsService.GetDetailedAnalytics(ctx, "", "", "TRY", "")
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), detailed.TotalEntries)
+	assert.Equal(t, []model.SalaryByPositionLevel{
+		{Position: "Backend", Level: "Senior", Average: 80000, Min: 60000, Max: 100000, Count: 2},
+	}, detailed.SalaryByPositionAndLevel)
+	assert.InDelta(t, 96000, detailed.PercentilesByPosition["Backend"].P90, 1e-9)
+}
+
+func TestAnalyticsService_GetGeneralAnalyticsIsCached(t *testing.T) {
+	ctx := context.Background()
+	analyticsService, salaries := newMemoryAnalyticsService(t)
+
+	first, err := analyticsService.GetGeneralAnalytics(ctx, "", "", "", "")
+	require.NoError(t, err)
+	assert.Zero(t, first.TotalEntries)
+
+	require.NoError(t, salaries.Create(ctx, &model.SalaryEntry{Position: "Backend", Currency: "TRY", SalaryMin: 1000}))
+
+	second, err := analyticsService.GetGeneralAnalytics(ctx, "", "", "", "")
+	require.NoError(t, err)
+	assert.Zero(t, second.TotalEntries)
+	assert.Equal(t, uint64(1), analyticsService.GeneralCacheStats().Hits)
+}
+
+func TestAnalyticsService_GetDetailedAnalytics(t *testing.T) {
+	ctx := context.Background()
+	analyticsService, salaries := newMemoryAnalyticsService(t)
+
+	userID := primitive.NewObjectID()
+	for _, entry := range []*model.SalaryEntry{
+		{UserID: userID, Position: "Backend", Level: "Senior", Currency: "TRY", SalaryMin: 120000},
+		{UserID: userID, Position: "Backend", Level: "Senior", Currency: "TRY", SalaryMin: 100000},
+		{UserID: userID, Position: "Backend", Level: "Junior", Currency: "TRY", SalaryMin: 60000},
+		{UserID: userID, Position: "Frontend", Level: "Senior", Currency: "USD", SalaryMin: 5000},
+	} {
+		require.NoError(t, salaries.Create(ctx, entry))
+	}
+
+	detailed, err := analyticsService.GetDetailedAnalytics(ctx, "", "", "TRY", "")
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(3), detailed.TotalEntries)
+	assert.Equal(t, []model.SalaryByPositionLevel{
+		{Position: "Backend", Level: "Junior", Average: 60000, Min: 60000, Max: 60000, Count: 1},
+		{Position: "Backend", Level: "Senior", Average: 110000, Min: 100000, Max: 120000, Count: 2},
+	}, detailed.SalaryByPositionAndLevel)
+	assert.Equal(t, map[string]model.SalaryPercentiles{
+		"Backend": {P25: 80000, P50: 100000, P75: 110000, P90: 116000},
+	}, detailed.PercentilesByPosition)
+
+	cached, err := analyticsService.GetDetailedAnalytics(ctx, "", "", "TRY", "")
+	require.NoError(t, err)
+	assert.Same(t, detailed, cached)
+	assert.Equal(t, uint64(1), analyticsService.DetailedCacheStats().Hits)
+}
+
+func TestAnalyticsService_EntryStats(t *testing.T) {
+	ctx := context.Background()
+	analyticsService, salaries := newMemoryAnalyticsService(t)
+
+	for _, currency := range []string{"TRY", "TRY", "EUR"} {
+		require.NoError(t, salaries.Create(ctx, &model.SalaryEntry{Currency: currency, StartTime: time.Now()}))
+	}
+
+	stats, err := analyticsService.EntryStats(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), stats.Total)
+	assert.Equal(t, map[string]int64{"TRY": 2, "EUR": 1}, stats.ByCurrency)
+}
+
+func TestAnalyticsService_GetCareerAnalytics_JobChanges(t *testing.T) {
+	ctx := context.Background()
+	analyticsService, salaries := newMemoryAnalyticsService(t)
+
+	alice, bob, carol := primitive.NewObjectID(), primitive.NewObjectID(), primitive.NewObjectID()
+	entries := []*model.SalaryEntry{
+		{UserID: alice, Position: "Backend", Level: "Junior", Currency: "TRY", SalaryMin: 100, StartTime: date(2019, 1),
+			Raises: []model.Raise{{RaiseDate: date(2020, 1), NewSalary: 200}}},
+		{UserID: alice, Position: "Backend", Level: "Senior", Currency: "TRY", SalaryMin: 300, StartTime: date(2021, 1), ChangeReason: model.ChangeReasonSalary},
+		{UserID: alice, Position: "Freelance", Currency: "USD", SalaryMin: 1, StartTime: date(2022, 1), Concurrent: true},
+		{UserID: alice, Position: "Backend", Level: "Lead", Currency: "USD", SalaryMin: 10, StartTime: date(2023, 1), ChangeReason: model.ChangeReasonCareerGrowth},
+		{UserID: bob, Position: "Frontend", Level: "Middle", Currency: "TRY", SalaryMin: 100, StartTime: date(2020, 1)},
+		{UserID: bob, Position: "Frontend", Level: "Senior", Currency: "TRY", SalaryMin: 90, StartTime: date(2021, 1)},
+		{UserID: carol, Position: "Data", Level: "Senior", Currency: "TRY", SalaryMin: 100, StartTime: date(2020, 1)},
+		{UserID: carol, Position: "Data", Level: "Senior", Currency: "EUR", SalaryMin: 5, StartTime: date(2021, 1), ChangeReason: model.ChangeReasonRelocation},
+	}
+	for _, entry := range entries {
+		require.NoError(t, salaries.Create(ctx, entry))
+	}
+
+	analytics, err := analyticsService.GetCareerAnalytics(ctx)
+	require.NoError(t, err)
+
+	jobChanges := analytics.JobChanges
+	assert.Equal(t, 3, jobChanges.TotalChanges, "the EUR change has no exchange rate")
+	assert.Equal(t, 24.44, jobChanges.AverageSalaryIncrease)
+	assert.Equal(t, 33.33, jobChanges.MedianSalaryIncrease)
+	assert.Equal(t, 66.67, jobChanges.PercentageWithIncrease)
+	assert.Equal(t, 50.0, jobChanges.SalaryMotivatedPercentage)
+
+	assert.Equal(t, []model.JobChangeBreakdown{
+		{Category: "Backend", Count: 2, AverageSalaryIncrease: 41.67, PercentageWithIncrease: 100},
+		{Category: "Frontend", Count: 1, AverageSalaryIncrease: -10, PercentageWithIncrease: 0},
+	}, jobChanges.ByPosition)
+	assert.Equal(t, []model.JobChangeBreakdown{
+		{Category: "Lead", Count: 1, AverageSalaryIncrease: 33.33, PercentageWithIncrease: 100},
+		{Category: "Senior", Count: 2, AverageSalaryIncrease: 20, PercentageWithIncrease: 50},
+	}, jobChanges.ByLevel)
+}
+
+func TestAnalyticsService_GetCareerAnalytics_JobChangesAcrossSalaryBases(t *testing.T) {
+	ctx := context.Background()
+	analyticsService, salaries := newMemoryAnalyticsService(t)
+
+	// A gross 1200 a year below the minimum wage is 85 net a month.
+	userID := primitive.NewObjectID()
+	for _, entry := range []*model.SalaryEntry{
+		{UserID: userID, Position: "Backend", Level: "Senior", Currency: "TRY", SalaryBasis: model.SalaryBasisGrossAnnual, SalaryMin: 1200, StartTime: date(2024, 1)},
+		{UserID: userID, Position: "Backend", Level: "Senior", Currency: "TRY", SalaryBasis: model.SalaryBasisNetMonthly, SalaryMin: 85, StartTime: date(2025, 1)},
+	} {
+		require.NoError(t, salaries.Create(ctx, entry))
+	}
+
+	analytics, err := analyticsService.GetCareerAnalytics(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, analytics.JobChanges.TotalChanges)
+	assert.Zero(t, analytics.JobChanges.AverageSalaryIncrease)
+}
+
+func TestAnalyticsService_GetCareerAnalytics_NoJobChanges(t *testing.T) {
+	analyticsService, _ := newMemoryAnalyticsService(t)
+
+	analytics, err := analyticsService.GetCareerAnalytics(context.Background())
+	require.NoError(t, err)
+	assert.Zero(t, analytics.JobChanges.TotalChanges)
+	assert.NotNil(t, analytics.JobChanges.ByPosition)
+	assert.NotNil(t, analytics.JobChanges.ByLevel)
+}
+
+func TestAnalyticsService_GetCareerAnalytics_Raises(t *testing.T) {
+	ctx := context.Background()
+	analyticsService, salaries := newMemoryAnalyticsService(t)
+
+	seniorEnd, juniorEnd := date(2023, 1), date(2022, 1)
+	for _, entry := range []*model.SalaryEntry{
+		{
+			UserID: primitive.NewObjectID(), Level: "Senior", Company: "Fintech", CompanySize: "51 - 100 Kişi", Currency: "TRY",
+			SalaryMin: 100, StartTime: date(2020, 1), EndTime: &seniorEnd,
+			// Stored out of order and with client-supplied percentages.
+			Raises: []model.Raise{
+				{RaiseDate: date(2022, 1), NewSalary: 150, Percentage: 99},
+				{RaiseDate: date(2021, 1), NewSalary: 120, Percentage: 99},
+			},
+		},
+		{
+			UserID: primitive.NewObjectID(), Level: "Junior", Company: "E-commerce", CompanySize: "6 - 10 Kişi", Currency: "TRY",
+			SalaryMin: 100, StartTime: date(2020, 1), EndTime: &juniorEnd,
+			Raises: []model.Raise{
+				{RaiseDate: date(2020, 4), NewSalary: 110},
+				{RaiseDate: date(2021, 10), NewSalary: 121},
+			},
+		},
+	} {
+		require.NoError(t, salaries.Create(ctx, entry))
+	}
+
+	analytics, err := analyticsService.GetCareerAnalytics(ctx)
+	require.NoError(t, err)
+
+	raises := analytics.Raises
+	assert.Equal(t, 4, raises.TotalRaises)
+	assert.Equal(t, 0.8, raises.AveragePerYear)
+	assert.Equal(t, 16.25, raises.AveragePercentage)
+	assert.Equal(t, model.RaisePercentiles{P10: 10, P25: 10, P50: 15, P75: 21.25, P90: 23.5}, raises.PercentagePercentiles)
+	assert.Equal(t, 12, raises.MedianTimeBetweenRaises)
+
+	assert.Equal(t, []model.RaiseIntervalBucket{
+		{Range: "0-6", Count: 1, Percentage: 25},
+		{Range: "7-12", Count: 2, Percentage: 50},
+		{Range: "13-18", Count: 1, Percentage: 25},
+		{Range: "19-24", Count: 0, Percentage: 0},
+		{Range: "25+", Count: 0, Percentage: 0},
+	}, raises.IntervalDistribution)
+
+	assert.Equal(t, []model.RaiseBreakdown{
+		{Category: "Junior", Count: 2, AveragePercentage: 10, MedianTimeBetweenRaises: 10},
+		{Category: "Senior", Count: 2, AveragePercentage: 22.5, MedianTimeBetweenRaises: 12},
+	}, raises.ByLevel)
+	assert.Equal(t, []string{"E-commerce", "Fintech"}, []string{raises.BySector[0].Category, raises.BySector[1].Category})
+	assert.Equal(t, []string{"51 - 100 Kişi", "6 - 10 Kişi"}, []string{raises.ByCompanySize[0].Category, raises.ByCompanySize[1].Category})
+}
+
+func TestAnalyticsService_GetCompensationAnalytics(t *testing.T) {
+	ctx := context.Background()
+	analyticsService, salaries := newMemoryAnalyticsService(t)
+
+	userID := primitive.NewObjectID()
+	for _, entry := range []*model.SalaryEntry{
+		{
+			UserID: userID, Position: "Backend", Level: "Senior", Currency: "TRY", SalaryBasis: model.SalaryBasisGrossMonthly, SalaryMin: 100,
+			Raises: []model.Raise{{RaiseDate: date(2021, 1), NewSalary: 200}},
+			Compensation: &model.Compensation{
+				Bonus:           &model.Bonus{Months: 2},
+				Equity:          &model.EquityGrant{Type: model.EquityTypeRSU, GrantValue: 4800, VestingMonths: 48},
+				MealCard:        50,
+				HealthInsurance: &model.HealthInsurance{MonthlyValue: 50},
+				OtherBenefits:   []model.Benefit{{Name: "Gym", MonthlyValue: 25}},
+			},
+		},
+		{
+			UserID: userID, Position: "Backend", Level: "Junior", Currency: "USD", SalaryBasis: model.SalaryBasisGrossMonthly, SalaryMin: 10,
+			Compensation: &model.Compensation{Bonus: &model.Bonus{Amount: 30}},
+		},
+		{UserID: userID, Position: "Frontend", Level: "Senior", Currency: "TRY", SalaryBasis: model.SalaryBasisGrossMonthly, SalaryMin: 100},
+		{UserID: userID, Position: "Frontend", Level: "Senior", Currency: "EUR", SalaryBasis: model.SalaryBasisGrossMonthly, SalaryMin: 100},
+	} {
+		require.NoError(t, salaries.Create(ctx, entry))
+	}
+
+	analytics, err := analyticsService.GetCompensationAnalytics(ctx, "TRY", model.SalaryBasisGrossAnnual)
+	require.NoError(t, err)
+
+	assert.Equal(t, "TRY", analytics.Currency)
+	assert.Equal(t, model.SalaryBasisGrossAnnual, analytics.Basis)
+	assert.Equal(t, 3, analytics.TotalEntries, "the EUR entry has no exchange rate")
+	assert.Equal(t, 2800.0, analytics.AverageBase)
+	assert.Equal(t, 4233.33, analytics.AverageTotal)
+	assert.Equal(t, 66.67, analytics.WithBonusPercentage)
+	assert.Equal(t, 33.33, analytics.WithEquityPercentage)
+	assert.Equal(t, 33.33, analytics.WithMealCardPercentage)
+	assert.Equal(t, 33.33, analytics.WithHealthInsurancePercentage)
+
+	assert.Equal(t, []model.CompensationCohort{
+		{Category: "Backend", Count: 2, AverageBase: 3600, AverageTotal: 5750, MedianBase: 3600, MedianTotal: 5750},
+		{Category: "Frontend", Count: 1, AverageBase: 1200, AverageTotal: 1200, MedianBase: 1200, MedianTotal: 1200},
+	}, analytics.ByPosition)
+	assert.Equal(t, []model.CompensationCohort{
+		{Category: "Junior", Count: 1, AverageBase: 4800, AverageTotal: 6000, MedianBase: 4800, MedianTotal: 6000},
+		{Category: "Senior", Count: 2, AverageBase: 1800, AverageTotal: 3350, MedianBase: 1800, MedianTotal: 3350},
+	}, analytics.ByLevel)
+	assert.Empty(t, analytics.ByExperience)
+
+	inUSD, err := analyticsService.GetCompensationAnalytics(ctx, "USD", model.SalaryBasisGrossAnnual)
+	require.NoError(t, err)
+	assert.Equal(t, 70.0, inUSD.AverageBase)
+
+	// Below the minimum wage only SGK and unemployment contributions apply.
+	netMonthly, err := analyticsService.GetCompensationAnalytics(ctx, "TRY", model.SalaryBasisNetMonthly)
+	require.NoError(t, err)
+	assert.Equal(t, []model.CompensationCohort{
+		{Category: "Junior", Count: 1, AverageBase: 340, AverageTotal: 440, MedianBase: 340, MedianTotal: 440},
+		{Category: "Senior", Count: 2, AverageBase: 127.5, AverageTotal: 254.17, MedianBase: 127.5, MedianTotal: 254.17},
+	}, netMonthly.ByLevel)
+}
+
+func TestAnalyticsService_NormalizeSalary(t *testing.T) {
+	analyticsService, _ := newMemoryAnalyticsService(t)
+
+	net, ok := analyticsService.normalizeSalary(100000, "TRY", model.SalaryBasisGrossMonthly, 2025, model.SalaryBasisNetMonthly)
+	require.True(t, ok)
+	assert.Less(t, net, 75004.08, "income tax rises through the year")
+
+	gross, ok := analyticsService.normalizeSalary(net*12, "TRY", model.SalaryBasisNetAnnual, 2025, model.SalaryBasisGrossAnnual)
+	require.True(t, ok)
+	assert.InDelta(t, 1200000, gross, 1)
+
+	inUSD, ok := analyticsService.normalizeSalary(2500, "USD", model.SalaryBasisGrossMonthly, 2025, model.SalaryBasisNetMonthly)
+	require.True(t, ok)
+	assert.InDelta(t, net/40, inUSD, 0.01)
+
+	unchanged, ok := analyticsService.normalizeSalary(1000, "EUR", "", 2025, model.SalaryBasisNetMonthly)
+	require.True(t, ok)
+	assert.Equal(t, 1000.0, unchanged)
+
+	_, ok = analyticsService.normalizeSalary(1000, "EUR", model.SalaryBasisGrossMonthly, 2025, model.SalaryBasisNetMonthly)
+	assert.False(t, ok, "gross to net needs a TRY exchange rate")
+}
@@ -0,0 +1,206 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/eminsonlu/salystic/internal/auth"
+	"github.com/eminsonlu/salystic/internal/model"
+	"github.com/eminsonlu/salystic/internal/repo"
+	"github.com/eminsonlu/salystic/pkg/tracing"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	defaultAPIKeyRateLimit = 600
+
+	// apiKeyUsageFlushInterval bounds how often a key's usage counter and
+	// last_used_at are written, so authenticating doesn't hit the database on
+	// every request.
+	apiKeyUsageFlushInterval = time.Minute
+)
+
+type APIKeyService interface {
+	CreateKey(ctx context.Context, createdBy string, req *model.CreateAPIKeyRequest) (*model.CreatedAPIKey, error)
+	ListKeys(ctx context.Context) ([]*model.APIKey, error)
+	RevokeKey(ctx context.Context, keyID string) error
+	Authenticate(ctx context.Context, rawKey string) (*model.APIKey, error)
+	Close()
+}
+
+type apiKeyUsage struct {
+	count  int64
+	usedAt time.Time
+}
+
+type apiKeyService struct {
+	apiKeyRepo repo.APIKeyRepository
+	logger     *slog.Logger
+
+	usageMu sync.Mutex
+	usage   map[primitive.ObjectID]*apiKeyUsage
+	stop    chan struct{}
+	done    chan struct{}
+}
+
+// NewAPIKeyService starts writing buffered key usage every
+// apiKeyUsageFlushInterval until Close is called.
+func NewAPIKeyService(apiKeyRepo repo.APIKeyRepository, logger *slog.Logger) APIKeyService {
+	s := &apiKeyService{
+		apiKeyRepo: apiKeyRepo,
+		logger:     logger,
+		usage:      make(map[primitive.ObjectID]*apiKeyUsage),
+		stop:       make(chan struct{}),
+		done:       make(chan struct{}),
+	}
+	go s.runUsageFlush(apiKeyUsageFlushInterval)
+	return s
+}
+
+// Close stops the background flush and writes any usage still buffered.
+func (s *apiKeyService) Close() {
+	close(s.stop)
+	<-s.done
+}
+
+func (s *apiKeyService) CreateKey(ctx context.Context, createdBy string, req *model.CreateAPIKeyRequest) (*model.CreatedAPIKey, error) {
+	ctx, span := tracing.Start(ctx, "APIKeyService.CreateKey")
+	defer span.End()
+
+	if req.ExpiresAt != nil && req.ExpiresAt.Before(time.Now()) {
+		return nil, fmt.Errorf("expiry must be in the future")
+	}
+
+	rawKey, prefix, err := auth.GenerateAPIKey()
+	if err != nil {
+		return nil, err
+	}
+
+	rateLimit := req.RateLimit
+	if rateLimit == 0 {
+		rateLimit = defaultAPIKeyRateLimit
+	}
+
+	key := &model.APIKey{
+		Name:      req.Name,
+		Prefix:    prefix,
+		KeyHash:   auth.HashAPIKey(rawKey),
+		Scopes:    req.Scopes,
+		RateLimit: rateLimit,
+		CreatedBy: createdBy,
+		ExpiresAt: req.ExpiresAt,
+	}
+
+	if err := s.apiKeyRepo.Create(ctx, key); err != nil {
+		return nil, fmt.Errorf("failed to create API key: %w", err)
+	}
+	s.logger.InfoContext(ctx, "API key created", "key_id", key.ID.Hex(), "prefix", key.Prefix, "scopes", key.Scopes, "created_by", createdBy)
+
+	return &model.CreatedAPIKey{
+		APIKey: key,
+		Key:    rawKey,
+	}, nil
+}
+
+func (s *apiKeyService) ListKeys(ctx context.Context) ([]*model.APIKey, error) {
+	ctx, span := tracing.Start(ctx, "APIKeyService.ListKeys")
+	defer span.End()
+
+	keys, err := s.apiKeyRepo.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list API keys: %w", err)
+	}
+	return keys, nil
+}
+
+func (s *apiKeyService) RevokeKey(ctx context.Context, keyID string) error {
+	ctx, span := tracing.Start(ctx, "APIKeyService.RevokeKey")
+	defer span.End()
+
+	id, err := primitive.ObjectIDFromHex(keyID)
+	if err != nil {
+		return fmt.Errorf("api key not found")
+	}
+
+	if err := s.apiKeyRepo.Revoke(ctx, id); err != nil {
+		return err
+	}
+
+	s.logger.InfoContext(ctx, "API key revoked", "key_id", keyID)
+	return nil
+}
+
+func (s *apiKeyService) Authenticate(ctx context.Context, rawKey string) (*model.APIKey, error) {
+	ctx, span := tracing.Start(ctx, "APIKeyService.Authenticate")
+	defer span.End()
+
+	key, err := s.apiKeyRepo.GetByHash(ctx, auth.HashAPIKey(rawKey))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get API key: %w", err)
+	}
+
+	if key == nil {
+		return nil, fmt.Errorf("invalid api key")
+	}
+
+	if key.RevokedAt != nil {
+		return nil, fmt.Errorf("api key revoked")
+	}
+
+	if key.ExpiresAt != nil && key.ExpiresAt.Before(time.Now()) {
+		return nil, fmt.Errorf("api key expired")
+	}
+
+	s.trackUsage(key.ID, time.Now())
+
+	return key, nil
+}
+
+func (s *apiKeyService) trackUsage(id primitive.ObjectID, usedAt time.Time) {
+	s.usageMu.Lock()
+	defer s.usageMu.Unlock()
+
+	usage, ok := s.usage[id]
+	if !ok {
+		usage = &apiKeyUsage{}
+		s.usage[id] = usage
+	}
+	usage.count++
+	usage.usedAt = usedAt
+}
+
+// flushUsage writes the usage buffered since the last flush. Usage that fails
+// to be written is logged and dropped rather than retried.
+func (s *apiKeyService) flushUsage(ctx context.Context) {
+	s.usageMu.Lock()
+	pending := s.usage
+	s.usage = make(map[primitive.ObjectID]*apiKeyUsage)
+	s.usageMu.Unlock()
+
+	for id, usage := range pending {
+		if err := s.apiKeyRepo.RecordUsage(ctx, id, usage.count, usage.usedAt); err != nil {
+			s.logger.ErrorContext(ctx, "Failed to record API key usage", "key_id", id.Hex(), "count", usage.count, "error", err)
+		}
+	}
+}
+
+func (s *apiKeyService) runUsageFlush(interval time.Duration) {
+	defer close(s.done)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			s.flushUsage(context.Background())
+		case <-s.stop:
+			s.flushUsage(context.Background())
+			return
+		}
+	}
+}
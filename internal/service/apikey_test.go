@@ -0,0 +1,143 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/eminsonlu/salystic/internal/auth"
+	"github.com/eminsonlu/salystic/internal/model"
+	"github.com/eminsonlu/salystic/pkg/logging"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type MockAPIKeyRepository struct {
+	mock.Mock
+}
+
+func (m *MockAPIKeyRepository) Create(ctx context.Context, key *model.APIKey) error {
+	args := m.Called(ctx, key)
+	return args.Error(0)
+}
+
+func (m *MockAPIKeyRepository) GetByHash(ctx context.Context, keyHash string) (*model.APIKey, error) {
+	args := m.Called(ctx, keyHash)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.APIKey), args.Error(1)
+}
+
+func (m *MockAPIKeyRepository) List(ctx context.Context) ([]*model.APIKey, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*model.APIKey), args.Error(1)
+}
+
+func (m *MockAPIKeyRepository) Revoke(ctx context.Context, id primitive.ObjectID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockAPIKeyRepository) RecordUsage(ctx context.Context, id primitive.ObjectID, count int64, usedAt time.Time) error {
+	args := m.Called(ctx, id, count, usedAt)
+	return args.Error(0)
+}
+
+func TestAPIKeyService_CreateKey(t *testing.T) {
+	mockRepo := &MockAPIKeyRepository{}
+	service := NewAPIKeyService(mockRepo, logging.NewNop())
+
+	mockRepo.On("Create", mock.Anything, mock.AnythingOfType("*model.APIKey")).Return(nil)
+
+	req := &model.CreateAPIKeyRequest{
+		Name:   "dashboards",
+		Scopes: []string{model.ScopeReadAnalytics},
+	}
+
+	created, err := service.CreateKey(context.Background(), "admin_id", req)
+
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(created.Key, "slk_"))
+	assert.True(t, strings.HasPrefix(created.Key, created.APIKey.Prefix))
+	assert.Equal(t, auth.HashAPIKey(created.Key), created.APIKey.KeyHash)
+	assert.NotContains(t, created.APIKey.KeyHash, created.Key)
+	assert.Equal(t, defaultAPIKeyRateLimit, created.APIKey.RateLimit)
+	assert.Equal(t, "admin_id", created.APIKey.CreatedBy)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestAPIKeyService_CreateKey_PastExpiry(t *testing.T) {
+	service := NewAPIKeyService(&MockAPIKeyRepository{}, logging.NewNop())
+
+	expiresAt := time.Now().Add(-time.Hour)
+	req := &model.CreateAPIKeyRequest{
+		Name:      "dashboards",
+		Scopes:    []string{model.ScopeReadAnalytics},
+		ExpiresAt: &expiresAt,
+	}
+
+	created, err := service.CreateKey(context.Background(), "admin_id", req)
+
+	assert.Error(t, err)
+	assert.Nil(t, created)
+}
+
+func TestAPIKeyService_Authenticate(t *testing.T) {
+	mockRepo := &MockAPIKeyRepository{}
+	service := NewAPIKeyService(mockRepo, logging.NewNop())
+
+	key := &model.APIKey{ID: primitive.NewObjectID(), Scopes: []string{model.ScopeReadAnalytics}}
+	mockRepo.On("GetByHash", mock.Anything, auth.HashAPIKey("slk_valid")).Return(key, nil)
+	mockRepo.On("RecordUsage", mock.Anything, key.ID, int64(3), mock.AnythingOfType("time.Time")).Return(nil).Once()
+
+	for i := 0; i < 3; i++ {
+		result, err := service.Authenticate(context.Background(), "slk_valid")
+		assert.NoError(t, err)
+		assert.Equal(t, key, result)
+	}
+	mockRepo.AssertNotCalled(t, "RecordUsage", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+
+	service.Close()
+	mockRepo.AssertExpectations(t)
+}
+
+func TestAPIKeyService_Authenticate_Rejected(t *testing.T) {
+	revokedAt := time.Now().Add(-time.Minute)
+	expiredAt := time.Now().Add(-time.Minute)
+
+	tests := []struct {
+		name string
+		key  *model.APIKey
+	}{
+		{"unknown", nil},
+		{"revoked", &model.APIKey{ID: primitive.NewObjectID(), RevokedAt: &revokedAt}},
+		{"expired", &model.APIKey{ID: primitive.NewObjectID(), ExpiresAt: &expiredAt}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := &MockAPIKeyRepository{}
+			service := NewAPIKeyService(mockRepo, logging.NewNop())
+
+			if tt.key == nil {
+				mockRepo.On("GetByHash", mock.Anything, mock.Anything).Return(nil, nil)
+			} else {
+				mockRepo.On("GetByHash", mock.Anything, mock.Anything).Return(tt.key, nil)
+			}
+
+			result, err := service.Authenticate(context.Background(), "slk_key")
+
+			assert.Error(t, err)
+			assert.Nil(t, result)
+			service.Close()
+			mockRepo.AssertNotCalled(t, "RecordUsage", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
@@ -3,41 +3,61 @@ package service
 import (
 	"context"
 	"fmt"
+	"log/slog"
+	"time"
+
 	"github.com/eminsonlu/salystic/internal/auth"
 	"github.com/eminsonlu/salystic/internal/model"
 	"github.com/eminsonlu/salystic/internal/repo"
+	"github.com/eminsonlu/salystic/pkg/tracing"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 type AuthService interface {
-	GetLinkedInAuthURL(state string) string
-	AuthenticateWithLinkedIn(ctx context.Context, code string) (*model.AuthResponse, error)
+	GetLinkedInAuthURL(state, codeVerifier string) string
+	AuthenticateWithLinkedIn(ctx context.Context, code, codeVerifier string, metadata *model.SessionMetadata) (*model.AuthResponse, error)
+	IssueHandoffCode(authResponse *model.AuthResponse) (string, error)
+	RedeemHandoffCode(code string) (*model.AuthResponse, error)
 	ValidateToken(tokenString string) (*model.JWTClaims, error)
+	ValidateSession(ctx context.Context, claims *model.JWTClaims) error
 	GetUserByID(ctx context.Context, userID string) (*model.User, error)
-	Logout(ctx context.Context, userID string) error
+	ListSessions(ctx context.Context, userID, currentSessionID string) ([]*model.Session, error)
+	RevokeSession(ctx context.Context, userID, sessionID string) error
+	Logout(ctx context.Context, userID, sessionID string) error
 }
 
+const sessionTouchInterval = time.Minute
+
 type authService struct {
 	userRepo      repo.UserRepository
+	sessionRepo   repo.SessionRepository
 	linkedinOAuth *auth.LinkedInOAuth
 	jwtManager    *auth.JWTManager
+	handoffStore  *auth.HandoffStore
+	logger        *slog.Logger
 }
 
-func NewAuthService(userRepo repo.UserRepository, linkedinOAuth *auth.LinkedInOAuth, jwtManager *auth.JWTManager) AuthService {
+func NewAuthService(userRepo repo.UserRepository, sessionRepo repo.SessionRepository, linkedinOAuth *auth.LinkedInOAuth, jwtManager *auth.JWTManager, handoffStore *auth.HandoffStore, logger *slog.Logger) AuthService {
 	return &authService{
 		userRepo:      userRepo,
+		sessionRepo:   sessionRepo,
 		linkedinOAuth: linkedinOAuth,
 		jwtManager:    jwtManager,
+		handoffStore:  handoffStore,
+		logger:        logger,
 	}
 }
 
-func (s *authService) GetLinkedInAuthURL(state string) string {
-	return s.linkedinOAuth.GetAuthURL(state)
+func (s *authService) GetLinkedInAuthURL(state, codeVerifier string) string {
+	return s.linkedinOAuth.GetAuthURL(state, codeVerifier)
 }
 
-func (s *authService) AuthenticateWithLinkedIn(ctx context.Context, code string) (*model.AuthResponse, error) {
-	token, err := s.linkedinOAuth.ExchangeCodeForToken(ctx, code)
+func (s *authService) AuthenticateWithLinkedIn(ctx context.Context, code, codeVerifier string, metadata *model.SessionMetadata) (*model.AuthResponse, error) {
+	ctx, span := tracing.Start(ctx, "AuthService.AuthenticateWithLinkedIn")
+	defer span.End()
+
+	token, err := s.linkedinOAuth.ExchangeCodeForToken(ctx, code, codeVerifier)
 	if err != nil {
 		return nil, fmt.Errorf("failed to exchange code for token: %w", err)
 	}
@@ -49,26 +69,32 @@ func (s *authService) AuthenticateWithLinkedIn(ctx context.Context, code string)
 
 	pseudonymizedID := s.linkedinOAuth.PseudonymizeLinkedInID(profile.Sub)
 
-	user, err := s.userRepo.GetByPseudonymizedID(ctx, pseudonymizedID)
+	user, created, err := s.userRepo.UpsertByPseudonymizedID(ctx, pseudonymizedID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get user by pseudonymized ID: %w", err)
+		return nil, fmt.Errorf("failed to upsert user: %w", err)
+	}
+	if created {
+		s.logger.InfoContext(ctx, "User registered", "user_id", user.ID.Hex())
 	}
 
-	if user == nil {
-		user = &model.User{
-			PseudonymizedID: pseudonymizedID,
+	session := &model.Session{
+		ID:        primitive.NewObjectID(),
+		UserID:    user.ID,
+		ExpiresAt: time.Now().Add(s.jwtManager.Expiry()),
+	}
+	if metadata != nil {
+		session.UserAgent = metadata.UserAgent
+		if metadata.IPAddress != "" {
+			session.IPHash = s.jwtManager.HashClientIP(metadata.IPAddress)
 		}
+	}
 
-		if err := s.userRepo.Create(ctx, user); err != nil {
-			return nil, fmt.Errorf("failed to create user: %w", err)
-		}
-	} else {
-		if err := s.userRepo.UpdateLastLogin(ctx, user.ID); err != nil {
-			return nil, fmt.Errorf("failed to update last login: %w", err)
-		}
+	if err := s.sessionRepo.Create(ctx, session); err != nil {
+		return nil, fmt.Errorf("failed to create session: %w", err)
 	}
+	s.logger.InfoContext(ctx, "Session created", "user_id", user.ID.Hex(), "session_id", session.ID.Hex())
 
-	accessToken, expiresAt, err := s.jwtManager.GenerateToken(user)
+	accessToken, expiresAt, err := s.jwtManager.GenerateToken(user, session.ID.Hex())
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate token: %w", err)
 	}
@@ -82,11 +108,53 @@ func (s *authService) AuthenticateWithLinkedIn(ctx context.Context, code string)
 	}, nil
 }
 
+func (s *authService) IssueHandoffCode(authResponse *model.AuthResponse) (string, error) {
+	return s.handoffStore.Issue(authResponse)
+}
+
+func (s *authService) RedeemHandoffCode(code string) (*model.AuthResponse, error) {
+	return s.handoffStore.Redeem(code)
+}
+
 func (s *authService) ValidateToken(tokenString string) (*model.JWTClaims, error) {
 	return s.jwtManager.ValidateToken(tokenString)
 }
 
+func (s *authService) ValidateSession(ctx context.Context, claims *model.JWTClaims) error {
+	ctx, span := tracing.Start(ctx, "AuthService.ValidateSession")
+	defer span.End()
+
+	sessionID, err := primitive.ObjectIDFromHex(claims.SessionID)
+	if err != nil {
+		return fmt.Errorf("session not found")
+	}
+
+	session, err := s.sessionRepo.GetByID(ctx, sessionID)
+	if err != nil {
+		return fmt.Errorf("failed to get session: %w", err)
+	}
+
+	if session == nil || session.UserID.Hex() != claims.UserID {
+		return fmt.Errorf("session not found")
+	}
+
+	if session.RevokedAt != nil {
+		return fmt.Errorf("session revoked")
+	}
+
+	if time.Since(session.LastSeenAt) > sessionTouchInterval {
+		if err := s.sessionRepo.Touch(ctx, session.ID); err != nil {
+			return fmt.Errorf("failed to update session: %w", err)
+		}
+	}
+
+	return nil
+}
+
 func (s *authService) GetUserByID(ctx context.Context, userID string) (*model.User, error) {
+	ctx, span := tracing.Start(ctx, "AuthService.GetUserByID")
+	defer span.End()
+
 	id, err := primitive.ObjectIDFromHex(userID)
 	if err != nil {
 		return nil, fmt.Errorf("invalid user ID: %w", err)
@@ -104,6 +172,49 @@ func (s *authService) GetUserByID(ctx context.Context, userID string) (*model.Us
 	return user, nil
 }
 
-func (s *authService) Logout(ctx context.Context, userID string) error {
+func (s *authService) ListSessions(ctx context.Context, userID, currentSessionID string) ([]*model.Session, error) {
+	ctx, span := tracing.Start(ctx, "AuthService.ListSessions")
+	defer span.End()
+
+	userObjID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	sessions, err := s.sessionRepo.GetActiveByUserID(ctx, userObjID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sessions: %w", err)
+	}
+
+	for _, session := range sessions {
+		session.Current = session.ID.Hex() == currentSessionID
+	}
+
+	return sessions, nil
+}
+
+func (s *authService) RevokeSession(ctx context.Context, userID, sessionID string) error {
+	ctx, span := tracing.Start(ctx, "AuthService.RevokeSession")
+	defer span.End()
+
+	userObjID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	sessionObjID, err := primitive.ObjectIDFromHex(sessionID)
+	if err != nil {
+		return fmt.Errorf("session not found")
+	}
+
+	if err := s.sessionRepo.Revoke(ctx, sessionObjID, userObjID); err != nil {
+		return err
+	}
+
+	s.logger.InfoContext(ctx, "Session revoked", "user_id", userID, "session_id", sessionID)
 	return nil
 }
+
+func (s *authService) Logout(ctx context.Context, userID, sessionID string) error {
+	return s.RevokeSession(ctx, userID, sessionID)
+}
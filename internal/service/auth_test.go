@@ -3,8 +3,10 @@ package service
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/eminsonlu/salystic/internal/model"
+	"github.com/eminsonlu/salystic/pkg/logging"
 	"github.com/eminsonlu/salystic/internal/repo"
 
 	"github.com/stretchr/testify/assert"
@@ -14,14 +16,14 @@ import (
 )
 
 type LinkedInOAuthInterface interface {
-	GetAuthURL(state string) string
-	ExchangeCodeForToken(ctx context.Context, code string) (*oauth2.Token, error)
+	GetAuthURL(state, codeVerifier string) string
+	ExchangeCodeForToken(ctx context.Context, code, codeVerifier string) (*oauth2.Token, error)
 	GetLinkedInProfile(ctx context.Context, token *oauth2.Token) (*model.LinkedInProfile, error)
 	PseudonymizeLinkedInID(linkedInID string) string
 }
 
 type JWTManagerInterface interface {
-	GenerateToken(user *model.User) (string, int64, error)
+	GenerateToken(user *model.User, sessionID string) (string, int64, error)
 	ValidateToken(tokenString string) (*model.JWTClaims, error)
 }
 
@@ -45,6 +47,14 @@ func (m *MockUserRepository) GetByPseudonymizedID(ctx context.Context, pseudonym
 	return args.Get(0).(*model.User), args.Error(1)
 }
 
+func (m *MockUserRepository) UpsertByPseudonymizedID(ctx context.Context, pseudonymizedID string) (*model.User, bool, error) {
+	args := m.Called(ctx, pseudonymizedID)
+	if args.Get(0) == nil {
+		return nil, args.Bool(1), args.Error(2)
+	}
+	return args.Get(0).(*model.User), args.Bool(1), args.Error(2)
+}
+
 func (m *MockUserRepository) GetByID(ctx context.Context, id primitive.ObjectID) (*model.User, error) {
 	args := m.Called(ctx, id)
 	if args.Get(0) == nil {
@@ -58,17 +68,52 @@ func (m *MockUserRepository) UpdateLastLogin(ctx context.Context, id primitive.O
 	return args.Error(0)
 }
 
+type MockSessionRepository struct {
+	mock.Mock
+}
+
+func (m *MockSessionRepository) Create(ctx context.Context, session *model.Session) error {
+	args := m.Called(ctx, session)
+	return args.Error(0)
+}
+
+func (m *MockSessionRepository) GetByID(ctx context.Context, id primitive.ObjectID) (*model.Session, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Session), args.Error(1)
+}
+
+func (m *MockSessionRepository) GetActiveByUserID(ctx context.Context, userID primitive.ObjectID) ([]*model.Session, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*model.Session), args.Error(1)
+}
+
+func (m *MockSessionRepository) Touch(ctx context.Context, id primitive.ObjectID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockSessionRepository) Revoke(ctx context.Context, id primitive.ObjectID, userID primitive.ObjectID) error {
+	args := m.Called(ctx, id, userID)
+	return args.Error(0)
+}
+
 type MockLinkedInOAuth struct {
 	mock.Mock
 }
 
-func (m *MockLinkedInOAuth) GetAuthURL(state string) string {
-	args := m.Called(state)
+func (m *MockLinkedInOAuth) GetAuthURL(state, codeVerifier string) string {
+	args := m.Called(state, codeVerifier)
 	return args.String(0)
 }
 
-func (m *MockLinkedInOAuth) ExchangeCodeForToken(ctx context.Context, code string) (*oauth2.Token, error) {
-	args := m.Called(ctx, code)
+func (m *MockLinkedInOAuth) ExchangeCodeForToken(ctx context.Context, code, codeVerifier string) (*oauth2.Token, error) {
+	args := m.Called(ctx, code, codeVerifier)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
@@ -92,8 +137,8 @@ type MockJWTManager struct {
 	mock.Mock
 }
 
-func (m *MockJWTManager) GenerateToken(user *model.User) (string, int64, error) {
-	args := m.Called(user)
+func (m *MockJWTManager) GenerateToken(user *model.User, sessionID string) (string, int64, error) {
+	args := m.Called(user, sessionID)
 	return args.String(0), args.Get(1).(int64), args.Error(2)
 }
 
@@ -119,8 +164,8 @@ func newTestAuthService(userRepo repo.UserRepository, linkedinOAuth LinkedInOAut
 	}
 }
 
-func (s *testAuthService) GetLinkedInAuthURL(state string) string {
-	return s.linkedinOAuth.GetAuthURL(state)
+func (s *testAuthService) GetLinkedInAuthURL(state, codeVerifier string) string {
+	return s.linkedinOAuth.GetAuthURL(state, codeVerifier)
 }
 
 func (s *testAuthService) ValidateToken(tokenString string) (*model.JWTClaims, error) {
@@ -135,11 +180,12 @@ func TestAuthService_GetLinkedInAuthURL(t *testing.T) {
 	service := newTestAuthService(mockUserRepo, mockLinkedInOAuth, mockJWTManager)
 
 	state := "test_state"
+	verifier := "test_verifier"
 	expectedURL := "https://linkedin.com/auth?state=test_state"
 
-	mockLinkedInOAuth.On("GetAuthURL", state).Return(expectedURL)
+	mockLinkedInOAuth.On("GetAuthURL", state, verifier).Return(expectedURL)
 
-	result := service.GetLinkedInAuthURL(state)
+	result := service.GetLinkedInAuthURL(state, verifier)
 
 	assert.Equal(t, expectedURL, result)
 	mockLinkedInOAuth.AssertExpectations(t)
@@ -188,4 +234,75 @@ func TestAuthService_Interfaces(t *testing.T) {
 	assert.NotNil(t, userRepo)
 	assert.NotNil(t, linkedinOAuth)
 	assert.NotNil(t, jwtManager)
-}
\ No newline at end of file
+}
+func TestAuthService_ValidateSession(t *testing.T) {
+	mockSessionRepo := &MockSessionRepository{}
+	service := NewAuthService(&MockUserRepository{}, mockSessionRepo, nil, nil, nil, logging.NewNop())
+
+	userID := primitive.NewObjectID()
+	sessionID := primitive.NewObjectID()
+	claims := &model.JWTClaims{UserID: userID.Hex(), SessionID: sessionID.Hex()}
+
+	mockSessionRepo.On("GetByID", mock.Anything, sessionID).Return(&model.Session{
+		ID:         sessionID,
+		UserID:     userID,
+		LastSeenAt: time.Now().Add(-time.Hour),
+	}, nil)
+	mockSessionRepo.On("Touch", mock.Anything, sessionID).Return(nil)
+
+	err := service.ValidateSession(context.Background(), claims)
+
+	assert.NoError(t, err)
+	mockSessionRepo.AssertExpectations(t)
+}
+
+func TestAuthService_ValidateSession_Revoked(t *testing.T) {
+	mockSessionRepo := &MockSessionRepository{}
+	service := NewAuthService(&MockUserRepository{}, mockSessionRepo, nil, nil, nil, logging.NewNop())
+
+	userID := primitive.NewObjectID()
+	sessionID := primitive.NewObjectID()
+	revokedAt := time.Now()
+	claims := &model.JWTClaims{UserID: userID.Hex(), SessionID: sessionID.Hex()}
+
+	mockSessionRepo.On("GetByID", mock.Anything, sessionID).Return(&model.Session{
+		ID:         sessionID,
+		UserID:     userID,
+		LastSeenAt: time.Now(),
+		RevokedAt:  &revokedAt,
+	}, nil)
+
+	err := service.ValidateSession(context.Background(), claims)
+
+	assert.Error(t, err)
+	mockSessionRepo.AssertNotCalled(t, "Touch", mock.Anything, mock.Anything)
+}
+
+func TestAuthService_ValidateSession_MissingSessionID(t *testing.T) {
+	service := NewAuthService(&MockUserRepository{}, &MockSessionRepository{}, nil, nil, nil, logging.NewNop())
+
+	err := service.ValidateSession(context.Background(), &model.JWTClaims{UserID: primitive.NewObjectID().Hex()})
+
+	assert.Error(t, err)
+}
+
+func TestAuthService_ListSessions_MarksCurrent(t *testing.T) {
+	mockSessionRepo := &MockSessionRepository{}
+	service := NewAuthService(&MockUserRepository{}, mockSessionRepo, nil, nil, nil, logging.NewNop())
+
+	userID := primitive.NewObjectID()
+	currentID := primitive.NewObjectID()
+	otherID := primitive.NewObjectID()
+
+	mockSessionRepo.On("GetActiveByUserID", mock.Anything, userID).Return([]*model.Session{
+		{ID: currentID, UserID: userID},
+		{ID: otherID, UserID: userID},
+	}, nil)
+
+	sessions, err := service.ListSessions(context.Background(), userID.Hex(), currentID.Hex())
+
+	assert.NoError(t, err)
+	assert.Len(t, sessions, 2)
+	assert.True(t, sessions[0].Current)
+	assert.False(t, sessions[1].Current)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"log/slog"
 	"os"
 	"strconv"
 	"strings"
@@ -16,11 +17,13 @@ import (
 
 type DataImportService struct {
 	salaryRepo repo.SalaryEntryRepository
+	logger     *slog.Logger
 }
 
-func NewDataImportService(salaryRepo repo.SalaryEntryRepository) *DataImportService {
+func NewDataImportService(salaryRepo repo.SalaryEntryRepository, logger *slog.Logger) *DataImportService {
 	return &DataImportService{
 		salaryRepo: salaryRepo,
+		logger:     logger,
 	}
 }
 
@@ -61,6 +64,7 @@ func (s *DataImportService) ImportFromJSON(ctx context.Context, filePath string)
 		}
 	}
 
+	s.logger.InfoContext(ctx, "Salary entries imported", "file", filePath, "entries", len(entries))
 	return nil
 }
 
@@ -94,12 +98,12 @@ func (s *DataImportService) AnalyzeTechStacks(ctx context.Context, filePath stri
 		}
 	}
 
-	fmt.Printf("\n=== TECH STACK ANALYSIS ===\n")
-	fmt.Printf("Total entries: %d\n", len(entries))
-	fmt.Printf("Valid technologies found: %d\n", len(techCounts))
-	fmt.Printf("Invalid/unparsed tech strings: %d\n\n", len(invalidTechs))
+	s.logger.InfoContext(ctx, "Tech stack analysis",
+		"entries", len(entries),
+		"valid_techs", len(techCounts),
+		"invalid_tech_strings", len(invalidTechs),
+	)
 
-	fmt.Println("Top 20 Valid Technologies:")
 	type techCount struct {
 		tech  string
 		count int
@@ -122,11 +126,10 @@ func (s *DataImportService) AnalyzeTechStacks(ctx context.Context, filePath stri
 		if i >= 20 {
 			break
 		}
-		fmt.Printf("%2d. %-20s: %d entries\n", i+1, tc.tech, tc.count)
+		s.logger.InfoContext(ctx, "Top valid technology", "rank", i+1, "tech", tc.tech, "entries", tc.count)
 	}
 
 	if len(invalidTechs) > 0 {
-		fmt.Println("\nTop 20 Invalid/Unparsed Tech Strings:")
 		var sortedInvalid []techCount
 		for tech, count := range invalidTechs {
 			sortedInvalid = append(sortedInvalid, techCount{tech, count})
@@ -144,12 +147,10 @@ func (s *DataImportService) AnalyzeTechStacks(ctx context.Context, filePath stri
 			if i >= 20 {
 				break
 			}
-			fmt.Printf("%2d. %-40s: %d entries\n", i+1, tc.tech, tc.count)
+			s.logger.InfoContext(ctx, "Top invalid tech string", "rank", i+1, "tech_stack", tc.tech, "entries", tc.count)
 		}
 	}
 
-	fmt.Println("\n=== END ANALYSIS ===")
-	fmt.Println()
 	return nil
 }
 
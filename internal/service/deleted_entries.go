@@ -0,0 +1,112 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/eminsonlu/salystic/internal/model"
+	"github.com/eminsonlu/salystic/pkg/tracing"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// GetDeletedEntries returns the user's deleted entries that can still be
+// undeleted, most recently deleted first.
+func (s *salaryEntryService) GetDeletedEntries(ctx context.Context, userID string) ([]*model.DeletedSalaryEntry, error) {
+	ctx, span := tracing.Start(ctx, "SalaryEntryService.GetDeletedEntries")
+	defer span.End()
+
+	userObjID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	entries, err := s.salaryRepo.ListDeletedByUserID(ctx, userObjID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get deleted entries: %w", err)
+	}
+
+	deleted := []*model.DeletedSalaryEntry{}
+	for _, entry := range entries {
+		// Expired entries are only waiting for the purger.
+		if s.restoreExpired(entry) {
+			continue
+		}
+		deleted = append(deleted, &model.DeletedSalaryEntry{
+			SalaryEntry:     entry,
+			RestorableUntil: s.restorableUntil(entry),
+		})
+	}
+
+	return deleted, nil
+}
+
+// UndeleteEntry brings back one of the user's deleted entries while its
+// retention period lasts. The undelete is recorded as a new revision.
+func (s *salaryEntryService) UndeleteEntry(ctx context.Context, userID, entryID string) (*model.SalaryEntry, error) {
+	ctx, span := tracing.Start(ctx, "SalaryEntryService.UndeleteEntry")
+	defer span.End()
+
+	userObjID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	entryObjID, err := primitive.ObjectIDFromHex(entryID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid entry ID: %w", err)
+	}
+
+	deleted, err := s.salaryRepo.GetDeletedByID(ctx, entryObjID, userObjID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get deleted entry: %w", err)
+	}
+	if deleted == nil {
+		return nil, fmt.Errorf("salary entry not found")
+	}
+
+	if err := s.validatePeriod(ctx, userObjID, entryObjID, deleted.StartTime, deleted.EndTime, deleted.Concurrent); err != nil {
+		return nil, err
+	}
+
+	entry := *deleted
+	entry.DeletedAt = nil
+
+	err = s.transactor.WithTransaction(ctx, func(ctx context.Context) error {
+		if err := s.undelete(ctx, deleted); err != nil {
+			return err
+		}
+		return s.recordRevision(ctx, &model.EntryRevision{Action: model.RevisionActionUndelete, ChangedBy: userObjID}, deleted, &entry)
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.logger.InfoContext(ctx, "Salary entry undeleted", "entry_id", entryID, "user_id", userID)
+
+	return &entry, nil
+}
+
+// undelete clears the deletion of entry if its retention period has not
+// passed.
+func (s *salaryEntryService) undelete(ctx context.Context, entry *model.SalaryEntry) error {
+	if s.restoreExpired(entry) {
+		return fmt.Errorf("restore period has expired")
+	}
+
+	if err := s.salaryRepo.Undelete(ctx, entry.ID, entry.UserID); err != nil {
+		if err.Error() == "salary entry not found" {
+			return err
+		}
+		return fmt.Errorf("failed to undelete salary entry: %w", err)
+	}
+	return nil
+}
+
+func (s *salaryEntryService) restorableUntil(entry *model.SalaryEntry) time.Time {
+	return entry.DeletedAt.Add(s.retention)
+}
+
+func (s *salaryEntryService) restoreExpired(entry *model.SalaryEntry) bool {
+	return !time.Now().Before(s.restorableUntil(entry))
+}
@@ -0,0 +1,172 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/eminsonlu/salystic/internal/model"
+	"github.com/eminsonlu/salystic/internal/repo"
+	"github.com/eminsonlu/salystic/pkg/logging"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestSalaryEntryService_UndeleteEntry(t *testing.T) {
+	ctx := context.Background()
+	salaryService := newMemorySalaryService()
+	userID := primitive.NewObjectID().Hex()
+
+	entry, err := salaryService.CreateEntry(ctx, userID, &model.CreateSalaryEntryRequest{Level: "Senior", Currency: "TRY", SalaryMin: 100, StartTime: date(2020, 1)})
+	require.NoError(t, err)
+	entryID := entry.ID.Hex()
+
+	_, err = salaryService.UndeleteEntry(ctx, userID, entryID)
+	assert.EqualError(t, err, "salary entry not found")
+
+	require.NoError(t, salaryService.DeleteEntry(ctx, userID, entryID))
+
+	_, err = salaryService.GetEntry(ctx, userID, entryID)
+	assert.EqualError(t, err, "salary entry not found")
+
+	deleted, err := salaryService.GetDeletedEntries(ctx, userID)
+	require.NoError(t, err)
+	require.Len(t, deleted, 1)
+	assert.Equal(t, entry.ID, deleted[0].ID)
+	require.NotNil(t, deleted[0].DeletedAt)
+	assert.Equal(t, deleted[0].DeletedAt.Add(30*24*time.Hour), deleted[0].RestorableUntil)
+
+	others, err := salaryService.GetDeletedEntries(ctx, primitive.NewObjectID().Hex())
+	require.NoError(t, err)
+	assert.Empty(t, others)
+
+	_, err = salaryService.UndeleteEntry(ctx, primitive.NewObjectID().Hex(), entryID)
+	assert.EqualError(t, err, "salary entry not found")
+
+	undeleted, err := salaryService.UndeleteEntry(ctx, userID, entryID)
+	require.NoError(t, err)
+	assert.Equal(t, "Senior", undeleted.Level)
+	assert.Nil(t, undeleted.DeletedAt)
+
+	stored, err := salaryService.GetEntry(ctx, userID, entryID)
+	require.NoError(t, err)
+	assert.Equal(t, entry.ID, stored.ID)
+
+	history, err := salaryService.GetEntryHistory(ctx, userID, entryID)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		model.RevisionActionCreate, model.RevisionActionDelete, model.RevisionActionUndelete,
+	}, revisionActions(history))
+	require.Len(t, history[2].Changes, 1)
+	assert.Equal(t, "deleted_at", history[2].Changes[0].Field)
+	assert.Empty(t, history[2].Changes[0].New)
+}
+
+func TestSalaryEntryService_UndeleteEntryRejectsOverlap(t *testing.T) {
+	ctx := context.Background()
+	salaryService := newMemorySalaryService()
+	userID := primitive.NewObjectID().Hex()
+
+	entry, err := salaryService.CreateEntry(ctx, userID, &model.CreateSalaryEntryRequest{Currency: "TRY", SalaryMin: 100, StartTime: date(2020, 1)})
+	require.NoError(t, err)
+	require.NoError(t, salaryService.DeleteEntry(ctx, userID, entry.ID.Hex()))
+
+	_, err = salaryService.CreateEntry(ctx, userID, &model.CreateSalaryEntryRequest{Currency: "TRY", SalaryMin: 100, StartTime: date(2021, 1)})
+	require.NoError(t, err)
+
+	_, err = salaryService.UndeleteEntry(ctx, userID, entry.ID.Hex())
+	assert.EqualError(t, err, "entry overlaps with another job")
+}
+
+func TestSalaryEntryService_UndeleteEntryAfterRetention(t *testing.T) {
+	ctx := context.Background()
+	store := repo.NewMemoryStore()
+	salaryService := NewSalaryEntryService(repo.NewMemorySalaryEntryRepository(store), repo.NewMemoryEntryRevisionRepository(store), repo.NewMemoryTransactor(store), 0, logging.NewNop())
+	userID := primitive.NewObjectID().Hex()
+
+	entry, err := salaryService.CreateEntry(ctx, userID, &model.CreateSalaryEntryRequest{Currency: "TRY", SalaryMin: 100, StartTime: date(2020, 1)})
+	require.NoError(t, err)
+	require.NoError(t, salaryService.DeleteEntry(ctx, userID, entry.ID.Hex()))
+
+	deleted, err := salaryService.GetDeletedEntries(ctx, userID)
+	require.NoError(t, err)
+	assert.Empty(t, deleted)
+
+	_, err = salaryService.UndeleteEntry(ctx, userID, entry.ID.Hex())
+	assert.EqualError(t, err, "restore period has expired")
+
+	_, err = salaryService.RestoreEntry(ctx, userID, entry.ID.Hex(), 1)
+	assert.EqualError(t, err, "restore period has expired")
+}
+
+func TestSalaryEntryService_DeleteAndUndeleteRollBackWhenRevisionFails(t *testing.T) {
+	ctx := context.Background()
+	salaryService, revisionRepo := newFailingRevisionSalaryService()
+	userID := primitive.NewObjectID().Hex()
+
+	entry, err := salaryService.CreateEntry(ctx, userID, &model.CreateSalaryEntryRequest{Currency: "TRY", SalaryMin: 100, StartTime: date(2020, 1)})
+	require.NoError(t, err)
+	entryID := entry.ID.Hex()
+
+	revisionRepo.fail = true
+	assert.EqualError(t, salaryService.DeleteEntry(ctx, userID, entryID), "failed to record entry revision: revision store unavailable")
+
+	_, err = salaryService.GetEntry(ctx, userID, entryID)
+	require.NoError(t, err)
+
+	revisionRepo.fail = false
+	require.NoError(t, salaryService.DeleteEntry(ctx, userID, entryID))
+
+	revisionRepo.fail = true
+	_, err = salaryService.UndeleteEntry(ctx, userID, entryID)
+	assert.EqualError(t, err, "failed to record entry revision: revision store unavailable")
+
+	_, err = salaryService.GetEntry(ctx, userID, entryID)
+	assert.EqualError(t, err, "salary entry not found")
+}
+
+func TestEntryPurger_Purge(t *testing.T) {
+	ctx := context.Background()
+	store := repo.NewMemoryStore()
+	salaryRepo := repo.NewMemorySalaryEntryRepository(store)
+	revisionRepo := repo.NewMemoryEntryRevisionRepository(store)
+	salaryService := NewSalaryEntryService(salaryRepo, revisionRepo, repo.NewMemoryTransactor(store), 30*24*time.Hour, logging.NewNop())
+	userID := primitive.NewObjectID().Hex()
+
+	kept, err := salaryService.CreateEntry(ctx, userID, &model.CreateSalaryEntryRequest{Currency: "TRY", SalaryMin: 100, StartTime: date(2020, 1)})
+	require.NoError(t, err)
+	end := date(2019, 1)
+	deleted, err := salaryService.CreateEntry(ctx, userID, &model.CreateSalaryEntryRequest{Currency: "TRY", SalaryMin: 100, StartTime: date(2018, 1), EndTime: &end})
+	require.NoError(t, err)
+	require.NoError(t, salaryService.DeleteEntry(ctx, userID, deleted.ID.Hex()))
+
+	now := time.Now()
+	purger := &EntryPurger{
+		salaryRepo:   salaryRepo,
+		revisionRepo: revisionRepo,
+		retention:    30 * 24 * time.Hour,
+		logger:       logging.NewNop(),
+		now:          func() time.Time { return now },
+	}
+
+	purged, err := purger.Purge(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, purged)
+
+	now = now.Add(31 * 24 * time.Hour)
+	purged, err = purger.Purge(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, purged)
+
+	_, err = salaryService.UndeleteEntry(ctx, userID, deleted.ID.Hex())
+	assert.EqualError(t, err, "salary entry not found")
+
+	_, err = salaryService.GetEntryHistory(ctx, userID, deleted.ID.Hex())
+	assert.EqualError(t, err, "salary entry not found")
+
+	history, err := salaryService.GetEntryHistory(ctx, userID, kept.ID.Hex())
+	require.NoError(t, err)
+	assert.Len(t, history, 1)
+}
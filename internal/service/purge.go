@@ -0,0 +1,78 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/eminsonlu/salystic/internal/repo"
+	"github.com/eminsonlu/salystic/pkg/tracing"
+)
+
+// EntryPurger permanently removes salary entries, and their revisions, once
+// they have been deleted for longer than the retention period.
+type EntryPurger struct {
+	salaryRepo   repo.SalaryEntryRepository
+	revisionRepo repo.EntryRevisionRepository
+	retention    time.Duration
+	logger       *slog.Logger
+	stop         chan struct{}
+	now          func() time.Time
+}
+
+// NewEntryPurger starts purging every interval until Stop is called.
+func NewEntryPurger(salaryRepo repo.SalaryEntryRepository, revisionRepo repo.EntryRevisionRepository, retention, interval time.Duration, logger *slog.Logger) *EntryPurger {
+	p := &EntryPurger{
+		salaryRepo:   salaryRepo,
+		revisionRepo: revisionRepo,
+		retention:    retention,
+		logger:       logger,
+		stop:         make(chan struct{}),
+		now:          time.Now,
+	}
+	go p.run(interval)
+	return p
+}
+
+func (p *EntryPurger) Stop() {
+	close(p.stop)
+}
+
+// Purge removes the entries whose retention period has passed and returns how
+// many were removed.
+func (p *EntryPurger) Purge(ctx context.Context) (int, error) {
+	ctx, span := tracing.Start(ctx, "EntryPurger.Purge")
+	defer span.End()
+
+	ids, err := p.salaryRepo.PurgeDeleted(ctx, p.now().Add(-p.retention))
+	if err != nil {
+		return 0, fmt.Errorf("failed to purge deleted entries: %w", err)
+	}
+	if len(ids) == 0 {
+		return 0, nil
+	}
+
+	if err := p.revisionRepo.DeleteByEntryIDs(ctx, ids); err != nil {
+		return 0, fmt.Errorf("failed to purge revisions of deleted entries: %w", err)
+	}
+
+	p.logger.InfoContext(ctx, "Purged deleted salary entries", "count", len(ids))
+	return len(ids), nil
+}
+
+func (p *EntryPurger) run(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if _, err := p.Purge(context.Background()); err != nil {
+				p.logger.Error("Failed to purge deleted salary entries", "error", err)
+			}
+		case <-p.stop:
+			return
+		}
+	}
+}
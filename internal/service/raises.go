@@ -0,0 +1,204 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/eminsonlu/salystic/internal/model"
+	"github.com/eminsonlu/salystic/pkg/tracing"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func (s *salaryEntryService) AddRaise(ctx context.Context, userID, entryID string, req *model.CreateRaiseRequest) error {
+	ctx, span := tracing.Start(ctx, "SalaryEntryService.AddRaise")
+	defer span.End()
+
+	entry, err := s.GetEntry(ctx, userID, entryID)
+	if err != nil {
+		return err
+	}
+
+	if err := validateRaiseDate(entry, req.RaiseDate); err != nil {
+		return err
+	}
+
+	raises := append(append([]model.Raise(nil), entry.Raises...), model.Raise{
+		RaiseDate: req.RaiseDate,
+		NewSalary: req.NewSalary,
+	})
+
+	err = s.transactor.WithTransaction(ctx, func(ctx context.Context) error {
+		updated, err := s.saveRaises(ctx, entry, raises)
+		if err != nil {
+			if err.Error() == "salary entry was modified concurrently" {
+				return err
+			}
+			return fmt.Errorf("failed to add raise: %w", err)
+		}
+		return s.recordRevision(ctx, &model.EntryRevision{Action: model.RevisionActionRaiseAdd, ChangedBy: entry.UserID}, entry, updated)
+	})
+	if err != nil {
+		return err
+	}
+	s.logger.InfoContext(ctx, "Raise added", "entry_id", entryID, "user_id", userID)
+
+	return nil
+}
+
+func (s *salaryEntryService) GetRaises(ctx context.Context, userID, entryID string) ([]model.Raise, error) {
+	ctx, span := tracing.Start(ctx, "SalaryEntryService.GetRaises")
+	defer span.End()
+
+	userObjID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	entryObjID, err := primitive.ObjectIDFromHex(entryID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid entry ID: %w", err)
+	}
+
+	raises, err := s.salaryRepo.GetRaises(ctx, entryObjID, userObjID)
+	if err != nil {
+		if err.Error() == "salary entry not found" {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to get raises: %w", err)
+	}
+
+	return chronologicalRaises(raises), nil
+}
+
+func (s *salaryEntryService) UpdateRaise(ctx context.Context, userID, entryID, raiseID string, req *model.UpdateRaiseRequest) error {
+	ctx, span := tracing.Start(ctx, "SalaryEntryService.UpdateRaise")
+	defer span.End()
+
+	entry, index, err := s.findRaise(ctx, userID, entryID, raiseID)
+	if err != nil {
+		return err
+	}
+
+	raises := append([]model.Raise(nil), entry.Raises...)
+	if req.RaiseDate != nil {
+		if err := validateRaiseDate(entry, *req.RaiseDate); err != nil {
+			return err
+		}
+		raises[index].RaiseDate = *req.RaiseDate
+	}
+	if req.NewSalary != nil {
+		raises[index].NewSalary = *req.NewSalary
+	}
+
+	err = s.transactor.WithTransaction(ctx, func(ctx context.Context) error {
+		updated, err := s.saveRaises(ctx, entry, raises)
+		if err != nil {
+			if err.Error() == "salary entry was modified concurrently" {
+				return err
+			}
+			return fmt.Errorf("failed to update raise: %w", err)
+		}
+		return s.recordRevision(ctx, &model.EntryRevision{Action: model.RevisionActionRaiseUpdate, ChangedBy: entry.UserID}, entry, updated)
+	})
+	if err != nil {
+		return err
+	}
+	s.logger.InfoContext(ctx, "Raise updated", "entry_id", entryID, "raise_id", raiseID, "user_id", userID)
+
+	return nil
+}
+
+func (s *salaryEntryService) DeleteRaise(ctx context.Context, userID, entryID, raiseID string) error {
+	ctx, span := tracing.Start(ctx, "SalaryEntryService.DeleteRaise")
+	defer span.End()
+
+	entry, index, err := s.findRaise(ctx, userID, entryID, raiseID)
+	if err != nil {
+		return err
+	}
+
+	raises := append(append([]model.Raise(nil), entry.Raises[:index]...), entry.Raises[index+1:]...)
+
+	err = s.transactor.WithTransaction(ctx, func(ctx context.Context) error {
+		updated, err := s.saveRaises(ctx, entry, raises)
+		if err != nil {
+			if err.Error() == "salary entry was modified concurrently" {
+				return err
+			}
+			return fmt.Errorf("failed to delete raise: %w", err)
+		}
+		return s.recordRevision(ctx, &model.EntryRevision{Action: model.RevisionActionRaiseDelete, ChangedBy: entry.UserID}, entry, updated)
+	})
+	if err != nil {
+		return err
+	}
+	s.logger.InfoContext(ctx, "Raise deleted", "entry_id", entryID, "raise_id", raiseID, "user_id", userID)
+
+	return nil
+}
+
+// findRaise loads the user's entry and returns the index of the raise in its
+// Raises.
+func (s *salaryEntryService) findRaise(ctx context.Context, userID, entryID, raiseID string) (*model.SalaryEntry, int, error) {
+	raiseObjID, err := primitive.ObjectIDFromHex(raiseID)
+	if err != nil {
+		return nil, 0, fmt.Errorf("invalid raise ID: %w", err)
+	}
+
+	entry, err := s.GetEntry(ctx, userID, entryID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	for i, raise := range entry.Raises {
+		if raise.ID == raiseObjID {
+			return entry, i, nil
+		}
+	}
+	return nil, 0, fmt.Errorf("raise not found")
+}
+
+// saveRaises stores raises in chronological order with each percentage
+// recomputed against the salary before that raise (the entry's starting
+// salary for the first one), and returns the updated entry. It fails if the
+// raises were changed since entry was read.
+func (s *salaryEntryService) saveRaises(ctx context.Context, entry *model.SalaryEntry, raises []model.Raise) (*model.SalaryEntry, error) {
+	raises = withRaisePercentages(entry.SalaryMin, raises)
+	if err := s.salaryRepo.SetRaises(ctx, entry.ID, entry.UserID, raises, entry.RaisesVersion); err != nil {
+		return nil, err
+	}
+
+	updated, err := s.salaryRepo.GetByID(ctx, entry.ID, entry.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if updated == nil {
+		return nil, fmt.Errorf("salary entry not found")
+	}
+	return updated, nil
+}
+
+// withRaisePercentages orders raises chronologically and sets each one's
+// percentage relative to the salary before it.
+func withRaisePercentages(startingSalary int64, raises []model.Raise) []model.Raise {
+	sorted := chronologicalRaises(raises)
+	previous := startingSalary
+	for i := range sorted {
+		sorted[i].Percentage = 0
+		if previous > 0 {
+			sorted[i].Percentage = roundToTwoDecimals(float64(sorted[i].NewSalary-previous) / float64(previous) * 100)
+		}
+		previous = sorted[i].NewSalary
+	}
+	return sorted
+}
+
+// validateRaiseDate rejects a raise outside the job's period.
+func validateRaiseDate(entry *model.SalaryEntry, raiseDate time.Time) error {
+	if raiseDate.Before(entry.StartTime) || (entry.EndTime != nil && raiseDate.After(*entry.EndTime)) {
+		return fmt.Errorf("raise date must be within the job period")
+	}
+	return nil
+}
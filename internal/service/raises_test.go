@@ -0,0 +1,155 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/eminsonlu/salystic/internal/model"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func raisePercentages(raises []model.Raise) []float64 {
+	percentages := make([]float64, len(raises))
+	for i, raise := range raises {
+		percentages[i] = raise.Percentage
+	}
+	return percentages
+}
+
+func TestSalaryEntryService_Raises(t *testing.T) {
+	ctx := context.Background()
+	salaryService := newMemorySalaryService()
+	userID := primitive.NewObjectID().Hex()
+
+	end := date(2023, 1)
+	entry, err := salaryService.CreateEntry(ctx, userID, &model.CreateSalaryEntryRequest{
+		Currency: "TRY", SalaryMin: 100, StartTime: date(2020, 1), EndTime: &end,
+	})
+	require.NoError(t, err)
+	entryID := entry.ID.Hex()
+
+	// Raises are kept in chronological order whatever order they are added in.
+	require.NoError(t, salaryService.AddRaise(ctx, userID, entryID, &model.CreateRaiseRequest{RaiseDate: date(2022, 1), NewSalary: 150}))
+	require.NoError(t, salaryService.AddRaise(ctx, userID, entryID, &model.CreateRaiseRequest{RaiseDate: date(2021, 1), NewSalary: 120}))
+
+	raises, err := salaryService.GetRaises(ctx, userID, entryID)
+	require.NoError(t, err)
+	require.Len(t, raises, 2)
+	assert.Equal(t, int64(120), raises[0].NewSalary)
+	assert.Equal(t, []float64{20, 25}, raisePercentages(raises))
+
+	newSalary := int64(125)
+	require.NoError(t, salaryService.UpdateRaise(ctx, userID, entryID, raises[0].ID.Hex(), &model.UpdateRaiseRequest{NewSalary: &newSalary}))
+	raises, err = salaryService.GetRaises(ctx, userID, entryID)
+	require.NoError(t, err)
+	assert.Equal(t, []float64{25, 20}, raisePercentages(raises))
+
+	require.NoError(t, salaryService.DeleteRaise(ctx, userID, entryID, raises[0].ID.Hex()))
+	raises, err = salaryService.GetRaises(ctx, userID, entryID)
+	require.NoError(t, err)
+	require.Len(t, raises, 1)
+	assert.Equal(t, []float64{50}, raisePercentages(raises))
+
+	// Changing the starting salary recomputes the first raise.
+	salaryMin := int64(75)
+	updated, err := salaryService.UpdateEntry(ctx, userID, entryID, &model.UpdateSalaryEntryRequest{SalaryMin: &salaryMin})
+	require.NoError(t, err)
+	assert.Equal(t, []float64{100}, raisePercentages(updated.Raises))
+
+	err = salaryService.DeleteRaise(ctx, userID, entryID, primitive.NewObjectID().Hex())
+	assert.EqualError(t, err, "raise not found")
+
+	err = salaryService.DeleteRaise(ctx, primitive.NewObjectID().Hex(), entryID, raises[0].ID.Hex())
+	assert.EqualError(t, err, "salary entry not found")
+}
+
+func TestSalaryEntryService_ConcurrentRaiseWrites(t *testing.T) {
+	ctx := context.Background()
+	salaryService := newMemorySalaryService()
+	userID := primitive.NewObjectID().Hex()
+
+	entry, err := salaryService.CreateEntry(ctx, userID, &model.CreateSalaryEntryRequest{Currency: "TRY", SalaryMin: 100, StartTime: date(2020, 1)})
+	require.NoError(t, err)
+	entryID := entry.ID.Hex()
+
+	// retry repeats a write that lost a race, as a client answered with 409
+	// would.
+	retry := func(write func() error) error {
+		for {
+			err := write()
+			if err == nil || err.Error() != "salary entry was modified concurrently" {
+				return err
+			}
+		}
+	}
+
+	const workers = 8
+	var (
+		wg   sync.WaitGroup
+		errs = make([]error, workers+1)
+	)
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			req := &model.CreateRaiseRequest{RaiseDate: date(2021, time.Month(i+1)), NewSalary: int64(110 + 10*i)}
+			errs[i] = retry(func() error { return salaryService.AddRaise(ctx, userID, entryID, req) })
+		}(i)
+	}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		salaryMin := int64(90)
+		errs[workers] = retry(func() error {
+			_, err := salaryService.UpdateEntry(ctx, userID, entryID, &model.UpdateSalaryEntryRequest{SalaryMin: &salaryMin})
+			return err
+		})
+	}()
+	wg.Wait()
+
+	for _, err := range errs {
+		require.NoError(t, err)
+	}
+
+	stored, err := salaryService.GetEntry(ctx, userID, entryID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(90), stored.SalaryMin)
+	require.Len(t, stored.Raises, workers)
+	assert.Equal(t, raisePercentages(withRaisePercentages(90, stored.Raises)), raisePercentages(stored.Raises))
+}
+
+func TestSalaryEntryService_RaiseDateValidation(t *testing.T) {
+	ctx := context.Background()
+	salaryService := newMemorySalaryService()
+	userID := primitive.NewObjectID().Hex()
+
+	end := date(2023, 1)
+	entry, err := salaryService.CreateEntry(ctx, userID, &model.CreateSalaryEntryRequest{
+		Currency: "TRY", SalaryMin: 100, StartTime: date(2020, 1), EndTime: &end,
+	})
+	require.NoError(t, err)
+	entryID := entry.ID.Hex()
+
+	err = salaryService.AddRaise(ctx, userID, entryID, &model.CreateRaiseRequest{RaiseDate: date(2019, 6), NewSalary: 120})
+	assert.EqualError(t, err, "raise date must be within the job period")
+
+	err = salaryService.AddRaise(ctx, userID, entryID, &model.CreateRaiseRequest{RaiseDate: date(2023, 6), NewSalary: 120})
+	assert.EqualError(t, err, "raise date must be within the job period")
+
+	require.NoError(t, salaryService.AddRaise(ctx, userID, entryID, &model.CreateRaiseRequest{RaiseDate: date(2021, 1), NewSalary: 120}))
+	raises, err := salaryService.GetRaises(ctx, userID, entryID)
+	require.NoError(t, err)
+
+	outside := date(2024, 1)
+	err = salaryService.UpdateRaise(ctx, userID, entryID, raises[0].ID.Hex(), &model.UpdateRaiseRequest{RaiseDate: &outside})
+	assert.EqualError(t, err, "raise date must be within the job period")
+
+	earlierEnd := date(2020, 6)
+	_, err = salaryService.UpdateEntry(ctx, userID, entryID, &model.UpdateSalaryEntryRequest{EndTime: &earlierEnd})
+	assert.EqualError(t, err, "job period must include all raises")
+}
@@ -0,0 +1,226 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/eminsonlu/salystic/internal/model"
+	"github.com/eminsonlu/salystic/pkg/tracing"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// untrackedEntryFields are left out of revision diffs: they identify the entry
+// or change on every write.
+var untrackedEntryFields = map[string]bool{
+	"id":         true,
+	"user_id":    true,
+	"created_at": true,
+	"updated_at": true,
+}
+
+// GetEntryHistory returns the revisions of one of the user's entries, oldest
+// first. Deleted entries keep their history until they are purged.
+func (s *salaryEntryService) GetEntryHistory(ctx context.Context, userID, entryID string) ([]*model.EntryRevision, error) {
+	ctx, span := tracing.Start(ctx, "SalaryEntryService.GetEntryHistory")
+	defer span.End()
+
+	userObjID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	entryObjID, err := primitive.ObjectIDFromHex(entryID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid entry ID: %w", err)
+	}
+
+	revisions, err := s.revisionRepo.ListByEntryID(ctx, entryObjID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get entry history: %w", err)
+	}
+
+	// Entries created before revisions were recorded have none yet.
+	if len(revisions) == 0 {
+		if _, err := s.GetEntry(ctx, userID, entryID); err != nil {
+			return nil, err
+		}
+		return revisions, nil
+	}
+
+	if revisions[0].UserID != userObjID {
+		return nil, fmt.Errorf("salary entry not found")
+	}
+
+	return revisions, nil
+}
+
+// GetEntryHistoryForAdmin returns the revisions of any entry, oldest first.
+func (s *salaryEntryService) GetEntryHistoryForAdmin(ctx context.Context, entryID string) ([]*model.EntryRevision, error) {
+	ctx, span := tracing.Start(ctx, "SalaryEntryService.GetEntryHistoryForAdmin")
+	defer span.End()
+
+	entryObjID, err := primitive.ObjectIDFromHex(entryID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid entry ID: %w", err)
+	}
+
+	revisions, err := s.revisionRepo.ListByEntryID(ctx, entryObjID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get entry history: %w", err)
+	}
+
+	return revisions, nil
+}
+
+// RestoreEntry puts the user's entry back to its state at a revision,
+// undeleting it first if it was deleted, or recreating it if it no longer
+// exists. The restore is recorded as a new revision.
+func (s *salaryEntryService) RestoreEntry(ctx context.Context, userID, entryID string, version int) (*model.SalaryEntry, error) {
+	ctx, span := tracing.Start(ctx, "SalaryEntryService.RestoreEntry")
+	defer span.End()
+
+	userObjID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	entryObjID, err := primitive.ObjectIDFromHex(entryID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid entry ID: %w", err)
+	}
+
+	revision, err := s.revisionRepo.GetByVersion(ctx, entryObjID, version)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get entry revision: %w", err)
+	}
+	if revision == nil || revision.UserID != userObjID {
+		return nil, fmt.Errorf("revision not found")
+	}
+
+	restored := revision.Snapshot
+	if err := s.validatePeriod(ctx, userObjID, entryObjID, restored.StartTime, restored.EndTime, restored.Concurrent); err != nil {
+		return nil, err
+	}
+
+	err = s.transactor.WithTransaction(ctx, func(ctx context.Context) error {
+		current, err := s.salaryRepo.GetByID(ctx, entryObjID, userObjID)
+		if err != nil {
+			return fmt.Errorf("failed to get salary entry: %w", err)
+		}
+
+		if current == nil {
+			current, err = s.salaryRepo.GetDeletedByID(ctx, entryObjID, userObjID)
+			if err != nil {
+				return fmt.Errorf("failed to get deleted entry: %w", err)
+			}
+			if current != nil {
+				if err := s.undelete(ctx, current); err != nil {
+					return err
+				}
+			}
+		}
+
+		if current == nil {
+			if err := s.salaryRepo.Create(ctx, restored); err != nil {
+				return fmt.Errorf("failed to restore salary entry: %w", err)
+			}
+		} else {
+			restored.CreatedAt = current.CreatedAt
+			restored.RaisesVersion = current.RaisesVersion
+			if err := s.salaryRepo.Replace(ctx, restored); err != nil {
+				if err.Error() == "salary entry was modified concurrently" {
+					return err
+				}
+				return fmt.Errorf("failed to restore salary entry: %w", err)
+			}
+		}
+
+		return s.recordRevision(ctx, &model.EntryRevision{Action: model.RevisionActionRestore, RestoredFrom: version, ChangedBy: userObjID}, current, restored)
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.logger.InfoContext(ctx, "Salary entry restored", "entry_id", entryID, "version", version, "user_id", userID)
+
+	return restored, nil
+}
+
+// recordRevision stores revision with the changes from before to after.
+// before is nil for a created entry and after for a deleted one. Callers run
+// it in the transaction of the change, so the change isn't kept without it.
+func (s *salaryEntryService) recordRevision(ctx context.Context, revision *model.EntryRevision, before, after *model.SalaryEntry) error {
+	snapshot := after
+	if snapshot == nil {
+		snapshot = before
+	}
+
+	changes, err := entryChanges(before, after)
+	if err != nil {
+		return fmt.Errorf("failed to record entry revision: %w", err)
+	}
+
+	revision.EntryID = snapshot.ID
+	revision.UserID = snapshot.UserID
+	revision.Changes = changes
+	revision.Snapshot = snapshot
+
+	if err := s.revisionRepo.Create(ctx, revision); err != nil {
+		return fmt.Errorf("failed to record entry revision: %w", err)
+	}
+	return nil
+}
+
+// entryChanges compares the JSON encoding of two versions of an entry field by
+// field, in field name order.
+func entryChanges(before, after *model.SalaryEntry) ([]model.FieldChange, error) {
+	oldFields, err := entryFields(before)
+	if err != nil {
+		return nil, err
+	}
+	newFields, err := entryFields(after)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make(map[string]bool)
+	for name := range oldFields {
+		names[name] = true
+	}
+	for name := range newFields {
+		names[name] = true
+	}
+
+	changes := []model.FieldChange{}
+	for name := range names {
+		if untrackedEntryFields[name] || bytes.Equal(oldFields[name], newFields[name]) {
+			continue
+		}
+		changes = append(changes, model.FieldChange{Field: name, Old: oldFields[name], New: newFields[name]})
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Field < changes[j].Field
+	})
+	return changes, nil
+}
+
+func entryFields(entry *model.SalaryEntry) (map[string]json.RawMessage, error) {
+	if entry == nil {
+		return nil, nil
+	}
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return nil, err
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
@@ -0,0 +1,208 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/eminsonlu/salystic/internal/model"
+	"github.com/eminsonlu/salystic/internal/repo"
+	"github.com/eminsonlu/salystic/pkg/logging"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func revisionActions(revisions []*model.EntryRevision) []string {
+	actions := make([]string, len(revisions))
+	for i, revision := range revisions {
+		actions[i] = revision.Action
+	}
+	return actions
+}
+
+// failingRevisionRepository fails revision writes while fail is set.
+type failingRevisionRepository struct {
+	repo.EntryRevisionRepository
+	fail bool
+}
+
+func (r *failingRevisionRepository) Create(ctx context.Context, revision *model.EntryRevision) error {
+	if r.fail {
+		return fmt.Errorf("revision store unavailable")
+	}
+	return r.EntryRevisionRepository.Create(ctx, revision)
+}
+
+func newFailingRevisionSalaryService() (SalaryEntryService, *failingRevisionRepository) {
+	store := repo.NewMemoryStore()
+	revisionRepo := &failingRevisionRepository{EntryRevisionRepository: repo.NewMemoryEntryRevisionRepository(store)}
+	return NewSalaryEntryService(repo.NewMemorySalaryEntryRepository(store), revisionRepo, repo.NewMemoryTransactor(store), 30*24*time.Hour, logging.NewNop()), revisionRepo
+}
+
+func TestSalaryEntryService_RevisionFailureRollsBackChanges(t *testing.T) {
+	ctx := context.Background()
+	salaryService, revisionRepo := newFailingRevisionSalaryService()
+	userID := primitive.NewObjectID().Hex()
+
+	entry, err := salaryService.CreateEntry(ctx, userID, &model.CreateSalaryEntryRequest{Level: "Senior", Currency: "TRY", SalaryMin: 100, StartTime: date(2020, 1)})
+	require.NoError(t, err)
+	entryID := entry.ID.Hex()
+	require.NoError(t, salaryService.AddRaise(ctx, userID, entryID, &model.CreateRaiseRequest{RaiseDate: date(2021, 1), NewSalary: 150}))
+
+	revisionRepo.fail = true
+
+	end := date(2016, 1)
+	_, err = salaryService.CreateEntry(ctx, userID, &model.CreateSalaryEntryRequest{Level: "Senior", Currency: "TRY", SalaryMin: 100, StartTime: date(2015, 1), EndTime: &end})
+	assert.EqualError(t, err, "failed to record entry revision: revision store unavailable")
+
+	salaryMin := int64(120)
+	_, err = salaryService.UpdateEntry(ctx, userID, entryID, &model.UpdateSalaryEntryRequest{SalaryMin: &salaryMin})
+	assert.EqualError(t, err, "failed to record entry revision: revision store unavailable")
+
+	err = salaryService.AddRaise(ctx, userID, entryID, &model.CreateRaiseRequest{RaiseDate: date(2022, 1), NewSalary: 200})
+	assert.EqualError(t, err, "failed to record entry revision: revision store unavailable")
+
+	page, err := salaryService.GetUserEntries(ctx, userID, &model.ListEntriesQuery{Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, page.Entries, 1)
+	stored := page.Entries[0]
+	assert.Equal(t, int64(100), stored.SalaryMin)
+	require.Len(t, stored.Raises, 1)
+	assert.Equal(t, 50.0, stored.Raises[0].Percentage)
+
+	history, err := salaryService.GetEntryHistory(ctx, userID, entryID)
+	require.NoError(t, err)
+	assert.Equal(t, []string{model.RevisionActionCreate, model.RevisionActionRaiseAdd}, revisionActions(history))
+}
+
+func TestSalaryEntryService_EntryHistory(t *testing.T) {
+	ctx := context.Background()
+	salaryService := newMemorySalaryService()
+	userID := primitive.NewObjectID().Hex()
+
+	end := date(2023, 1)
+	entry, err := salaryService.CreateEntry(ctx, userID, &model.CreateSalaryEntryRequest{
+		Level: "Senior", Currency: "TRY", SalaryMin: 100, StartTime: date(2020, 1), EndTime: &end,
+	})
+	require.NoError(t, err)
+	entryID := entry.ID.Hex()
+
+	level := "Staff"
+	_, err = salaryService.UpdateEntry(ctx, userID, entryID, &model.UpdateSalaryEntryRequest{Level: &level})
+	require.NoError(t, err)
+	require.NoError(t, salaryService.AddRaise(ctx, userID, entryID, &model.CreateRaiseRequest{RaiseDate: date(2021, 1), NewSalary: 120}))
+	require.NoError(t, salaryService.DeleteEntry(ctx, userID, entryID))
+
+	history, err := salaryService.GetEntryHistory(ctx, userID, entryID)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		model.RevisionActionCreate, model.RevisionActionUpdate, model.RevisionActionRaiseAdd, model.RevisionActionDelete,
+	}, revisionActions(history))
+
+	update := history[1]
+	assert.Equal(t, 2, update.Version)
+	assert.Equal(t, entry.UserID, update.ChangedBy)
+	require.Len(t, update.Changes, 1)
+	assert.Equal(t, "level", update.Changes[0].Field)
+	assert.JSONEq(t, `"Senior"`, string(update.Changes[0].Old))
+	assert.JSONEq(t, `"Staff"`, string(update.Changes[0].New))
+
+	assert.Equal(t, []string{"raises"}, []string{history[2].Changes[0].Field})
+	require.Len(t, history[2].Snapshot.Raises, 1)
+	assert.False(t, history[2].Snapshot.Raises[0].ID.IsZero())
+
+	deleted := history[3]
+	assert.Equal(t, "Staff", deleted.Snapshot.Level)
+	for _, change := range deleted.Changes {
+		assert.Empty(t, change.New, change.Field)
+	}
+
+	_, err = salaryService.GetEntryHistory(ctx, primitive.NewObjectID().Hex(), entryID)
+	assert.EqualError(t, err, "salary entry not found")
+
+	adminHistory, err := salaryService.GetEntryHistoryForAdmin(ctx, entryID)
+	require.NoError(t, err)
+	assert.Len(t, adminHistory, 4)
+}
+
+func TestSalaryEntryService_RestoreEntry(t *testing.T) {
+	ctx := context.Background()
+	salaryService := newMemorySalaryService()
+	userID := primitive.NewObjectID().Hex()
+
+	entry, err := salaryService.CreateEntry(ctx, userID, &model.CreateSalaryEntryRequest{
+		Level: "Senior", Currency: "TRY", SalaryMin: 100, StartTime: date(2020, 1),
+	})
+	require.NoError(t, err)
+	entryID := entry.ID.Hex()
+
+	end := date(2023, 1)
+	level := "Staff"
+	_, err = salaryService.UpdateEntry(ctx, userID, entryID, &model.UpdateSalaryEntryRequest{Level: &level, EndTime: &end})
+	require.NoError(t, err)
+
+	// Restoring the first version clears the end time set since.
+	restored, err := salaryService.RestoreEntry(ctx, userID, entryID, 1)
+	require.NoError(t, err)
+	assert.Equal(t, "Senior", restored.Level)
+	assert.Nil(t, restored.EndTime)
+
+	stored, err := salaryService.GetEntry(ctx, userID, entryID)
+	require.NoError(t, err)
+	assert.Equal(t, "Senior", stored.Level)
+	assert.Nil(t, stored.EndTime)
+
+	// A deleted entry is undeleted and restored with its ID.
+	require.NoError(t, salaryService.DeleteEntry(ctx, userID, entryID))
+	restored, err = salaryService.RestoreEntry(ctx, userID, entryID, 2)
+	require.NoError(t, err)
+	assert.Equal(t, entry.ID, restored.ID)
+	assert.Equal(t, "Staff", restored.Level)
+
+	history, err := salaryService.GetEntryHistory(ctx, userID, entryID)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		model.RevisionActionCreate, model.RevisionActionUpdate, model.RevisionActionRestore, model.RevisionActionDelete, model.RevisionActionRestore,
+	}, revisionActions(history))
+	assert.Equal(t, 2, history[4].RestoredFrom)
+
+	_, err = salaryService.RestoreEntry(ctx, userID, entryID, 9)
+	assert.EqualError(t, err, "revision not found")
+
+	_, err = salaryService.RestoreEntry(ctx, primitive.NewObjectID().Hex(), entryID, 1)
+	assert.EqualError(t, err, "revision not found")
+}
+
+func TestSalaryEntryService_RestoreEntryRejectsOverlap(t *testing.T) {
+	ctx := context.Background()
+	salaryService := newMemorySalaryService()
+	userID := primitive.NewObjectID().Hex()
+
+	entry, err := salaryService.CreateEntry(ctx, userID, &model.CreateSalaryEntryRequest{Currency: "TRY", SalaryMin: 100, StartTime: date(2020, 1)})
+	require.NoError(t, err)
+	require.NoError(t, salaryService.DeleteEntry(ctx, userID, entry.ID.Hex()))
+
+	_, err = salaryService.CreateEntry(ctx, userID, &model.CreateSalaryEntryRequest{Currency: "TRY", SalaryMin: 100, StartTime: date(2021, 1)})
+	require.NoError(t, err)
+
+	_, err = salaryService.RestoreEntry(ctx, userID, entry.ID.Hex(), 1)
+	assert.EqualError(t, err, "entry overlaps with another job")
+}
+
+func TestEntryChanges(t *testing.T) {
+	before := &model.SalaryEntry{ID: primitive.NewObjectID(), Level: "Senior", SalaryMin: 100}
+	after := *before
+	after.SalaryMin = 120
+
+	changes, err := entryChanges(before, &after)
+	require.NoError(t, err)
+	require.Len(t, changes, 1)
+	assert.Equal(t, model.FieldChange{Field: "salary_min", Old: []byte("100"), New: []byte("120")}, changes[0])
+
+	changes, err = entryChanges(before, before)
+	require.NoError(t, err)
+	assert.Empty(t, changes)
+}
@@ -2,12 +2,17 @@ package service
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/json"
 	"fmt"
+	"log/slog"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/eminsonlu/salystic/internal/model"
 	"github.com/eminsonlu/salystic/internal/repo"
+	"github.com/eminsonlu/salystic/pkg/tracing"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
@@ -15,29 +20,55 @@ import (
 type SalaryEntryService interface {
 	CreateEntry(ctx context.Context, userID string, req *model.CreateSalaryEntryRequest) (*model.SalaryEntry, error)
 	GetEntry(ctx context.Context, userID, entryID string) (*model.SalaryEntry, error)
-	GetUserEntries(ctx context.Context, userID string) ([]*model.SalaryEntry, error)
+	GetUserEntries(ctx context.Context, userID string, query *model.ListEntriesQuery) (*model.SalaryEntryPage, error)
 	UpdateEntry(ctx context.Context, userID, entryID string, req *model.UpdateSalaryEntryRequest) (*model.SalaryEntry, error)
 	DeleteEntry(ctx context.Context, userID, entryID string) error
 	AddRaise(ctx context.Context, userID, entryID string, req *model.CreateRaiseRequest) error
 	GetRaises(ctx context.Context, userID, entryID string) ([]model.Raise, error)
+	UpdateRaise(ctx context.Context, userID, entryID, raiseID string, req *model.UpdateRaiseRequest) error
+	DeleteRaise(ctx context.Context, userID, entryID, raiseID string) error
+	GetTimeline(ctx context.Context, userID string) (*model.Timeline, error)
+	GetEntryHistory(ctx context.Context, userID, entryID string) ([]*model.EntryRevision, error)
+	GetEntryHistoryForAdmin(ctx context.Context, entryID string) ([]*model.EntryRevision, error)
+	RestoreEntry(ctx context.Context, userID, entryID string, version int) (*model.SalaryEntry, error)
+	GetDeletedEntries(ctx context.Context, userID string) ([]*model.DeletedSalaryEntry, error)
+	UndeleteEntry(ctx context.Context, userID, entryID string) (*model.SalaryEntry, error)
 }
 
 type salaryEntryService struct {
-	salaryRepo repo.SalaryEntryRepository
+	salaryRepo   repo.SalaryEntryRepository
+	revisionRepo repo.EntryRevisionRepository
+	transactor   repo.Transactor
+	retention    time.Duration
+	logger       *slog.Logger
 }
 
-func NewSalaryEntryService(salaryRepo repo.SalaryEntryRepository) SalaryEntryService {
+// NewSalaryEntryService keeps deleted entries restorable by their owner for
+// retention. Every change to an entry is stored together with its revision in
+// a transaction run by transactor.
+func NewSalaryEntryService(salaryRepo repo.SalaryEntryRepository, revisionRepo repo.EntryRevisionRepository, transactor repo.Transactor, retention time.Duration, logger *slog.Logger) SalaryEntryService {
 	return &salaryEntryService{
-		salaryRepo: salaryRepo,
+		salaryRepo:   salaryRepo,
+		revisionRepo: revisionRepo,
+		transactor:   transactor,
+		retention:    retention,
+		logger:       logger,
 	}
 }
 
 func (s *salaryEntryService) CreateEntry(ctx context.Context, userID string, req *model.CreateSalaryEntryRequest) (*model.SalaryEntry, error) {
+	ctx, span := tracing.Start(ctx, "SalaryEntryService.CreateEntry")
+	defer span.End()
+
 	userObjID, err := primitive.ObjectIDFromHex(userID)
 	if err != nil {
 		return nil, fmt.Errorf("invalid user ID: %w", err)
 	}
 
+	if err := s.validatePeriod(ctx, userObjID, primitive.NilObjectID, req.StartTime, req.EndTime, req.Concurrent); err != nil {
+		return nil, err
+	}
+
 	var salaryRange string
 	if req.SalaryMax != nil {
 		salaryRange = fmt.Sprintf("%d - %d", req.SalaryMin, *req.SalaryMax)
@@ -45,35 +76,54 @@ func (s *salaryEntryService) CreateEntry(ctx context.Context, userID string, req
 		salaryRange = fmt.Sprintf("%d+", req.SalaryMin)
 	}
 
+	salaryBasis := req.SalaryBasis
+	if salaryBasis == "" {
+		salaryBasis = model.SalaryBasisNetMonthly
+	}
+
 	entry := &model.SalaryEntry{
-		UserID:      userObjID,
-		Level:       req.Level,
-		Position:    req.Position,
-		TechStack:   req.TechStack,
-		Experience:  req.Experience,
-		Gender:      req.Gender,
-		Company:     req.Company,
-		CompanySize: req.CompanySize,
-		WorkType:    req.WorkType,
-		City:        req.City,
-		Currency:    req.Currency,
-		SalaryRange: salaryRange,
-		SalaryMin:   req.SalaryMin,
-		SalaryMax:   req.SalaryMax,
-		RaisePeriod: req.RaisePeriod,
-		StartTime:   req.StartTime,
-		EndTime:     req.EndTime,
-		Raises:      []model.Raise{},
-	}
-
-	if err := s.salaryRepo.Create(ctx, entry); err != nil {
-		return nil, fmt.Errorf("failed to create salary entry: %w", err)
+		UserID:       userObjID,
+		Level:        req.Level,
+		Position:     req.Position,
+		TechStack:    req.TechStack,
+		Experience:   req.Experience,
+		Gender:       req.Gender,
+		Company:      req.Company,
+		CompanySize:  req.CompanySize,
+		WorkType:     req.WorkType,
+		City:         req.City,
+		Currency:     req.Currency,
+		SalaryBasis:  salaryBasis,
+		SalaryRange:  salaryRange,
+		SalaryMin:    req.SalaryMin,
+		SalaryMax:    req.SalaryMax,
+		RaisePeriod:  req.RaisePeriod,
+		StartTime:    req.StartTime,
+		EndTime:      req.EndTime,
+		Concurrent:   req.Concurrent,
+		ChangeReason: req.ChangeReason,
+		Compensation: req.Compensation,
+		Raises:       []model.Raise{},
+	}
+
+	err = s.transactor.WithTransaction(ctx, func(ctx context.Context) error {
+		if err := s.salaryRepo.Create(ctx, entry); err != nil {
+			return fmt.Errorf("failed to create salary entry: %w", err)
+		}
+		return s.recordRevision(ctx, &model.EntryRevision{Action: model.RevisionActionCreate, ChangedBy: userObjID}, nil, entry)
+	})
+	if err != nil {
+		return nil, err
 	}
+	s.logger.InfoContext(ctx, "Salary entry created", "entry_id", entry.ID.Hex(), "user_id", userID)
 
 	return entry, nil
 }
 
 func (s *salaryEntryService) GetEntry(ctx context.Context, userID, entryID string) (*model.SalaryEntry, error) {
+	ctx, span := tracing.Start(ctx, "SalaryEntryService.GetEntry")
+	defer span.End()
+
 	userObjID, err := primitive.ObjectIDFromHex(userID)
 	if err != nil {
 		return nil, fmt.Errorf("invalid user ID: %w", err)
@@ -96,21 +146,58 @@ func (s *salaryEntryService) GetEntry(ctx context.Context, userID, entryID strin
 	return entry, nil
 }
 
-func (s *salaryEntryService) GetUserEntries(ctx context.Context, userID string) ([]*model.SalaryEntry, error) {
+func (s *salaryEntryService) GetUserEntries(ctx context.Context, userID string, query *model.ListEntriesQuery) (*model.SalaryEntryPage, error) {
+	ctx, span := tracing.Start(ctx, "SalaryEntryService.GetUserEntries")
+	defer span.End()
+
 	userObjID, err := primitive.ObjectIDFromHex(userID)
 	if err != nil {
 		return nil, fmt.Errorf("invalid user ID: %w", err)
 	}
 
-	entries, err := s.salaryRepo.GetByUserID(ctx, userObjID)
+	opts := &repo.SalaryEntryListOptions{
+		SortBy:    query.SortBy,
+		Ascending: query.Order == "asc",
+		Currency:  query.Currency,
+		Limit:     query.Limit + 1,
+	}
+	if query.Status != "" {
+		current := query.Status == model.EntryStatusCurrent
+		opts.Current = &current
+	}
+	if query.Cursor != "" {
+		after, err := decodeEntryCursor(query)
+		if err != nil {
+			return nil, err
+		}
+		opts.After = after
+	}
+
+	entries, err := s.salaryRepo.ListByUserID(ctx, userObjID, opts)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get user entries: %w", err)
 	}
 
-	return entries, nil
+	page := &model.SalaryEntryPage{Entries: entries}
+	if len(entries) > query.Limit {
+		page.Entries = entries[:query.Limit]
+		page.HasMore = true
+		page.NextCursor, err = encodeEntryCursor(query, page.Entries[query.Limit-1])
+		if err != nil {
+			return nil, err
+		}
+	}
+	if page.Entries == nil {
+		page.Entries = []*model.SalaryEntry{}
+	}
+
+	return page, nil
 }
 
 func (s *salaryEntryService) UpdateEntry(ctx context.Context, userID, entryID string, req *model.UpdateSalaryEntryRequest) (*model.SalaryEntry, error) {
+	ctx, span := tracing.Start(ctx, "SalaryEntryService.UpdateEntry")
+	defer span.End()
+
 	userObjID, err := primitive.ObjectIDFromHex(userID)
 	if err != nil {
 		return nil, fmt.Errorf("invalid user ID: %w", err)
@@ -121,37 +208,75 @@ func (s *salaryEntryService) UpdateEntry(ctx context.Context, userID, entryID st
 		return nil, fmt.Errorf("invalid entry ID: %w", err)
 	}
 
-	entry, err := s.salaryRepo.Update(ctx, entryObjID, userObjID, req)
+	existing, err := s.salaryRepo.GetByID(ctx, entryObjID, userObjID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to update salary entry: %w", err)
+		return nil, fmt.Errorf("failed to get salary entry: %w", err)
 	}
-
-	if entry == nil {
+	if existing == nil {
 		return nil, fmt.Errorf("salary entry not found")
 	}
 
-	return entry, nil
-}
+	if req.StartTime != nil || req.EndTime != nil || req.Concurrent != nil {
+		startTime, endTime, concurrent := existing.StartTime, existing.EndTime, existing.Concurrent
+		if req.StartTime != nil {
+			startTime = *req.StartTime
+		}
+		if req.EndTime != nil {
+			endTime = req.EndTime
+		}
+		if req.Concurrent != nil {
+			concurrent = *req.Concurrent
+		}
 
-func (s *salaryEntryService) DeleteEntry(ctx context.Context, userID, entryID string) error {
-	userObjID, err := primitive.ObjectIDFromHex(userID)
-	if err != nil {
-		return fmt.Errorf("invalid user ID: %w", err)
-	}
+		if err := s.validatePeriod(ctx, userObjID, entryObjID, startTime, endTime, concurrent); err != nil {
+			return nil, err
+		}
 
-	entryObjID, err := primitive.ObjectIDFromHex(entryID)
-	if err != nil {
-		return fmt.Errorf("invalid entry ID: %w", err)
+		period := &model.SalaryEntry{StartTime: startTime, EndTime: endTime}
+		for _, raise := range existing.Raises {
+			if validateRaiseDate(period, raise.RaiseDate) != nil {
+				return nil, fmt.Errorf("job period must include all raises")
+			}
+		}
 	}
 
-	if err := s.salaryRepo.Delete(ctx, entryObjID, userObjID); err != nil {
-		return fmt.Errorf("failed to delete salary entry: %w", err)
+	var entry *model.SalaryEntry
+	err = s.transactor.WithTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		entry, err = s.salaryRepo.Update(ctx, entryObjID, userObjID, req)
+		if err != nil {
+			return fmt.Errorf("failed to update salary entry: %w", err)
+		}
+
+		if entry == nil {
+			return fmt.Errorf("salary entry not found")
+		}
+
+		// The first raise's percentage is relative to the starting salary, and
+		// each later one to the raise before it.
+		if req.SalaryMin != nil && *req.SalaryMin != existing.SalaryMin && len(entry.Raises) > 0 {
+			entry, err = s.saveRaises(ctx, entry, entry.Raises)
+			if err != nil {
+				if err.Error() == "salary entry was modified concurrently" {
+					return err
+				}
+				return fmt.Errorf("failed to update raise percentages: %w", err)
+			}
+		}
+
+		return s.recordRevision(ctx, &model.EntryRevision{Action: model.RevisionActionUpdate, ChangedBy: userObjID}, existing, entry)
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	return nil
+	return entry, nil
 }
 
-func (s *salaryEntryService) AddRaise(ctx context.Context, userID, entryID string, req *model.CreateRaiseRequest) error {
+func (s *salaryEntryService) DeleteEntry(ctx context.Context, userID, entryID string) error {
+	ctx, span := tracing.Start(ctx, "SalaryEntryService.DeleteEntry")
+	defer span.End()
+
 	userObjID, err := primitive.ObjectIDFromHex(userID)
 	if err != nil {
 		return fmt.Errorf("invalid user ID: %w", err)
@@ -162,36 +287,82 @@ func (s *salaryEntryService) AddRaise(ctx context.Context, userID, entryID strin
 		return fmt.Errorf("invalid entry ID: %w", err)
 	}
 
-	raise := &model.Raise{
-		RaiseDate:  req.RaiseDate,
-		NewSalary:  req.NewSalary,
-		Percentage: req.Percentage,
+	existing, err := s.salaryRepo.GetByID(ctx, entryObjID, userObjID)
+	if err != nil {
+		return fmt.Errorf("failed to get salary entry: %w", err)
+	}
+	if existing == nil {
+		return fmt.Errorf("salary entry not found")
 	}
 
-	if err := s.salaryRepo.AddRaise(ctx, entryObjID, userObjID, raise); err != nil {
-		return fmt.Errorf("failed to add raise: %w", err)
+	err = s.transactor.WithTransaction(ctx, func(ctx context.Context) error {
+		if err := s.salaryRepo.Delete(ctx, entryObjID, userObjID); err != nil {
+			if err.Error() == "salary entry not found" {
+				return err
+			}
+			return fmt.Errorf("failed to delete salary entry: %w", err)
+		}
+		return s.recordRevision(ctx, &model.EntryRevision{Action: model.RevisionActionDelete, ChangedBy: userObjID}, existing, nil)
+	})
+	if err != nil {
+		return err
 	}
+	s.logger.InfoContext(ctx, "Salary entry deleted", "entry_id", entryID, "user_id", userID)
 
 	return nil
 }
 
-func (s *salaryEntryService) GetRaises(ctx context.Context, userID, entryID string) ([]model.Raise, error) {
-	userObjID, err := primitive.ObjectIDFromHex(userID)
+// entryCursor is the opaque cursor handed to clients. It carries the sort it
+// was issued for so a cursor cannot be replayed against a different ordering.
+type entryCursor struct {
+	SortBy    string    `json:"s"`
+	Order     string    `json:"o"`
+	StartTime time.Time `json:"t"`
+	SalaryMin int64     `json:"m"`
+	CreatedAt time.Time `json:"c"`
+	ID        string    `json:"id"`
+}
+
+func encodeEntryCursor(query *model.ListEntriesQuery, last *model.SalaryEntry) (string, error) {
+	data, err := json.Marshal(entryCursor{
+		SortBy:    query.SortBy,
+		Order:     query.Order,
+		StartTime: last.StartTime,
+		SalaryMin: last.SalaryMin,
+		CreatedAt: last.CreatedAt,
+		ID:        last.ID.Hex(),
+	})
 	if err != nil {
-		return nil, fmt.Errorf("invalid user ID: %w", err)
+		return "", fmt.Errorf("failed to encode cursor: %w", err)
 	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
 
-	entryObjID, err := primitive.ObjectIDFromHex(entryID)
+func decodeEntryCursor(query *model.ListEntriesQuery) (*repo.SalaryEntryCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(query.Cursor)
 	if err != nil {
-		return nil, fmt.Errorf("invalid entry ID: %w", err)
+		return nil, fmt.Errorf("invalid cursor")
+	}
+
+	var cursor entryCursor
+	if err := json.Unmarshal(data, &cursor); err != nil {
+		return nil, fmt.Errorf("invalid cursor")
+	}
+	if cursor.SortBy != query.SortBy || cursor.Order != query.Order {
+		return nil, fmt.Errorf("invalid cursor")
 	}
 
-	raises, err := s.salaryRepo.GetRaises(ctx, entryObjID, userObjID)
+	id, err := primitive.ObjectIDFromHex(cursor.ID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get raises: %w", err)
+		return nil, fmt.Errorf("invalid cursor")
 	}
 
-	return raises, nil
+	return &repo.SalaryEntryCursor{
+		StartTime: cursor.StartTime,
+		SalaryMin: cursor.SalaryMin,
+		CreatedAt: cursor.CreatedAt,
+		ID:        id,
+	}, nil
 }
 
 func parseSalaryRange(salaryRange string) (int64, *int64) {
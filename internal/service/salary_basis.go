@@ -0,0 +1,69 @@
+package service
+
+import (
+	"strings"
+	"time"
+
+	"github.com/eminsonlu/salystic/internal/model"
+	"github.com/eminsonlu/salystic/pkg/payroll"
+)
+
+// normalizeSalary converts a salary recorded on basis to the target basis,
+// keeping its currency. Gross and net are converted with the Turkish payroll
+// rules of year, so amounts in other currencies go through TRY and are left
+// out (false) when there is no exchange rate. Entries without a basis are net
+// monthly.
+func (s *AnalyticsService) normalizeSalary(amount float64, salaryCurrency, basis string, year int, target string) (float64, bool) {
+	if basis == "" {
+		basis = model.SalaryBasisNetMonthly
+	}
+	if basis == target {
+		return amount, true
+	}
+
+	monthly := amount
+	if isAnnualBasis(basis) {
+		monthly /= 12
+	}
+
+	if isGrossBasis(basis) != isGrossBasis(target) {
+		inTRY, ok := s.exchangeRates.Convert(monthly, salaryCurrency, "TRY")
+		if !ok {
+			return 0, false
+		}
+
+		rules := payroll.ClosestYear(year)
+		if isGrossBasis(target) {
+			inTRY = rules.GrossMonthly(inTRY)
+		} else {
+			inTRY = rules.NetMonthly(inTRY)
+		}
+
+		if monthly, ok = s.exchangeRates.Convert(inTRY, "TRY", salaryCurrency); !ok {
+			return 0, false
+		}
+	}
+
+	if isAnnualBasis(target) {
+		return monthly * 12, true
+	}
+	return monthly, true
+}
+
+// salaryYear is the year of a job's latest salary: the year of its last raise,
+// or of its start without raises.
+func salaryYear(startTime time.Time, raises []model.Raise) int {
+	sorted := chronologicalRaises(raises)
+	if len(sorted) == 0 {
+		return startTime.Year()
+	}
+	return sorted[len(sorted)-1].RaiseDate.Year()
+}
+
+func isGrossBasis(basis string) bool {
+	return strings.HasPrefix(basis, "gross_")
+}
+
+func isAnnualBasis(basis string) bool {
+	return strings.HasSuffix(basis, "_annual")
+}
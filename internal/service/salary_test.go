@@ -0,0 +1,87 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/eminsonlu/salystic/internal/model"
+	"github.com/eminsonlu/salystic/internal/repo"
+	"github.com/eminsonlu/salystic/pkg/logging"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func newMemorySalaryService() SalaryEntryService {
+	store := repo.NewMemoryStore()
+	return NewSalaryEntryService(repo.NewMemorySalaryEntryRepository(store), repo.NewMemoryEntryRevisionRepository(store), repo.NewMemoryTransactor(store), 30*24*time.Hour, logging.NewNop())
+}
+
+func TestSalaryEntryService_GetUserEntries_Paginates(t *testing.T) {
+	ctx := context.Background()
+	salaryService := newMemorySalaryService()
+	userID := primitive.NewObjectID().Hex()
+
+	for _, salary := range []int64{500, 100, 300, 200, 400} {
+		_, err := salaryService.CreateEntry(ctx, userID, &model.CreateSalaryEntryRequest{
+			Currency:   "TRY",
+			SalaryMin:  salary,
+			StartTime:  time.Now(),
+			Concurrent: true,
+		})
+		require.NoError(t, err)
+	}
+
+	query := &model.ListEntriesQuery{Limit: 2, SortBy: model.EntrySortSalary, Order: "desc"}
+
+	var salaries []int64
+	for pages := 0; ; pages++ {
+		require.Less(t, pages, 3)
+
+		page, err := salaryService.GetUserEntries(ctx, userID, query)
+		require.NoError(t, err)
+		for _, entry := range page.Entries {
+			salaries = append(salaries, entry.SalaryMin)
+		}
+		if !page.HasMore {
+			assert.Empty(t, page.NextCursor)
+			break
+		}
+		query.Cursor = page.NextCursor
+	}
+
+	assert.Equal(t, []int64{500, 400, 300, 200, 100}, salaries)
+}
+
+func TestSalaryEntryService_GetUserEntries_EmptyPage(t *testing.T) {
+	salaryService := newMemorySalaryService()
+
+	page, err := salaryService.GetUserEntries(context.Background(), primitive.NewObjectID().Hex(),
+		&model.ListEntriesQuery{Limit: 10, SortBy: model.EntrySortCreatedAt, Order: "desc"})
+	require.NoError(t, err)
+	assert.Equal(t, []*model.SalaryEntry{}, page.Entries)
+	assert.False(t, page.HasMore)
+}
+
+func TestSalaryEntryService_GetUserEntries_RejectsForeignCursor(t *testing.T) {
+	ctx := context.Background()
+	salaryService := newMemorySalaryService()
+	userID := primitive.NewObjectID().Hex()
+
+	for i := 0; i < 2; i++ {
+		_, err := salaryService.CreateEntry(ctx, userID, &model.CreateSalaryEntryRequest{Currency: "TRY", SalaryMin: 100, Concurrent: true})
+		require.NoError(t, err)
+	}
+
+	page, err := salaryService.GetUserEntries(ctx, userID, &model.ListEntriesQuery{Limit: 1, SortBy: model.EntrySortSalary, Order: "asc"})
+	require.NoError(t, err)
+	require.True(t, page.HasMore)
+
+	_, err = salaryService.GetUserEntries(ctx, userID, &model.ListEntriesQuery{Cursor: page.NextCursor, Limit: 1, SortBy: model.EntrySortStartTime, Order: "asc"})
+	assert.EqualError(t, err, "invalid cursor")
+
+	_, err = salaryService.GetUserEntries(ctx, userID, &model.ListEntriesQuery{Cursor: "not-a-cursor", Limit: 1, SortBy: model.EntrySortSalary, Order: "asc"})
+	assert.EqualError(t, err, "invalid cursor")
+}
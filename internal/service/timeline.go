@@ -0,0 +1,149 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/eminsonlu/salystic/internal/model"
+	"github.com/eminsonlu/salystic/pkg/tracing"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func (s *salaryEntryService) GetTimeline(ctx context.Context, userID string) (*model.Timeline, error) {
+	ctx, span := tracing.Start(ctx, "SalaryEntryService.GetTimeline")
+	defer span.End()
+
+	userObjID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	entries, err := s.salaryRepo.GetByUserID(ctx, userObjID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user entries: %w", err)
+	}
+
+	return buildTimeline(entries), nil
+}
+
+// validatePeriod rejects an end time before the start time and, unless one of
+// the two jobs is marked concurrent, a period overlapping another of the
+// user's jobs. excludeID is the entry being updated.
+func (s *salaryEntryService) validatePeriod(ctx context.Context, userID, excludeID primitive.ObjectID, startTime time.Time, endTime *time.Time, concurrent bool) error {
+	if endTime != nil && endTime.Before(startTime) {
+		return fmt.Errorf("end time must be after start time")
+	}
+	if concurrent {
+		return nil
+	}
+
+	entries, err := s.salaryRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to get user entries: %w", err)
+	}
+
+	for _, other := range entries {
+		if other.ID == excludeID || other.Concurrent {
+			continue
+		}
+		if periodsOverlap(startTime, endTime, other.StartTime, other.EndTime) {
+			return fmt.Errorf("entry overlaps with another job")
+		}
+	}
+	return nil
+}
+
+// periodsOverlap treats a missing end time as an ongoing job. A job may start
+// on the day the previous one ended.
+func periodsOverlap(startA time.Time, endA *time.Time, startB time.Time, endB *time.Time) bool {
+	aEndsBeforeB := endA != nil && !endA.After(startB)
+	bEndsBeforeA := endB != nil && !endB.After(startA)
+	return !aEndsBeforeB && !bEndsBeforeA
+}
+
+func buildTimeline(entries []*model.SalaryEntry) *model.Timeline {
+	sorted := append([]*model.SalaryEntry(nil), entries...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if !sorted[i].StartTime.Equal(sorted[j].StartTime) {
+			return sorted[i].StartTime.Before(sorted[j].StartTime)
+		}
+		return sorted[i].CreatedAt.Before(sorted[j].CreatedAt)
+	})
+
+	timeline := &model.Timeline{
+		Jobs:       make([]model.TimelineJob, len(sorted)),
+		JobChanges: []model.JobChange{},
+	}
+
+	var previous *model.TimelineJob
+	for i, entry := range sorted {
+		entry.Raises = chronologicalRaises(entry.Raises)
+
+		job := &timeline.Jobs[i]
+		job.SalaryEntry = entry
+		job.StartingSalary = entry.SalaryMin
+		job.FinalSalary = latestSalary(entry.SalaryMin, entry.Raises)
+
+		if entry.Concurrent {
+			continue
+		}
+
+		if previous != nil {
+			previousID, nextID := previous.ID, entry.ID
+			previous.NextJobID = &nextID
+			job.PreviousJobID = &previousID
+			timeline.JobChanges = append(timeline.JobChanges, newJobChange(previous, job))
+		}
+		previous = job
+	}
+
+	return timeline
+}
+
+func newJobChange(from, to *model.TimelineJob) model.JobChange {
+	change := model.JobChange{
+		FromEntryID:  from.ID,
+		ToEntryID:    to.ID,
+		ChangedAt:    to.StartTime,
+		FromSalary:   from.FinalSalary,
+		ToSalary:     to.StartingSalary,
+		FromCurrency: from.Currency,
+		ToCurrency:   to.Currency,
+	}
+
+	if from.EndTime != nil && to.StartTime.After(*from.EndTime) {
+		change.GapDays = int(to.StartTime.Sub(*from.EndTime).Hours() / 24)
+	}
+
+	if from.Currency == to.Currency {
+		increase := to.StartingSalary - from.FinalSalary
+		change.Increase = &increase
+		if from.FinalSalary > 0 {
+			percentage := roundToTwoDecimals(float64(increase) / float64(from.FinalSalary) * 100)
+			change.Percentage = &percentage
+		}
+	}
+
+	return change
+}
+
+// latestSalary is the salary after the latest raise, or the starting salary
+// when the job had none.
+func latestSalary(startingSalary int64, raises []model.Raise) int64 {
+	sorted := chronologicalRaises(raises)
+	if len(sorted) == 0 {
+		return startingSalary
+	}
+	return sorted[len(sorted)-1].NewSalary
+}
+
+func chronologicalRaises(raises []model.Raise) []model.Raise {
+	sorted := append([]model.Raise(nil), raises...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].RaiseDate.Before(sorted[j].RaiseDate)
+	})
+	return sorted
+}
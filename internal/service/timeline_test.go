@@ -0,0 +1,172 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/eminsonlu/salystic/internal/model"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func date(year int, month time.Month) time.Time {
+	return time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+}
+
+func TestBuildTimeline(t *testing.T) {
+	firstEnd, secondEnd := date(2021, 1), date(2022, 6)
+	first := &model.SalaryEntry{
+		ID: primitive.NewObjectID(), Currency: "TRY", SalaryMin: 100,
+		StartTime: date(2019, 1), EndTime: &firstEnd,
+		Raises: []model.Raise{
+			{RaiseDate: date(2020, 6), NewSalary: 130},
+			{RaiseDate: date(2020, 1), NewSalary: 110},
+		},
+	}
+	second := &model.SalaryEntry{
+		ID: primitive.NewObjectID(), Currency: "TRY", SalaryMin: 156,
+		StartTime: date(2021, 3), EndTime: &secondEnd,
+	}
+	freelance := &model.SalaryEntry{
+		ID: primitive.NewObjectID(), Currency: "USD", SalaryMin: 10,
+		StartTime: date(2021, 6), Concurrent: true,
+	}
+	third := &model.SalaryEntry{
+		ID: primitive.NewObjectID(), Currency: "USD", SalaryMin: 5,
+		StartTime: date(2022, 6),
+	}
+
+	timeline := buildTimeline([]*model.SalaryEntry{third, freelance, first, second})
+
+	require.Len(t, timeline.Jobs, 4)
+	assert.Equal(t, []primitive.ObjectID{first.ID, second.ID, freelance.ID, third.ID},
+		[]primitive.ObjectID{timeline.Jobs[0].ID, timeline.Jobs[1].ID, timeline.Jobs[2].ID, timeline.Jobs[3].ID})
+
+	assert.Nil(t, timeline.Jobs[0].PreviousJobID)
+	assert.Equal(t, &second.ID, timeline.Jobs[0].NextJobID)
+	assert.Equal(t, &first.ID, timeline.Jobs[1].PreviousJobID)
+	assert.Equal(t, &third.ID, timeline.Jobs[1].NextJobID)
+	assert.Nil(t, timeline.Jobs[2].PreviousJobID)
+	assert.Nil(t, timeline.Jobs[2].NextJobID)
+	assert.Equal(t, &second.ID, timeline.Jobs[3].PreviousJobID)
+	assert.Nil(t, timeline.Jobs[3].NextJobID)
+
+	assert.Equal(t, int64(100), timeline.Jobs[0].StartingSalary)
+	assert.Equal(t, int64(130), timeline.Jobs[0].FinalSalary)
+	assert.Equal(t, int64(110), timeline.Jobs[0].Raises[0].NewSalary)
+
+	require.Len(t, timeline.JobChanges, 2)
+
+	change := timeline.JobChanges[0]
+	assert.Equal(t, first.ID, change.FromEntryID)
+	assert.Equal(t, second.ID, change.ToEntryID)
+	assert.Equal(t, int64(130), change.FromSalary)
+	assert.Equal(t, int64(156), change.ToSalary)
+	assert.Equal(t, 59, change.GapDays)
+	require.NotNil(t, change.Increase)
+	assert.Equal(t, int64(26), *change.Increase)
+	require.NotNil(t, change.Percentage)
+	assert.Equal(t, 20.0, *change.Percentage)
+
+	currencyChange := timeline.JobChanges[1]
+	assert.Equal(t, "TRY", currencyChange.FromCurrency)
+	assert.Equal(t, "USD", currencyChange.ToCurrency)
+	assert.Zero(t, currencyChange.GapDays)
+	assert.Nil(t, currencyChange.Increase)
+	assert.Nil(t, currencyChange.Percentage)
+}
+
+func TestBuildTimeline_Empty(t *testing.T) {
+	timeline := buildTimeline(nil)
+	assert.Empty(t, timeline.Jobs)
+	assert.NotNil(t, timeline.JobChanges)
+}
+
+func TestPeriodsOverlap(t *testing.T) {
+	end2020, end2021 := date(2020, 1), date(2021, 1)
+
+	assert.False(t, periodsOverlap(date(2019, 1), &end2020, date(2020, 1), nil), "starting on the previous end date")
+	assert.True(t, periodsOverlap(date(2019, 1), &end2021, date(2020, 1), nil))
+	assert.True(t, periodsOverlap(date(2019, 1), nil, date(2020, 1), nil), "two ongoing jobs")
+	assert.False(t, periodsOverlap(date(2021, 6), nil, date(2019, 1), &end2021))
+}
+
+func TestSalaryEntryService_ValidatesPeriods(t *testing.T) {
+	ctx := context.Background()
+	salaryService := newMemorySalaryService()
+	userID := primitive.NewObjectID().Hex()
+
+	end := date(2021, 1)
+	first, err := salaryService.CreateEntry(ctx, userID, &model.CreateSalaryEntryRequest{
+		Currency: "TRY", SalaryMin: 100, StartTime: date(2019, 1), EndTime: &end,
+	})
+	require.NoError(t, err)
+
+	_, err = salaryService.CreateEntry(ctx, userID, &model.CreateSalaryEntryRequest{
+		Currency: "TRY", SalaryMin: 100, StartTime: date(2020, 1),
+	})
+	assert.EqualError(t, err, "entry overlaps with another job")
+
+	before := date(2018, 1)
+	_, err = salaryService.CreateEntry(ctx, userID, &model.CreateSalaryEntryRequest{
+		Currency: "TRY", SalaryMin: 100, StartTime: date(2022, 1), EndTime: &before,
+	})
+	assert.EqualError(t, err, "end time must be after start time")
+
+	_, err = salaryService.CreateEntry(ctx, userID, &model.CreateSalaryEntryRequest{
+		Currency: "USD", SalaryMin: 10, StartTime: date(2020, 1), Concurrent: true,
+	})
+	require.NoError(t, err)
+
+	second, err := salaryService.CreateEntry(ctx, userID, &model.CreateSalaryEntryRequest{
+		Currency: "TRY", SalaryMin: 150, StartTime: date(2021, 1),
+	})
+	require.NoError(t, err)
+
+	_, err = salaryService.CreateEntry(ctx, primitive.NewObjectID().Hex(), &model.CreateSalaryEntryRequest{
+		Currency: "TRY", SalaryMin: 100, StartTime: date(2020, 1),
+	})
+	require.NoError(t, err, "other users' jobs are not considered")
+
+	later := date(2021, 6)
+	_, err = salaryService.UpdateEntry(ctx, userID, first.ID.Hex(), &model.UpdateSalaryEntryRequest{EndTime: &later})
+	assert.EqualError(t, err, "entry overlaps with another job")
+
+	concurrent := true
+	_, err = salaryService.UpdateEntry(ctx, userID, first.ID.Hex(), &model.UpdateSalaryEntryRequest{EndTime: &later, Concurrent: &concurrent})
+	require.NoError(t, err)
+
+	earlier := date(2020, 1)
+	updated, err := salaryService.UpdateEntry(ctx, userID, second.ID.Hex(), &model.UpdateSalaryEntryRequest{StartTime: &earlier})
+	require.NoError(t, err, "the entry is not compared with itself and the others are concurrent")
+	assert.True(t, updated.StartTime.Equal(earlier))
+}
+
+func TestSalaryEntryService_GetTimeline(t *testing.T) {
+	ctx := context.Background()
+	salaryService := newMemorySalaryService()
+	userID := primitive.NewObjectID().Hex()
+
+	end := date(2021, 1)
+	first, err := salaryService.CreateEntry(ctx, userID, &model.CreateSalaryEntryRequest{
+		Currency: "TRY", SalaryMin: 100, StartTime: date(2019, 1), EndTime: &end,
+	})
+	require.NoError(t, err)
+	require.NoError(t, salaryService.AddRaise(ctx, userID, first.ID.Hex(), &model.CreateRaiseRequest{
+		RaiseDate: date(2020, 1), NewSalary: 120,
+	}))
+	_, err = salaryService.CreateEntry(ctx, userID, &model.CreateSalaryEntryRequest{
+		Currency: "TRY", SalaryMin: 180, StartTime: date(2021, 1),
+	})
+	require.NoError(t, err)
+
+	timeline, err := salaryService.GetTimeline(ctx, userID)
+	require.NoError(t, err)
+	require.Len(t, timeline.Jobs, 2)
+	require.Len(t, timeline.JobChanges, 1)
+	assert.Equal(t, int64(120), timeline.JobChanges[0].FromSalary)
+	assert.Equal(t, 50.0, *timeline.JobChanges[0].Percentage)
+}
@@ -0,0 +1,67 @@
+package currency
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Rates holds the value of one unit of each currency in a common base
+// currency, e.g. {"TRY": 1, "USD": 34}.
+type Rates map[string]float64
+
+func ParseRates(value string) (Rates, error) {
+	rates := Rates{}
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+
+		parts := strings.SplitN(item, "=", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("invalid exchange rate %q, expected <currency>=<rate>", item)
+		}
+
+		code := strings.ToUpper(strings.TrimSpace(parts[0]))
+		rate, err := strconv.ParseFloat(strings.TrimSpace(parts[1]), 64)
+		if err != nil || rate <= 0 {
+			return nil, fmt.Errorf("invalid exchange rate for %s: %q", code, parts[1])
+		}
+		rates[code] = rate
+	}
+	return rates, nil
+}
+
+// Convert converts amount from one currency to another. It reports false when
+// either currency has no rate.
+func (r Rates) Convert(amount float64, from, to string) (float64, bool) {
+	if from == to {
+		return amount, true
+	}
+
+	fromRate, ok := r[from]
+	if !ok {
+		return 0, false
+	}
+	toRate, ok := r[to]
+	if !ok {
+		return 0, false
+	}
+	return amount * fromRate / toRate, true
+}
+
+func (r Rates) String() string {
+	codes := make([]string, 0, len(r))
+	for code := range r {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+
+	items := make([]string, len(codes))
+	for i, code := range codes {
+		items[i] = code + "=" + strconv.FormatFloat(r[code], 'f', -1, 64)
+	}
+	return strings.Join(items, ",")
+}
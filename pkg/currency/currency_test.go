@@ -0,0 +1,39 @@
+package currency
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRates(t *testing.T) {
+	rates, err := ParseRates("TRY=1, usd=34.5,EUR=37")
+	require.NoError(t, err)
+	assert.Equal(t, Rates{"TRY": 1, "USD": 34.5, "EUR": 37}, rates)
+	assert.Equal(t, "EUR=37,TRY=1,USD=34.5", rates.String())
+
+	for _, value := range []string{"USD", "USD=abc", "USD=0", "USD=-1"} {
+		_, err := ParseRates(value)
+		assert.Error(t, err, value)
+	}
+}
+
+func TestRates_Convert(t *testing.T) {
+	rates := Rates{"TRY": 1, "USD": 40, "EUR": 50}
+
+	amount, ok := rates.Convert(100, "USD", "TRY")
+	assert.True(t, ok)
+	assert.Equal(t, 4000.0, amount)
+
+	amount, ok = rates.Convert(100, "EUR", "USD")
+	assert.True(t, ok)
+	assert.Equal(t, 125.0, amount)
+
+	amount, ok = rates.Convert(100, "JPY", "JPY")
+	assert.True(t, ok)
+	assert.Equal(t, 100.0, amount)
+
+	_, ok = rates.Convert(100, "JPY", "TRY")
+	assert.False(t, ok)
+}
@@ -0,0 +1,121 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+const (
+	StatusOK      = "ok"
+	StatusPending = "pending"
+	StatusFailed  = "failed"
+)
+
+type Check func(ctx context.Context) error
+
+type Task func(ctx context.Context) error
+
+type Report struct {
+	Ready  bool              `json:"ready"`
+	Checks map[string]string `json:"checks"`
+}
+
+type startupTask struct {
+	name   string
+	run    Task
+	status string
+}
+
+type Checker struct {
+	mu           sync.RWMutex
+	checks       map[string]Check
+	tasks        []*startupTask
+	checkTimeout time.Duration
+}
+
+func NewChecker(checkTimeout time.Duration) *Checker {
+	return &Checker{
+		checks:       make(map[string]Check),
+		checkTimeout: checkTimeout,
+	}
+}
+
+func (c *Checker) AddCheck(name string, check Check) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.checks[name] = check
+}
+
+func (c *Checker) AddStartupTask(name string, task Task) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.tasks = append(c.tasks, &startupTask{name: name, run: task, status: StatusPending})
+}
+
+func (c *Checker) RunStartupTasks(ctx context.Context) error {
+	c.mu.RLock()
+	tasks := append([]*startupTask(nil), c.tasks...)
+	c.mu.RUnlock()
+
+	for _, task := range tasks {
+		err := task.run(ctx)
+
+		c.mu.Lock()
+		if err != nil {
+			task.status = StatusFailed
+		} else {
+			task.status = StatusOK
+		}
+		c.mu.Unlock()
+
+		if err != nil {
+			return fmt.Errorf("startup task %s failed: %w", task.name, err)
+		}
+	}
+	return nil
+}
+
+func (c *Checker) Ready(ctx context.Context) Report {
+	c.mu.RLock()
+	checks := make(map[string]Check, len(c.checks))
+	for name, check := range c.checks {
+		checks[name] = check
+	}
+	report := Report{Ready: true, Checks: make(map[string]string, len(checks)+len(c.tasks))}
+	for _, task := range c.tasks {
+		report.Checks[task.name] = task.status
+		if task.status != StatusOK {
+			report.Ready = false
+		}
+	}
+	c.mu.RUnlock()
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for name, check := range checks {
+		wg.Add(1)
+		go func(name string, check Check) {
+			defer wg.Done()
+
+			checkCtx, cancel := context.WithTimeout(ctx, c.checkTimeout)
+			defer cancel()
+
+			status := StatusOK
+			if err := check(checkCtx); err != nil {
+				status = StatusFailed
+			}
+
+			mu.Lock()
+			report.Checks[name] = status
+			if status != StatusOK {
+				report.Ready = false
+			}
+			mu.Unlock()
+		}(name, check)
+	}
+	wg.Wait()
+
+	return report
+}
@@ -0,0 +1,54 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChecker_StartupTasksRunInOrderAndStopOnFailure(t *testing.T) {
+	checker := NewChecker(time.Second)
+
+	var order []string
+	checker.AddStartupTask("seed", func(ctx context.Context) error {
+		order = append(order, "seed")
+		return nil
+	})
+	checker.AddStartupTask("indexes", func(ctx context.Context) error {
+		order = append(order, "indexes")
+		return errors.New("duplicate key")
+	})
+	checker.AddStartupTask("warmup", func(ctx context.Context) error {
+		order = append(order, "warmup")
+		return nil
+	})
+
+	err := checker.RunStartupTasks(context.Background())
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "indexes")
+	assert.Equal(t, []string{"seed", "indexes"}, order)
+
+	report := checker.Ready(context.Background())
+	assert.False(t, report.Ready)
+	assert.Equal(t, StatusOK, report.Checks["seed"])
+	assert.Equal(t, StatusFailed, report.Checks["indexes"])
+	assert.Equal(t, StatusPending, report.Checks["warmup"])
+}
+
+func TestChecker_CheckTimeout(t *testing.T) {
+	checker := NewChecker(10 * time.Millisecond)
+	checker.AddCheck("slow", func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+
+	report := checker.Ready(context.Background())
+
+	assert.False(t, report.Ready)
+	assert.Equal(t, StatusFailed, report.Checks["slow"])
+}
@@ -0,0 +1,46 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew_AddsRequestIDFromContext(t *testing.T) {
+	var buf bytes.Buffer
+	logger, err := New(&buf, "info", "json")
+	require.NoError(t, err)
+
+	ctx := WithRequestID(context.Background(), "req-123")
+	logger.With("component", "test").InfoContext(ctx, "hello")
+
+	var line map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &line))
+	assert.Equal(t, "hello", line["msg"])
+	assert.Equal(t, "req-123", line[RequestIDKey])
+	assert.Equal(t, "test", line["component"])
+}
+
+func TestNew_RespectsLevel(t *testing.T) {
+	var buf bytes.Buffer
+	logger, err := New(&buf, "warn", "text")
+	require.NoError(t, err)
+
+	logger.Info("ignored")
+	logger.Warn("kept")
+
+	assert.NotContains(t, buf.String(), "ignored")
+	assert.Contains(t, buf.String(), "kept")
+}
+
+func TestNew_InvalidOptions(t *testing.T) {
+	_, err := New(&bytes.Buffer{}, "loud", "json")
+	assert.Error(t, err)
+
+	_, err = New(&bytes.Buffer{}, "info", "xml")
+	assert.Error(t, err)
+}
@@ -0,0 +1,215 @@
+package migrate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+	"time"
+)
+
+var ErrLocked = errors.New("migrations are locked by another process")
+
+type Migration struct {
+	Version     int64
+	Description string
+	Up          func(ctx context.Context) error
+	Down        func(ctx context.Context) error
+}
+
+type AppliedMigration struct {
+	Version     int64     `bson:"_id"`
+	Description string    `bson:"description"`
+	AppliedAt   time.Time `bson:"applied_at"`
+}
+
+type Status struct {
+	Version     int64      `json:"version"`
+	Description string     `json:"description"`
+	Applied     bool       `json:"applied"`
+	AppliedAt   *time.Time `json:"applied_at,omitempty"`
+}
+
+type Store interface {
+	Applied(ctx context.Context) (map[int64]AppliedMigration, error)
+	MarkApplied(ctx context.Context, migration AppliedMigration) error
+	MarkRolledBack(ctx context.Context, version int64) error
+	Lock(ctx context.Context, owner string, ttl time.Duration) (bool, error)
+	Unlock(ctx context.Context, owner string) error
+}
+
+type Options struct {
+	Owner        string
+	LockTTL      time.Duration
+	LockWait     time.Duration
+	PollInterval time.Duration
+}
+
+type Migrator struct {
+	store      Store
+	migrations []Migration
+	logger     *slog.Logger
+	opts       Options
+}
+
+func New(store Store, migrations []Migration, logger *slog.Logger, opts Options) (*Migrator, error) {
+	sorted := append([]Migration(nil), migrations...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Version < sorted[j].Version })
+
+	for i, migration := range sorted {
+		if migration.Version <= 0 {
+			return nil, fmt.Errorf("migration %q has invalid version %d", migration.Description, migration.Version)
+		}
+		if migration.Up == nil {
+			return nil, fmt.Errorf("migration %d has no up function", migration.Version)
+		}
+		if i > 0 && sorted[i-1].Version == migration.Version {
+			return nil, fmt.Errorf("duplicate migration version %d", migration.Version)
+		}
+	}
+
+	if opts.LockTTL <= 0 {
+		opts.LockTTL = 10 * time.Minute
+	}
+	if opts.PollInterval <= 0 {
+		opts.PollInterval = time.Second
+	}
+
+	return &Migrator{
+		store:      store,
+		migrations: sorted,
+		logger:     logger,
+		opts:       opts,
+	}, nil
+}
+
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	applied, err := m.store.Applied(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load applied migrations: %w", err)
+	}
+
+	statuses := make([]Status, 0, len(m.migrations))
+	for _, migration := range m.migrations {
+		status := Status{
+			Version:     migration.Version,
+			Description: migration.Description,
+		}
+		if record, ok := applied[migration.Version]; ok {
+			appliedAt := record.AppliedAt
+			status.Applied = true
+			status.AppliedAt = &appliedAt
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+func (m *Migrator) Pending(ctx context.Context) ([]Migration, error) {
+	applied, err := m.store.Applied(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load applied migrations: %w", err)
+	}
+
+	var pending []Migration
+	for _, migration := range m.migrations {
+		if _, ok := applied[migration.Version]; !ok {
+			pending = append(pending, migration)
+		}
+	}
+	return pending, nil
+}
+
+func (m *Migrator) Up(ctx context.Context) (int, error) {
+	count := 0
+	err := m.withLock(ctx, func() error {
+		pending, err := m.Pending(ctx)
+		if err != nil {
+			return err
+		}
+
+		for _, migration := range pending {
+			m.logger.InfoContext(ctx, "Applying migration", "version", migration.Version, "description", migration.Description)
+
+			if err := migration.Up(ctx); err != nil {
+				return fmt.Errorf("migration %d (%s) failed: %w", migration.Version, migration.Description, err)
+			}
+
+			if err := m.store.MarkApplied(ctx, AppliedMigration{
+				Version:     migration.Version,
+				Description: migration.Description,
+				AppliedAt:   time.Now().UTC(),
+			}); err != nil {
+				return fmt.Errorf("failed to record migration %d: %w", migration.Version, err)
+			}
+			count++
+		}
+		return nil
+	})
+	return count, err
+}
+
+func (m *Migrator) Down(ctx context.Context, steps int) (int, error) {
+	count := 0
+	err := m.withLock(ctx, func() error {
+		applied, err := m.store.Applied(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to load applied migrations: %w", err)
+		}
+
+		for i := len(m.migrations) - 1; i >= 0 && count < steps; i-- {
+			migration := m.migrations[i]
+			if _, ok := applied[migration.Version]; !ok {
+				continue
+			}
+			if migration.Down == nil {
+				return fmt.Errorf("migration %d (%s) cannot be rolled back", migration.Version, migration.Description)
+			}
+
+			m.logger.InfoContext(ctx, "Rolling back migration", "version", migration.Version, "description", migration.Description)
+
+			if err := migration.Down(ctx); err != nil {
+				return fmt.Errorf("rollback of migration %d (%s) failed: %w", migration.Version, migration.Description, err)
+			}
+
+			if err := m.store.MarkRolledBack(ctx, migration.Version); err != nil {
+				return fmt.Errorf("failed to record rollback of migration %d: %w", migration.Version, err)
+			}
+			count++
+		}
+		return nil
+	})
+	return count, err
+}
+
+func (m *Migrator) withLock(ctx context.Context, fn func() error) error {
+	deadline := time.Now().Add(m.opts.LockWait)
+	for {
+		locked, err := m.store.Lock(ctx, m.opts.Owner, m.opts.LockTTL)
+		if err != nil {
+			return fmt.Errorf("failed to acquire migration lock: %w", err)
+		}
+		if locked {
+			break
+		}
+		if !time.Now().Before(deadline) {
+			return ErrLocked
+		}
+
+		m.logger.InfoContext(ctx, "Waiting for migration lock")
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(m.opts.PollInterval):
+		}
+	}
+
+	defer func() {
+		if err := m.store.Unlock(context.WithoutCancel(ctx), m.opts.Owner); err != nil {
+			m.logger.ErrorContext(ctx, "Failed to release migration lock", "error", err)
+		}
+	}()
+
+	return fn()
+}
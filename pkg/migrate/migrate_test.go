@@ -0,0 +1,227 @@
+package migrate
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/eminsonlu/salystic/pkg/logging"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type memoryStore struct {
+	mu        sync.Mutex
+	applied   map[int64]AppliedMigration
+	lockOwner string
+	unlocks   int
+}
+
+func newMemoryStore() *memoryStore {
+	return &memoryStore{applied: make(map[int64]AppliedMigration)}
+}
+
+func (s *memoryStore) Applied(_ context.Context) (map[int64]AppliedMigration, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	applied := make(map[int64]AppliedMigration, len(s.applied))
+	for version, record := range s.applied {
+		applied[version] = record
+	}
+	return applied, nil
+}
+
+func (s *memoryStore) MarkApplied(_ context.Context, migration AppliedMigration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.applied[migration.Version] = migration
+	return nil
+}
+
+func (s *memoryStore) MarkRolledBack(_ context.Context, version int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.applied, version)
+	return nil
+}
+
+func (s *memoryStore) Lock(_ context.Context, owner string, _ time.Duration) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.lockOwner != "" && s.lockOwner != owner {
+		return false, nil
+	}
+	s.lockOwner = owner
+	return true, nil
+}
+
+func (s *memoryStore) Unlock(_ context.Context, owner string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.lockOwner == owner {
+		s.lockOwner = ""
+	}
+	s.unlocks++
+	return nil
+}
+
+func recordingMigration(version int64, calls *[]string) Migration {
+	return Migration{
+		Version:     version,
+		Description: "test",
+		Up: func(context.Context) error {
+			*calls = append(*calls, "up")
+			return nil
+		},
+		Down: func(context.Context) error {
+			*calls = append(*calls, "down")
+			return nil
+		},
+	}
+}
+
+func TestNew_RejectsInvalidMigrations(t *testing.T) {
+	noop := func(context.Context) error { return nil }
+
+	_, err := New(newMemoryStore(), []Migration{{Version: 1, Up: noop}, {Version: 1, Up: noop}}, logging.NewNop(), Options{})
+	assert.ErrorContains(t, err, "duplicate migration version 1")
+
+	_, err = New(newMemoryStore(), []Migration{{Version: 0, Up: noop}}, logging.NewNop(), Options{})
+	assert.ErrorContains(t, err, "invalid version")
+
+	_, err = New(newMemoryStore(), []Migration{{Version: 1}}, logging.NewNop(), Options{})
+	assert.ErrorContains(t, err, "no up function")
+}
+
+func TestUp_AppliesPendingInVersionOrder(t *testing.T) {
+	store := newMemoryStore()
+	var order []int64
+	migration := func(version int64) Migration {
+		return Migration{Version: version, Up: func(context.Context) error {
+			order = append(order, version)
+			return nil
+		}}
+	}
+
+	m, err := New(store, []Migration{migration(3), migration(1), migration(2)}, logging.NewNop(), Options{Owner: "a"})
+	require.NoError(t, err)
+
+	count, err := m.Up(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 3, count)
+	assert.Equal(t, []int64{1, 2, 3}, order)
+	assert.Len(t, store.applied, 3)
+	assert.Equal(t, 1, store.unlocks)
+
+	count, err = m.Up(context.Background())
+	require.NoError(t, err)
+	assert.Zero(t, count)
+	assert.Equal(t, []int64{1, 2, 3}, order)
+}
+
+func TestUp_StopsAtFailedMigration(t *testing.T) {
+	store := newMemoryStore()
+	var calls []string
+	failing := Migration{Version: 2, Up: func(context.Context) error { return errors.New("boom") }}
+
+	m, err := New(store, []Migration{recordingMigration(1, &calls), failing, recordingMigration(3, &calls)}, logging.NewNop(), Options{Owner: "a"})
+	require.NoError(t, err)
+
+	count, err := m.Up(context.Background())
+	assert.ErrorContains(t, err, "migration 2")
+	assert.Equal(t, 1, count)
+	assert.Equal(t, []string{"up"}, calls)
+	assert.Contains(t, store.applied, int64(1))
+	assert.NotContains(t, store.applied, int64(2))
+	assert.Empty(t, store.lockOwner)
+}
+
+func TestDown_RollsBackMostRecentFirst(t *testing.T) {
+	store := newMemoryStore()
+	var order []int64
+	migration := func(version int64) Migration {
+		return Migration{
+			Version: version,
+			Up:      func(context.Context) error { return nil },
+			Down: func(context.Context) error {
+				order = append(order, version)
+				return nil
+			},
+		}
+	}
+
+	m, err := New(store, []Migration{migration(1), migration(2), migration(3)}, logging.NewNop(), Options{Owner: "a"})
+	require.NoError(t, err)
+	_, err = m.Up(context.Background())
+	require.NoError(t, err)
+
+	count, err := m.Down(context.Background(), 2)
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+	assert.Equal(t, []int64{3, 2}, order)
+
+	statuses, err := m.Status(context.Background())
+	require.NoError(t, err)
+	require.Len(t, statuses, 3)
+	assert.True(t, statuses[0].Applied)
+	assert.NotNil(t, statuses[0].AppliedAt)
+	assert.False(t, statuses[1].Applied)
+	assert.False(t, statuses[2].Applied)
+}
+
+func TestDown_WithoutDownFunction(t *testing.T) {
+	store := newMemoryStore()
+	m, err := New(store, []Migration{{Version: 1, Up: func(context.Context) error { return nil }}}, logging.NewNop(), Options{Owner: "a"})
+	require.NoError(t, err)
+	_, err = m.Up(context.Background())
+	require.NoError(t, err)
+
+	_, err = m.Down(context.Background(), 1)
+	assert.ErrorContains(t, err, "cannot be rolled back")
+	assert.Contains(t, store.applied, int64(1))
+}
+
+func TestUp_LockHeldByAnotherOwner(t *testing.T) {
+	store := newMemoryStore()
+	store.lockOwner = "other-replica"
+	var calls []string
+
+	m, err := New(store, []Migration{recordingMigration(1, &calls)}, logging.NewNop(), Options{
+		Owner:        "a",
+		LockWait:     20 * time.Millisecond,
+		PollInterval: 5 * time.Millisecond,
+	})
+	require.NoError(t, err)
+
+	_, err = m.Up(context.Background())
+	assert.ErrorIs(t, err, ErrLocked)
+	assert.Empty(t, calls)
+	assert.Equal(t, "other-replica", store.lockOwner)
+}
+
+func TestUp_WaitsForLockRelease(t *testing.T) {
+	store := newMemoryStore()
+	store.lockOwner = "other-replica"
+	var calls []string
+
+	m, err := New(store, []Migration{recordingMigration(1, &calls)}, logging.NewNop(), Options{
+		Owner:        "a",
+		LockWait:     time.Second,
+		PollInterval: 5 * time.Millisecond,
+	})
+	require.NoError(t, err)
+
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		store.Unlock(context.Background(), "other-replica")
+	}()
+
+	count, err := m.Up(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+	assert.Equal(t, []string{"up"}, calls)
+}
@@ -0,0 +1,91 @@
+package migrate
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	migrationsCollection = "schema_migrations"
+	lockCollection       = "schema_migrations_lock"
+	lockID               = "schema_migrations"
+)
+
+type MongoStore struct {
+	migrations *mongo.Collection
+	locks      *mongo.Collection
+}
+
+func NewMongoStore(db *mongo.Database) *MongoStore {
+	return &MongoStore{
+		migrations: db.Collection(migrationsCollection),
+		locks:      db.Collection(lockCollection),
+	}
+}
+
+func (s *MongoStore) Applied(ctx context.Context) (map[int64]AppliedMigration, error) {
+	cursor, err := s.migrations.Find(ctx, bson.M{})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var records []AppliedMigration
+	if err := cursor.All(ctx, &records); err != nil {
+		return nil, err
+	}
+
+	applied := make(map[int64]AppliedMigration, len(records))
+	for _, record := range records {
+		applied[record.Version] = record
+	}
+	return applied, nil
+}
+
+func (s *MongoStore) MarkApplied(ctx context.Context, migration AppliedMigration) error {
+	_, err := s.migrations.InsertOne(ctx, migration)
+	return err
+}
+
+func (s *MongoStore) MarkRolledBack(ctx context.Context, version int64) error {
+	_, err := s.migrations.DeleteOne(ctx, bson.M{"_id": version})
+	return err
+}
+
+func (s *MongoStore) Lock(ctx context.Context, owner string, ttl time.Duration) (bool, error) {
+	now := time.Now().UTC()
+
+	filter := bson.M{
+		"_id": lockID,
+		"$or": bson.A{
+			bson.M{"owner": owner},
+			bson.M{"expires_at": bson.M{"$lte": now}},
+		},
+	}
+	update := bson.M{
+		"$set": bson.M{
+			"owner":      owner,
+			"locked_at":  now,
+			"expires_at": now.Add(ttl),
+		},
+	}
+
+	_, err := s.locks.UpdateOne(ctx, filter, update, options.Update().SetUpsert(true))
+	if mongo.IsDuplicateKeyError(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to lock migrations: %w", err)
+	}
+	return true, nil
+}
+
+func (s *MongoStore) Unlock(ctx context.Context, owner string) error {
+	_, err := s.locks.DeleteOne(ctx, bson.M{"_id": lockID, "owner": owner})
+	return err
+}
@@ -0,0 +1,97 @@
+package migrate
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const postgresSchema = `
+CREATE TABLE IF NOT EXISTS schema_migrations (
+	version     BIGINT PRIMARY KEY,
+	description TEXT NOT NULL,
+	applied_at  TIMESTAMPTZ NOT NULL
+);
+CREATE TABLE IF NOT EXISTS schema_migrations_lock (
+	id         TEXT PRIMARY KEY,
+	owner      TEXT NOT NULL,
+	locked_at  TIMESTAMPTZ NOT NULL,
+	expires_at TIMESTAMPTZ NOT NULL
+);`
+
+type PostgresStore struct {
+	pool *pgxpool.Pool
+}
+
+func NewPostgresStore(pool *pgxpool.Pool) *PostgresStore {
+	return &PostgresStore{pool: pool}
+}
+
+func (s *PostgresStore) ensureTables(ctx context.Context) error {
+	if _, err := s.pool.Exec(ctx, postgresSchema); err != nil {
+		return fmt.Errorf("failed to create migration tables: %w", err)
+	}
+	return nil
+}
+
+func (s *PostgresStore) Applied(ctx context.Context) (map[int64]AppliedMigration, error) {
+	if err := s.ensureTables(ctx); err != nil {
+		return nil, err
+	}
+
+	rows, err := s.pool.Query(ctx, `SELECT version, description, applied_at FROM schema_migrations`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	applied := make(map[int64]AppliedMigration)
+	for rows.Next() {
+		var record AppliedMigration
+		if err := rows.Scan(&record.Version, &record.Description, &record.AppliedAt); err != nil {
+			return nil, err
+		}
+		applied[record.Version] = record
+	}
+	return applied, rows.Err()
+}
+
+func (s *PostgresStore) MarkApplied(ctx context.Context, migration AppliedMigration) error {
+	_, err := s.pool.Exec(ctx,
+		`INSERT INTO schema_migrations (version, description, applied_at) VALUES ($1, $2, $3)`,
+		migration.Version, migration.Description, migration.AppliedAt,
+	)
+	return err
+}
+
+func (s *PostgresStore) MarkRolledBack(ctx context.Context, version int64) error {
+	_, err := s.pool.Exec(ctx, `DELETE FROM schema_migrations WHERE version = $1`, version)
+	return err
+}
+
+func (s *PostgresStore) Lock(ctx context.Context, owner string, ttl time.Duration) (bool, error) {
+	if err := s.ensureTables(ctx); err != nil {
+		return false, err
+	}
+
+	now := time.Now().UTC()
+	tag, err := s.pool.Exec(ctx, `
+		INSERT INTO schema_migrations_lock (id, owner, locked_at, expires_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (id) DO UPDATE
+		SET owner = EXCLUDED.owner, locked_at = EXCLUDED.locked_at, expires_at = EXCLUDED.expires_at
+		WHERE schema_migrations_lock.owner = EXCLUDED.owner OR schema_migrations_lock.expires_at <= EXCLUDED.locked_at`,
+		lockID, owner, now, now.Add(ttl),
+	)
+	if err != nil {
+		return false, fmt.Errorf("failed to lock migrations: %w", err)
+	}
+	return tag.RowsAffected() == 1, nil
+}
+
+func (s *PostgresStore) Unlock(ctx context.Context, owner string) error {
+	_, err := s.pool.Exec(ctx, `DELETE FROM schema_migrations_lock WHERE id = $1 AND owner = $2`, lockID, owner)
+	return err
+}
@@ -0,0 +1,201 @@
+// Package payroll converts between gross and net wages under Turkish payroll
+// rules: SGK and unemployment insurance contributions, income tax on the
+// cumulative yearly base and stamp tax, with the minimum wage exemption.
+package payroll
+
+import (
+	"math"
+	"sort"
+)
+
+const (
+	SGKRate          = 0.14
+	UnemploymentRate = 0.01
+	StampTaxRate     = 0.00759
+
+	// sgkCeilingMultiple caps the monthly contribution base at this many
+	// gross minimum wages.
+	sgkCeilingMultiple = 7.5
+)
+
+// Bracket taxes the part of the cumulative yearly base up to UpTo at Rate.
+// The last bracket has no upper limit.
+type Bracket struct {
+	UpTo float64
+	Rate float64
+}
+
+// Rules are the parameters of one year.
+type Rules struct {
+	Year        int
+	MinimumWage float64 // gross monthly
+	Brackets    []Bracket
+}
+
+var rulesByYear = map[int]Rules{
+	2024: {
+		Year:        2024,
+		MinimumWage: 20002.50,
+		Brackets: []Bracket{
+			{UpTo: 110000, Rate: 0.15},
+			{UpTo: 230000, Rate: 0.20},
+			{UpTo: 870000, Rate: 0.27},
+			{UpTo: 3000000, Rate: 0.35},
+			{UpTo: math.Inf(1), Rate: 0.40},
+		},
+	},
+	2025: {
+		Year:        2025,
+		MinimumWage: 26005.50,
+		Brackets: []Bracket{
+			{UpTo: 158000, Rate: 0.15},
+			{UpTo: 330000, Rate: 0.20},
+			{UpTo: 1200000, Rate: 0.27},
+			{UpTo: 4300000, Rate: 0.35},
+			{UpTo: math.Inf(1), Rate: 0.40},
+		},
+	},
+	2026: {
+		Year:        2026,
+		MinimumWage: 33030.00,
+		Brackets: []Bracket{
+			{UpTo: 190000, Rate: 0.15},
+			{UpTo: 400000, Rate: 0.20},
+			{UpTo: 1500000, Rate: 0.27},
+			{UpTo: 5300000, Rate: 0.35},
+			{UpTo: math.Inf(1), Rate: 0.40},
+		},
+	},
+}
+
+// Years lists the years with known rules in ascending order.
+func Years() []int {
+	years := make([]int, 0, len(rulesByYear))
+	for year := range rulesByYear {
+		years = append(years, year)
+	}
+	sort.Ints(years)
+	return years
+}
+
+func ForYear(year int) (Rules, bool) {
+	rules, ok := rulesByYear[year]
+	return rules, ok
+}
+
+// ClosestYear returns the rules of year, or of the nearest year with known
+// rules when it has none.
+func ClosestYear(year int) Rules {
+	years := Years()
+	switch {
+	case year <= years[0]:
+		return rulesByYear[years[0]]
+	case year >= years[len(years)-1]:
+		return rulesByYear[years[len(years)-1]]
+	}
+	for _, known := range years {
+		if known >= year {
+			return rulesByYear[known]
+		}
+	}
+	return rulesByYear[years[len(years)-1]]
+}
+
+// Month is the payroll of one month.
+type Month struct {
+	Gross        float64
+	SGK          float64
+	Unemployment float64
+	IncomeTax    float64
+	StampTax     float64
+	Net          float64
+}
+
+// Payroll is a year of monthly payrolls for the same gross wage. Income tax
+// grows during the year as the cumulative base reaches higher brackets.
+type Payroll struct {
+	Months [12]Month
+	Gross  float64
+	Net    float64
+}
+
+// Calculate runs a year of payroll for a gross monthly wage.
+func (r Rules) Calculate(grossMonthly float64) Payroll {
+	var (
+		payroll            Payroll
+		cumulativeBase     float64
+		cumulativeMinBase  float64
+		minimumWageTaxBase = r.MinimumWage * (1 - SGKRate - UnemploymentRate)
+	)
+
+	for i := range payroll.Months {
+		contributionBase := math.Min(grossMonthly, r.MinimumWage*sgkCeilingMultiple)
+		month := Month{
+			Gross:        grossMonthly,
+			SGK:          roundCents(contributionBase * SGKRate),
+			Unemployment: roundCents(contributionBase * UnemploymentRate),
+		}
+
+		taxBase := grossMonthly - month.SGK - month.Unemployment
+		incomeTax := r.tax(cumulativeBase+taxBase) - r.tax(cumulativeBase)
+		exemption := r.tax(cumulativeMinBase+minimumWageTaxBase) - r.tax(cumulativeMinBase)
+		cumulativeBase += taxBase
+		cumulativeMinBase += minimumWageTaxBase
+
+		month.IncomeTax = roundCents(math.Max(0, incomeTax-exemption))
+		month.StampTax = roundCents(math.Max(0, grossMonthly-r.MinimumWage) * StampTaxRate)
+		month.Net = roundCents(month.Gross - month.SGK - month.Unemployment - month.IncomeTax - month.StampTax)
+
+		payroll.Months[i] = month
+		payroll.Gross += month.Gross
+		payroll.Net += month.Net
+	}
+
+	payroll.Gross = roundCents(payroll.Gross)
+	payroll.Net = roundCents(payroll.Net)
+	return payroll
+}
+
+// NetMonthly is the average monthly net wage over the year.
+func (r Rules) NetMonthly(grossMonthly float64) float64 {
+	return roundCents(r.Calculate(grossMonthly).Net / 12)
+}
+
+// GrossMonthly is the gross monthly wage whose average monthly net over the
+// year is netMonthly.
+func (r Rules) GrossMonthly(netMonthly float64) float64 {
+	if netMonthly <= 0 {
+		return 0
+	}
+
+	low, high := netMonthly, netMonthly*2
+	for r.NetMonthly(high) < netMonthly {
+		high *= 2
+	}
+	for high-low > 0.005 {
+		mid := (low + high) / 2
+		if r.NetMonthly(mid) < netMonthly {
+			low = mid
+		} else {
+			high = mid
+		}
+	}
+	return roundCents(high)
+}
+
+// tax is the income tax on a cumulative yearly base.
+func (r Rules) tax(base float64) float64 {
+	var tax, lower float64
+	for _, bracket := range r.Brackets {
+		if base <= lower {
+			break
+		}
+		tax += (math.Min(base, bracket.UpTo) - lower) * bracket.Rate
+		lower = bracket.UpTo
+	}
+	return tax
+}
+
+func roundCents(value float64) float64 {
+	return math.Round(value*100) / 100
+}
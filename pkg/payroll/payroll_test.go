@@ -0,0 +1,51 @@
+package payroll
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRules_CalculateMinimumWage(t *testing.T) {
+	for year, net := range map[int]float64{2024: 17002.12, 2025: 22104.67} {
+		rules, ok := ForYear(year)
+		require.True(t, ok)
+
+		payroll := rules.Calculate(rules.MinimumWage)
+		for _, month := range payroll.Months {
+			assert.Zero(t, month.IncomeTax, year)
+			assert.Zero(t, month.StampTax, year)
+			assert.Equal(t, net, month.Net, year)
+		}
+	}
+}
+
+func TestRules_Calculate(t *testing.T) {
+	rules, _ := ForYear(2025)
+	payroll := rules.Calculate(100000)
+
+	january := payroll.Months[0]
+	assert.Equal(t, 14000.0, january.SGK)
+	assert.Equal(t, 1000.0, january.Unemployment)
+	assert.Equal(t, 75004.08, january.Net)
+
+	// The cumulative base reaches higher brackets later in the year.
+	assert.Less(t, payroll.Months[11].Net, january.Net)
+	assert.Equal(t, 1200000.0, payroll.Gross)
+}
+
+func TestRules_GrossMonthly(t *testing.T) {
+	rules, _ := ForYear(2025)
+	for _, gross := range []float64{rules.MinimumWage, 50000, 250000} {
+		net := rules.NetMonthly(gross)
+		assert.InDelta(t, gross, rules.GrossMonthly(net), 0.05)
+	}
+	assert.Zero(t, rules.GrossMonthly(0))
+}
+
+func TestClosestYear(t *testing.T) {
+	assert.Equal(t, 2024, ClosestYear(2019).Year)
+	assert.Equal(t, 2025, ClosestYear(2025).Year)
+	assert.Equal(t, 2026, ClosestYear(2030).Year)
+}
@@ -0,0 +1,82 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+type window struct {
+	count   int
+	resetAt time.Time
+}
+
+type MemoryStore struct {
+	mu      sync.Mutex
+	windows map[string]*window
+	stop    chan struct{}
+	now     func() time.Time
+}
+
+func NewMemoryStore(cleanupInterval time.Duration) *MemoryStore {
+	s := &MemoryStore{
+		windows: make(map[string]*window),
+		stop:    make(chan struct{}),
+		now:     time.Now,
+	}
+	go s.cleanup(cleanupInterval)
+	return s
+}
+
+func (s *MemoryStore) Allow(ctx context.Context, key string, rule Rule) (Result, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	w, ok := s.windows[key]
+	if !ok || !now.Before(w.resetAt) {
+		w = &window{resetAt: now.Add(rule.Window)}
+		s.windows[key] = w
+	}
+
+	if w.count >= rule.Limit {
+		return Result{
+			Allowed:    false,
+			Limit:      rule.Limit,
+			Remaining:  0,
+			RetryAfter: w.resetAt.Sub(now),
+		}, nil
+	}
+
+	w.count++
+	return Result{
+		Allowed:   true,
+		Limit:     rule.Limit,
+		Remaining: rule.Limit - w.count,
+	}, nil
+}
+
+func (s *MemoryStore) Stop() {
+	close(s.stop)
+}
+
+func (s *MemoryStore) cleanup(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			s.mu.Lock()
+			now := s.now()
+			for key, w := range s.windows {
+				if !now.Before(w.resetAt) {
+					delete(s.windows, key)
+				}
+			}
+			s.mu.Unlock()
+		case <-s.stop:
+			return
+		}
+	}
+}
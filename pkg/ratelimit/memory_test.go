@@ -0,0 +1,57 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseRule(t *testing.T) {
+	rule, err := ParseRule("10/24h")
+
+	assert.NoError(t, err)
+	assert.Equal(t, 10, rule.Limit)
+	assert.Equal(t, 24*time.Hour, rule.Window)
+}
+
+func TestParseRule_Invalid(t *testing.T) {
+	for _, value := range []string{"", "10", "abc/1m", "10/abc", "0/1m", "10/0s"} {
+		_, err := ParseRule(value)
+		assert.Error(t, err, value)
+	}
+}
+
+func TestMemoryStore_Allow(t *testing.T) {
+	store := NewMemoryStore(time.Minute)
+	defer store.Stop()
+
+	now := time.Now()
+	store.now = func() time.Time { return now }
+
+	rule := Rule{Limit: 2, Window: time.Minute}
+	ctx := context.Background()
+
+	result, err := store.Allow(ctx, "ip:1", rule)
+	assert.NoError(t, err)
+	assert.True(t, result.Allowed)
+	assert.Equal(t, 1, result.Remaining)
+
+	result, _ = store.Allow(ctx, "ip:1", rule)
+	assert.True(t, result.Allowed)
+	assert.Equal(t, 0, result.Remaining)
+
+	now = now.Add(20 * time.Second)
+	result, _ = store.Allow(ctx, "ip:1", rule)
+	assert.False(t, result.Allowed)
+	assert.Equal(t, 40*time.Second, result.RetryAfter)
+
+	result, _ = store.Allow(ctx, "ip:2", rule)
+	assert.True(t, result.Allowed)
+
+	now = now.Add(40 * time.Second)
+	result, _ = store.Allow(ctx, "ip:1", rule)
+	assert.True(t, result.Allowed)
+	assert.Equal(t, 1, result.Remaining)
+}
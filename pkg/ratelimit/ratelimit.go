@@ -0,0 +1,44 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type Rule struct {
+	Limit  int
+	Window time.Duration
+}
+
+type Result struct {
+	Allowed    bool
+	Limit      int
+	Remaining  int
+	RetryAfter time.Duration
+}
+
+type Store interface {
+	Allow(ctx context.Context, key string, rule Rule) (Result, error)
+}
+
+func ParseRule(value string) (Rule, error) {
+	parts := strings.SplitN(value, "/", 2)
+	if len(parts) != 2 {
+		return Rule{}, fmt.Errorf("invalid rate limit rule %q, expected <limit>/<window>", value)
+	}
+
+	limit, err := strconv.Atoi(strings.TrimSpace(parts[0]))
+	if err != nil || limit <= 0 {
+		return Rule{}, fmt.Errorf("invalid rate limit %q", parts[0])
+	}
+
+	window, err := time.ParseDuration(strings.TrimSpace(parts[1]))
+	if err != nil || window <= 0 {
+		return Rule{}, fmt.Errorf("invalid rate limit window %q", parts[1])
+	}
+
+	return Rule{Limit: limit, Window: window}, nil
+}
@@ -7,16 +7,26 @@ import (
 )
 
 type Response struct {
-	Success bool        `json:"success"`
-	Message string      `json:"message,omitempty"`
-	Data    interface{} `json:"data,omitempty"`
-	Error   string      `json:"error,omitempty"`
+	Success    bool        `json:"success"`
+	Message    string      `json:"message,omitempty"`
+	Data       interface{} `json:"data,omitempty"`
+	Pagination *Pagination `json:"pagination,omitempty"`
+	Error      string      `json:"error,omitempty"`
+}
+
+type Pagination struct {
+	Limit      int    `json:"limit"`
+	HasMore    bool   `json:"has_more"`
+	NextCursor string `json:"next_cursor,omitempty"`
 }
 
 type HealthResponse struct {
-	Status   string `json:"status"`
-	Database string `json:"database"`
-	Version  string `json:"version"`
+	Status    string            `json:"status"`
+	Database  string            `json:"database,omitempty"`
+	Version   string            `json:"version"`
+	Commit    string            `json:"commit"`
+	BuildTime string            `json:"build_time"`
+	Checks    map[string]string `json:"checks,omitempty"`
 }
 
 func Success(c echo.Context, data interface{}) error {
@@ -26,6 +36,14 @@ func Success(c echo.Context, data interface{}) error {
 	})
 }
 
+func SuccessWithPagination(c echo.Context, data interface{}, pagination Pagination) error {
+	return c.JSON(http.StatusOK, Response{
+		Success:    true,
+		Data:       data,
+		Pagination: &pagination,
+	})
+}
+
 func SuccessWithMessage(c echo.Context, message string, data interface{}) error {
 	return c.JSON(http.StatusOK, Response{
 		Success: true,
@@ -49,10 +67,26 @@ func Unauthorized(c echo.Context, message string) error {
 	return Error(c, http.StatusUnauthorized, message)
 }
 
+func Forbidden(c echo.Context, message string) error {
+	return Error(c, http.StatusForbidden, message)
+}
+
 func NotFound(c echo.Context, message string) error {
 	return Error(c, http.StatusNotFound, message)
 }
 
+func TooManyRequests(c echo.Context, message string) error {
+	return Error(c, http.StatusTooManyRequests, message)
+}
+
 func InternalServerError(c echo.Context, message string) error {
 	return Error(c, http.StatusInternalServerError, message)
-}
\ No newline at end of file
+}
+
+func ServiceUnavailable(c echo.Context, message string, data interface{}) error {
+	return c.JSON(http.StatusServiceUnavailable, Response{
+		Success: false,
+		Error:   message,
+		Data:    data,
+	})
+}
@@ -0,0 +1,75 @@
+package tracing
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/event"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func useRecorder(t *testing.T) *tracetest.InMemoryExporter {
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(provider)
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+
+	return exporter
+}
+
+func TestMongoMonitor_CreatesChildSpans(t *testing.T) {
+	exporter := useRecorder(t)
+	monitor := MongoMonitor()
+
+	ctx, parent := Start(context.Background(), "AnalyticsRepo.GetCombinedAnalytics")
+
+	command, err := bson.Marshal(bson.D{{Key: "aggregate", Value: "salary_entries"}})
+	require.NoError(t, err)
+
+	monitor.Started(ctx, &event.CommandStartedEvent{
+		Command:      command,
+		DatabaseName: "salarydb",
+		CommandName:  "aggregate",
+		RequestID:    1,
+	})
+	monitor.Succeeded(ctx, &event.CommandSucceededEvent{
+		CommandFinishedEvent: event.CommandFinishedEvent{CommandName: "aggregate", RequestID: 1, Duration: time.Millisecond},
+	})
+
+	monitor.Started(ctx, &event.CommandStartedEvent{
+		Command:      command,
+		DatabaseName: "salarydb",
+		CommandName:  "aggregate",
+		RequestID:    2,
+	})
+	monitor.Failed(ctx, &event.CommandFailedEvent{
+		CommandFinishedEvent: event.CommandFinishedEvent{CommandName: "aggregate", RequestID: 2},
+		Failure:              "exceeded time limit",
+	})
+	parent.End()
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 3)
+
+	assert.Equal(t, "salary_entries.aggregate", spans[0].Name)
+	assert.Equal(t, parent.SpanContext().SpanID(), spans[0].Parent.SpanID())
+	assert.Equal(t, codes.Unset, spans[0].Status.Code)
+
+	assert.Equal(t, "salary_entries.aggregate", spans[1].Name)
+	assert.Equal(t, codes.Error, spans[1].Status.Code)
+	assert.Equal(t, "exceeded time limit", spans[1].Status.Description)
+}
+
+func TestSetup_RejectsUnknownExporter(t *testing.T) {
+	_, err := Setup(context.Background(), Options{Exporter: "zipkin"})
+	assert.Error(t, err)
+}